@@ -0,0 +1,63 @@
+package block_engine_relayer_client
+import(
+  "sync"
+  "time"
+
+  "github.com/scatkit/pumpdexer/solana"
+)
+
+// interestSet is a set of accounts or programs of interest. Keys expire unless the block engine
+// repeats them within ttl; a zero ttl keeps them forever.
+type interestSet struct{
+  mu   sync.RWMutex
+  keys map[solana.PublicKey]time.Time // last time the key was received
+}
+
+func newInterestSet() *interestSet{
+  return &interestSet{keys: make(map[solana.PublicKey]time.Time)}
+}
+
+// add merges an update into the set, drops the keys expired by now and returns the number of invalid keys
+// the update contained.
+func (s *interestSet) add(keys []string, now time.Time, ttl time.Duration) (invalid int){
+  s.mu.Lock()
+  defer s.mu.Unlock()
+  for _, key := range keys{
+    pubkey, err := solana.PublicKeyFromBase58(key)
+    if err != nil{
+      invalid++
+      continue
+    }
+    s.keys[pubkey] = now
+  }
+  if ttl != 0{
+    for key, seen := range s.keys{
+      if now.Sub(seen) > ttl{
+        delete(s.keys, key)
+      }
+    }
+  }
+  return invalid
+}
+
+func (s *interestSet) contains(key solana.PublicKey, now time.Time, ttl time.Duration) bool{
+  s.mu.RLock()
+  defer s.mu.RUnlock()
+  seen, ok := s.keys[key]
+  return ok && (ttl == 0 || now.Sub(seen) <= ttl)
+}
+
+// list returns the live keys and drops the expired ones.
+func (s *interestSet) list(now time.Time, ttl time.Duration) []solana.PublicKey{
+  s.mu.Lock()
+  defer s.mu.Unlock()
+  out := make([]solana.PublicKey, 0, len(s.keys))
+  for key, seen := range s.keys{
+    if ttl != 0 && now.Sub(seen) > ttl{
+      delete(s.keys, key)
+      continue
+    }
+    out = append(out, key)
+  }
+  return out
+}
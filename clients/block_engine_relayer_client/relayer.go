@@ -0,0 +1,268 @@
+package block_engine_relayer_client
+import(
+  "context"
+  "crypto/tls"
+  "errors"
+  "log/slog"
+  "time"
+
+  "github.com/scatkit/pumpdexer/solana"
+  "github.com/scatkit/gojito/pb"
+  "github.com/scatkit/gojito/pkg"
+  "github.com/scatkit/gojito/tracing"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/credentials"
+  "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ErrHeartbeatTimeout is returned when the block engine stops answering heartbeats on the expiring packet stream.
+var ErrHeartbeatTimeout = errors.New("block engine heartbeat timed out")
+
+// Client is the relayer side of the block engine: it learns which accounts and programs the block engine
+// is interested in and forwards the matching packets as expiring batches.
+type Client struct{
+  GrpcConn       *grpc.ClientConn
+  RelayerService jito_pb.BlockEngineRelayerClient
+  Auth           *pkg.AuthenticationService
+  ErrChan        chan error // ErrChan is used for dispatching errors from functions executed within goroutines.
+  Logger         *slog.Logger
+
+  HeartbeatInterval time.Duration // heartbeat rate on the expiring packet stream (default 500ms)
+  HeartbeatTimeout  time.Duration // the stream fails when the block engine doesn't answer heartbeats in time (default 1.5s)
+  ResubscribeDelay  time.Duration // delay before resubscribing to the interests after a failure (default 1s)
+  InterestTTL       time.Duration // accounts and programs not repeated by the block engine within InterestTTL are dropped (default 70s, as in jito-relayer; 0 keeps them)
+
+  accounts *interestSet
+  programs *interestSet
+}
+
+// New creates a block engine client authenticated with the relayer role.
+func New(
+  ctx context.Context,
+  grpcDialURL string,
+  privateKey solana.PrivateKey,
+  tlsConfig *tls.Config,
+  opts ...grpc.DialOption,
+) (*Client, error){
+  if tlsConfig != nil{
+    opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+  } else{
+    opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{})))
+  }
+  opts = append(opts, tracing.DialOption())
+
+  chErr := make(chan error)
+  conn, err := pkg.CreateAndObserveGRPCConn(ctx, chErr, grpcDialURL, opts...)
+  if err != nil{
+    return nil, err
+  }
+
+  authService := pkg.NewAuthenticationService(conn, privateKey)
+  if err = authService.AuthenticateAndRefresh(jito_pb.Role_RELAYER); err != nil{
+    return nil, err
+  }
+
+  c := NewWithConn(conn, authService)
+  c.ErrChan = chErr
+  return c, nil
+}
+
+// NewWithConn creates a client over an existing connection and authentication service.
+func NewWithConn(conn *grpc.ClientConn, auth *pkg.AuthenticationService) *Client{
+  return &Client{
+    GrpcConn:          conn,
+    RelayerService:    jito_pb.NewBlockEngineRelayerClient(conn),
+    Auth:              auth,
+    ErrChan:           make(chan error),
+    Logger:            pkg.NopLogger(),
+    HeartbeatInterval: 500 * time.Millisecond,
+    HeartbeatTimeout:  1500 * time.Millisecond,
+    ResubscribeDelay:  time.Second,
+    InterestTTL:       70 * time.Second,
+    accounts:          newInterestSet(),
+    programs:          newInterestSet(),
+  }
+}
+
+// SetLogger sets the structured logger used by the client and its authentication service.
+func (c *Client) SetLogger(logger *slog.Logger){
+  if logger == nil{
+    logger = pkg.NopLogger()
+  }
+  c.Logger = logger
+  c.Auth.Logger = logger
+}
+
+func (c *Client) Close() error{
+  return c.GrpcConn.Close()
+}
+
+// AccountsOfInterest returns the accounts the block engine currently wants packets for.
+func (c *Client) AccountsOfInterest() []solana.PublicKey{
+  return c.accounts.list(time.Now(), c.InterestTTL)
+}
+
+// ProgramsOfInterest returns the programs the block engine currently wants packets for.
+func (c *Client) ProgramsOfInterest() []solana.PublicKey{
+  return c.programs.list(time.Now(), c.InterestTTL)
+}
+
+// WatchInterests keeps the accounts and programs of interest up to date until ctx is done.
+// Failed subscriptions are logged and resubscribed after ResubscribeDelay.
+func (c *Client) WatchInterests(ctx context.Context, opts ...grpc.CallOption){
+  go c.watch(ctx, "accounts", c.accounts, func(ctx context.Context) (func() ([]string, error), error){
+    stream, err := c.RelayerService.SubscribeAccountsOfInterest(c.Auth.Context(ctx), &jito_pb.AccountsOfInterestRequest{}, opts...)
+    if err != nil{
+      return nil, err
+    }
+    return func() ([]string, error){
+      update, err := stream.Recv()
+      return update.GetAccounts(), err
+    }, nil
+  })
+  go c.watch(ctx, "programs", c.programs, func(ctx context.Context) (func() ([]string, error), error){
+    stream, err := c.RelayerService.SubscribeProgramsOfInterest(c.Auth.Context(ctx), &jito_pb.ProgramsOfInterestRequest{}, opts...)
+    if err != nil{
+      return nil, err
+    }
+    return func() ([]string, error){
+      update, err := stream.Recv()
+      return update.GetPrograms(), err
+    }, nil
+  })
+}
+
+// watch runs subscribe until ctx is done, merging every update into set.
+func (c *Client) watch(ctx context.Context, kind string, set *interestSet, subscribe func(context.Context) (func() ([]string, error), error)){
+  for{
+    recv, err := subscribe(ctx)
+    for err == nil{
+      var keys []string
+      if keys, err = recv(); err == nil{
+        if invalid := set.add(keys, time.Now(), c.InterestTTL); invalid > 0{
+          c.Logger.Warn("invalid keys of interest", slog.String("kind", kind), slog.Int("count", invalid))
+        }
+      }
+    }
+    if ctx.Err() != nil{
+      return
+    }
+    c.Logger.Warn("interest subscription failed, resubscribing", slog.String("kind", kind), slog.Any("error", err))
+    select{
+    case <-ctx.Done():
+      return
+    case <-time.After(c.ResubscribeDelay):
+    }
+  }
+}
+
+// Matches reports whether tx references an account or invokes a program of interest. Only the static account keys
+// are checked; accounts loaded from address lookup tables are not resolved.
+func (c *Client) Matches(tx *solana.Transaction) bool{
+  now := time.Now()
+  keys := tx.Message.AccountKeys
+  for _, key := range keys{
+    if c.accounts.contains(key, now, c.InterestTTL){
+      return true
+    }
+  }
+  for _, inst := range tx.Message.Instructions{
+    if int(inst.ProgramIDIndex) < len(keys) && c.programs.contains(keys[inst.ProgramIDIndex], now, c.InterestTTL){
+      return true
+    }
+  }
+  return false
+}
+
+// Filter returns the packets matching the interests. Discarded packets, simple vote transactions
+// and packets that don't decode to a transaction are dropped.
+func (c *Client) Filter(packets []*jito_pb.Packet) []*jito_pb.Packet{
+  var out []*jito_pb.Packet
+  for _, packet := range packets{
+    if flags := packet.GetMeta().GetFlags(); flags.GetDiscard() || flags.GetSimpleVoteTx(){
+      continue
+    }
+    tx, err := pkg.ConvertProtobufPacketToTransaction(packet)
+    if err != nil{
+      continue
+    }
+    if c.Matches(tx){
+      out = append(out, packet)
+    }
+  }
+  return out
+}
+
+// ForwardPackets opens an expiring packet stream and forwards the packets of source matching the interests,
+// each batch expiring after expiry. Heartbeats are sent every HeartbeatInterval and the stream fails with
+// ErrHeartbeatTimeout if the block engine doesn't answer within HeartbeatTimeout.
+// It returns nil once source is closed, or the error that ended the stream.
+func (c *Client) ForwardPackets(ctx context.Context, source <-chan *jito_pb.PacketBatch, expiry time.Duration, opts ...grpc.CallOption) error{
+  streamCtx, cancel := context.WithCancel(ctx)
+  defer cancel()
+
+  stream, err := c.RelayerService.StartExpiringPacketStream(c.Auth.Context(streamCtx), opts...)
+  if err != nil{
+    return err
+  }
+
+  heartbeats := make(chan struct{}, 1)
+  errs := make(chan error, 1)
+  go func(){
+    for{
+      resp, err := stream.Recv()
+      if err != nil{
+        errs <- err
+        return
+      }
+      if resp.GetHeartbeat() != nil{
+        select{
+        case heartbeats <- struct{}{}:
+        default:
+        }
+      }
+    }
+  }()
+
+  ticker := time.NewTicker(c.HeartbeatInterval)
+  defer ticker.Stop()
+  timeout := time.NewTimer(c.HeartbeatTimeout)
+  defer timeout.Stop()
+
+  var count uint64
+  for{
+    select{
+    case <-ctx.Done():
+      return ctx.Err()
+    case err = <-errs:
+      return err
+    case <-timeout.C:
+      return ErrHeartbeatTimeout
+    case <-heartbeats:
+      timeout.Reset(c.HeartbeatTimeout)
+    case <-ticker.C:
+      count++
+      err = stream.Send(&jito_pb.PacketBatchUpdate{Msg: &jito_pb.PacketBatchUpdate_Heartbeat{Heartbeat: &jito_pb.Heartbeat{Count: count}}})
+      if err != nil{
+        return err
+      }
+    case batch, ok := <-source:
+      if !ok{
+        return stream.CloseSend()
+      }
+      packets := c.Filter(batch.GetPackets())
+      if len(packets) == 0{
+        continue
+      }
+      err = stream.Send(&jito_pb.PacketBatchUpdate{Msg: &jito_pb.PacketBatchUpdate_Batches{Batches: &jito_pb.ExpiringPacketBatch{
+        Header:   &jito_pb.Header{Ts: timestamppb.Now()},
+        Batch:    &jito_pb.PacketBatch{Packets: packets},
+        ExpiryMs: uint32(expiry.Milliseconds()),
+      }}})
+      if err != nil{
+        return err
+      }
+    }
+  }
+}
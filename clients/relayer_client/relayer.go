@@ -0,0 +1,182 @@
+package relayer_client
+import(
+  "context"
+  "crypto/tls"
+  "errors"
+  "iter"
+  "log/slog"
+  "time"
+
+  "github.com/scatkit/pumpdexer/solana"
+  "github.com/scatkit/gojito/pb"
+  "github.com/scatkit/gojito/pkg"
+  "github.com/scatkit/gojito/tracing"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/credentials"
+)
+
+// ErrHeartbeatTimeout is reported when the relayer stops sending heartbeats on a packet subscription.
+var ErrHeartbeatTimeout = errors.New("relayer heartbeat timed out")
+
+// errStopped ends a subscription when the consumer stops iterating.
+var errStopped = errors.New("subscription stopped")
+
+type Client struct{
+  GrpcConn       *grpc.ClientConn
+  RelayerService jito_pb.RelayerClient
+  Auth           *pkg.AuthenticationService
+  ErrChan        chan error // ErrChan is used for dispatching errors from functions executed within goroutines.
+  Logger         *slog.Logger
+
+  // HeartbeatTimeout is how long a packet subscription may go without a heartbeat before it is restarted
+  // (default 1.5s, i.e. three missed heartbeats of a relayer sending one every 500ms).
+  HeartbeatTimeout time.Duration
+  ResubscribeDelay time.Duration // delay before resubscribing after a failed subscription (default 1s)
+}
+
+// New creates a relayer client authenticated with role, which is Role_VALIDATOR for validators
+// subscribing to the relayer's packets.
+func New(
+  ctx context.Context,
+  grpcDialURL string,
+  privateKey solana.PrivateKey,
+  role jito_pb.Role,
+  tlsConfig *tls.Config,
+  opts ...grpc.DialOption,
+) (*Client, error){
+  if tlsConfig != nil{
+    opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+  } else{
+    opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{})))
+  }
+  opts = append(opts, tracing.DialOption())
+
+  chErr := make(chan error)
+  conn, err := pkg.CreateAndObserveGRPCConn(ctx, chErr, grpcDialURL, opts...)
+  if err != nil{
+    return nil, err
+  }
+
+  authService := pkg.NewAuthenticationService(conn, privateKey)
+  if err = authService.AuthenticateAndRefresh(role); err != nil{
+    return nil, err
+  }
+
+  return &Client{
+    GrpcConn:         conn,
+    RelayerService:   jito_pb.NewRelayerClient(conn),
+    Auth:             authService,
+    ErrChan:          chErr,
+    Logger:           pkg.NopLogger(),
+    HeartbeatTimeout: 1500 * time.Millisecond,
+    ResubscribeDelay: time.Second,
+  }, nil
+}
+
+// SetLogger sets the structured logger used by the client and its authentication service.
+func (c *Client) SetLogger(logger *slog.Logger){
+  if logger == nil{
+    logger = pkg.NopLogger()
+  }
+  c.Logger = logger
+  c.Auth.Logger = logger
+}
+
+func (c *Client) Close() error{
+  return c.GrpcConn.Close()
+}
+
+// GetTpuConfigs returns the TPU and TPU forward sockets the relayer receives transactions on.
+func (c *Client) GetTpuConfigs(ctx context.Context, opts ...grpc.CallOption) (*jito_pb.GetTpuConfigsResponse, error){
+  return c.RelayerService.GetTpuConfigs(c.Auth.Context(ctx), &jito_pb.GetTpuConfigsRequest{}, opts...)
+}
+
+// PacketMessage is a message of a packet subscription: either a heartbeat or a batch of packets.
+type PacketMessage struct{
+  Timestamp time.Time // set by the relayer
+  Heartbeat *jito_pb.Heartbeat
+  Batch     *jito_pb.PacketBatch
+}
+
+func (m *PacketMessage) IsHeartbeat() bool{ return m.Heartbeat != nil }
+
+func newPacketMessage(resp *jito_pb.SubscribePacketsResponse) *PacketMessage{
+  msg := &PacketMessage{Heartbeat: resp.GetHeartbeat(), Batch: resp.GetBatch()}
+  if ts := resp.GetHeader().GetTs(); ts != nil{
+    msg.Timestamp = ts.AsTime()
+  }
+  return msg
+}
+
+// SubscribePackets iterates over the heartbeats and packet batches sent by the relayer until ctx is done or the
+// caller stops iterating. When the stream fails or no heartbeat arrives within HeartbeatTimeout, the error is
+// yielded and the client resubscribes after ResubscribeDelay.
+func (c *Client) SubscribePackets(ctx context.Context, opts ...grpc.CallOption) iter.Seq2[*PacketMessage, error]{
+  return func(yield func(*PacketMessage, error) bool){
+    for{
+      err := c.streamPackets(ctx, yield, opts...)
+      if errors.Is(err, errStopped) || ctx.Err() != nil{
+        return
+      }
+      c.Logger.Warn("relayer packet subscription failed, resubscribing", slog.Any("error", err))
+      if !yield(nil, err){
+        return
+      }
+      select{
+      case <-ctx.Done():
+        return
+      case <-time.After(c.ResubscribeDelay):
+      }
+    }
+  }
+}
+
+// streamPackets runs a single subscription until it fails or the consumer stops.
+func (c *Client) streamPackets(ctx context.Context, yield func(*PacketMessage, error) bool, opts ...grpc.CallOption) error{
+  subCtx, cancel := context.WithCancel(ctx)
+  defer cancel()
+
+  stream, err := c.RelayerService.SubscribePackets(c.Auth.Context(subCtx), &jito_pb.SubscribePacketsRequest{}, opts...)
+  if err != nil{
+    return err
+  }
+
+  messages := make(chan *jito_pb.SubscribePacketsResponse)
+  errs := make(chan error, 1)
+  go func(){
+    for{
+      resp, err := stream.Recv()
+      if err != nil{
+        errs <- err
+        return
+      }
+      select{
+      case messages <- resp:
+      case <-subCtx.Done():
+        return
+      }
+    }
+  }()
+
+  timer := time.NewTimer(c.HeartbeatTimeout)
+  defer timer.Stop()
+  for{
+    select{
+    case <-subCtx.Done():
+      return subCtx.Err()
+    case err = <-errs:
+      return err
+    case <-timer.C:
+      return ErrHeartbeatTimeout
+    case resp := <-messages:
+      msg := newPacketMessage(resp)
+      if msg.IsHeartbeat(){
+        timer.Reset(c.HeartbeatTimeout)
+      }
+      if !yield(msg, nil){
+        return errStopped
+      }
+    }
+  }
+}
@@ -5,12 +5,19 @@ import (
   "math/big"
   "fmt"
   "time"
+  "log/slog"
   
   "github.com/scatkit/pumpdexer/solana"
   "github.com/scatkit/pumpdexer/rpc"
   "github.com/scatkit/gojito/pb"
   "github.com/scatkit/gojito/pkg"
+  "github.com/scatkit/gojito/dedup"
+  "github.com/scatkit/gojito/journal"
+  "github.com/scatkit/gojito/metrics"
+  "github.com/scatkit/gojito/tracing"
   
+  "go.opentelemetry.io/otel/attribute"
+  "go.opentelemetry.io/otel/trace"
   "google.golang.org/grpc"
 )
 
@@ -60,78 +67,113 @@ type SimulatedBundleResponseStruct struct {
 	TransactionResult []TransactionResult `json:"transactionResults"`
 }
 
-// SimulateBundle is an RPC method that simulates a Jito bundle – exclusively available to Jito-Solana validator.
-func (cl *Client) SimulateBundle(ctx context.Context, bundleParams SimulateBundleParams, simulationConfigs SimulateBundleConfig) (*SimulatedBundleResponse, error) {
-	if len(bundleParams.EncodedTransactions) != len(simulationConfigs.PreExecutionAccountsConfigs) {
-		return nil, errors.New("pre/post execution account config length must match bundle length")
-	}
-	var out SimulatedBundleResponse
-  params := []interface{}{
-    bundleParams, 
-    simulationConfigs,
+// BroadcastBundleWithConfirmation sends a bundle of transactions on chain thru Jito BlockEngine and waits for its confirmation.
+func (cl *Client) BroadcastBundleWithConfirmation(ctx context.Context, transactions []*solana.Transaction, opts ...grpc.CallOption, 
+) (*jito_pb.SendBundleResponse, error){
+  ctx, span := tracing.Start(ctx, "jito.bundle", cl.bundleAttributes(transactions)...)
+  bundle, err := cl.broadcastBundleWithConfirmation(ctx, span, transactions, opts...)
+  if bundle != nil{
+    span.SetAttributes(tracing.BundleUUIDKey.String(bundle.Uuid))
   }
-	err := cl.JitoRpcConn.RPCCallForInfo(ctx, &out, "simulateBundle", params)
-	return &out, err
+  tracing.End(span, err)
+  return bundle, err
 }
 
-// BroadcastBundleWithConfirmation sends a bundle of transactions on chain thru Jito BlockEngine and waits for its confirmation.
-func (cl *Client) BroadcastBundleWithConfirmation(ctx context.Context, transactions []*solana.Transaction, opts ...grpc.CallOption, 
+func (cl *Client) broadcastBundleWithConfirmation(ctx context.Context, span trace.Span, transactions []*solana.Transaction, opts ...grpc.CallOption, 
 ) (*jito_pb.SendBundleResponse, error){
-  fmt.Println("Entered broadcastBundle function")
-  bundle, err := cl.BroadcastBundle(transactions, opts...)
-  fmt.Println("Built a bundle!")
+  submittedAt := time.Now()
+  bundle, err := cl.broadcastBundle(ctx, transactions, opts...)
   if err != nil{
     return nil, fmt.Errorf("Couldn't broadcast bundles: %w", err)
   }
+  cl.Metrics.InFlight(1)
+  defer cl.Metrics.InFlight(-1)
   
   bundleSignatures := pkg.BatchExtractSigFromTx(transactions)
-  fmt.Println("Extracted signatures from transactions")
-  
+  logger := cl.Logger.With(
+    slog.String("bundle_uuid", bundle.Uuid),
+    slog.Any("signatures", pkg.SignatureStrings(bundleSignatures)),
+    slog.String("region", cl.region),
+  )
   
   for{
-    fmt.Println("looping")
     select{
     case <- cl.Auth.GrpcCtx.Done():
-      fmt.Println("done")
+      logger.Debug("auth context done")
       return nil, cl.Auth.GrpcCtx.Err()
     default:
-      time.Sleep(5 * time.Second)
-      fmt.Println("Requested bundle result")
-      bundleResult, err := cl.BundleStreamSubscription.Recv() // 
-      fmt.Println("Received bundle result:", bundleResult)
+      bundleResult, err := cl.BundleStreamSubscription.Recv() // blocks until the next result
       if err != nil{
+        logger.Warn("bundle result stream failed", slog.Any("error", err))
         return bundle, err
       }
+      // the stream carries the results of every bundle sent by this client
+      if bundleResult.GetBundleId() != bundle.Uuid{
+        logger.Debug("skipping result of another bundle", slog.String("result_bundle_id", bundleResult.GetBundleId()))
+        continue
+      }
+      logger.Debug("bundle result received", slog.String("result", fmt.Sprintf("%T", bundleResult.GetResult())))
+      
+      outcome, reason := metrics.ClassifyBundleResult(bundleResult)
+      cl.Metrics.BundleResult(outcome, reason)
+      tracing.RecordBundleResult(span, bundleResult)
       
-      // (bundleResult, bundleID)
-      if err := handleBundleResult(bundleResult, ""); err != nil{
+      err = handleBundleResult(bundleResult, bundle.Uuid)
+      if err == nil && outcome == metrics.OutcomeDropped{
+        err = NewDroppedBundle(reason)
+      }
+      if err != nil{
+        logger.Warn("bundle rejected", slog.Any("error", err))
+        state := journal.StateRejected
+        if outcome == metrics.OutcomeDropped{
+          state = journal.StateDropped
+        }
+        cl.journalUpdate(transactions, journal.Update{State: state, Reason: err.Error()})
         return bundle, err
       }
-      fmt.Println("Handled bundle result")
+      if outcome == metrics.OutcomeAccepted{
+        cl.journalUpdate(transactions, journal.Update{State: journal.StateAccepted})
+      }
       
       //ctx, cancel := context.WithTimeout(ctx, time.Second*15)
       //defer cancel()
       var start = time.Now()
       var statuses *rpc.GetSignatureStatusesResult
       
-      isRPCNil(cl.RpcConn)
+      cl.ensureRPC()
+      lastValid, expires := cl.lastValidBlockHeight(transactions)
+      // with a known blockhash expiry the bundle is awaited until it can no longer land; ConfirmTimeout still
+      // applies from the last successful expiry check, so a failing check doesn't wait forever
+      checkedAt := start
     
       for{
+        var expired bool
+        var height uint64
+        if expires{
+          var expErr error
+          expired, _, height, expErr = cl.Blockhashes.Expired(ctx, transactions[0].Message.RecentBlockhash)
+          if expErr != nil{
+            logger.Debug("failed to check blockhash expiry", slog.Any("error", expErr))
+          } else if !expired{
+            checkedAt = time.Now()
+          }
+        }
+
         // GetSignatureStatuses(context, searchTransactionHistory, transactionSignatures)
         statuses, err = cl.RpcConn.GetSignatureStatuses(ctx, false, bundleSignatures...)
         if err != nil{
           return bundle, err
         }
-        ready := true
+        ready, landed := true, false
         for _, status := range statuses.Value{
           if status == nil{
             ready = false
-            fmt.Println("Not ready yet: breaking...")
-            break
+          } else{
+            landed = true
           }
         }
         if ready{
-          fmt.Println("Excited the loop: All statuses are ready!")
+          logger.Debug("all signature statuses are ready", slog.Duration("latency", time.Since(start)))
           break
         }
         //select{
@@ -140,10 +182,23 @@ func (cl *Client) BroadcastBundleWithConfirmation(ctx context.Context, transacti
         //default:
         //  time.Sleep(1*time.Second)
         //}
-        if time.Since(start) > time.Second*15{
-          return bundle, errors.New("operation timed out after 15 secodns")
+        // a partially reported bundle is awaited: bundles land atomically, the remaining statuses will show up
+        if expired && !landed{
+          logger.Warn("bundle expired",
+            slog.Uint64("last_valid_block_height", lastValid),
+            slog.Uint64("block_height", height),
+            slog.Duration("latency", time.Since(start)),
+          )
+          cl.Metrics.BundleResult(metrics.OutcomeExpired, "")
+          err = NewBlockhashExpiredError(transactions[0].Message.RecentBlockhash.String(), lastValid, height)
+          cl.journalUpdate(transactions, journal.Update{State: journal.StateExpired, Reason: err.Error()})
+          return bundle, err
+        }
+        if time.Since(checkedAt) > cl.ConfirmTimeout{
+          logger.Warn("timed out waiting for signature statuses", slog.Duration("latency", time.Since(start)))
+          return bundle, fmt.Errorf("operation timed out after %s", cl.ConfirmTimeout)
         } else{
-          time.Sleep(time.Second*1)
+          time.Sleep(cl.PollInterval)
         }
       }
       
@@ -153,7 +208,13 @@ func (cl *Client) BroadcastBundleWithConfirmation(ctx context.Context, transacti
         }
       }
       
-      fmt.Println("All good, return a bundle")
+      cl.Metrics.BundleResult(metrics.OutcomeLanded, "")
+      cl.Metrics.Landed(time.Since(submittedAt))
+      if len(statuses.Value) > 0{
+        span.AddEvent("landed", trace.WithAttributes(tracing.SlotKey.Int64(int64(statuses.Value[0].Slot))))
+        cl.journalUpdate(transactions, journal.Update{State: journal.StateLanded, Slot: statuses.Value[0].Slot})
+      }
+      logger.Info("bundle confirmed", slog.Duration("latency", time.Since(start)))
       return bundle, nil
     }
   }
@@ -162,16 +223,102 @@ func (cl *Client) BroadcastBundleWithConfirmation(ctx context.Context, transacti
  
 // Sends a bundle of transaction(s) on chain through Jito
 func (cl *Client) BroadcastBundle(transactions []*solana.Transaction, opts ...grpc.CallOption) (*jito_pb.SendBundleResponse, error){
+  return cl.broadcastBundle(context.Background(), transactions, opts...)
+}
+
+// broadcastBundle sends the bundle with the trace span from ctx attached to the authenticated gRPC context.
+func (cl *Client) broadcastBundle(ctx context.Context, transactions []*solana.Transaction, opts ...grpc.CallOption) (*jito_pb.SendBundleResponse, error){
+  _, buildSpan := tracing.Start(ctx, "jito.bundle.build")
   bundle, err := cl.AssembleBundle(transactions) // array of protobuf packets
+  tracing.End(buildSpan, err)
   if err != nil{
     return nil, err
   }
+  return dedup.Do(ctx, cl.Dedup, metrics.KindBundle, dedup.BundleKeys(bundle, transactions), func() (*jito_pb.SendBundleResponse, error){
+    return cl.sendBundle(ctx, bundle, transactions, opts...)
+  })
+}
+
+// sendBundle journals and sends an assembled bundle.
+func (cl *Client) sendBundle(ctx context.Context, bundle *jito_pb.Bundle, transactions []*solana.Transaction, opts ...grpc.CallOption) (*jito_pb.SendBundleResponse, error){
+  if cl.Journal != nil{
+    lastValid, _ := cl.lastValidBlockHeight(transactions)
+    if _, err := cl.Journal.Begin(transactions, pkg.TipLamports(transactions, cl.cachedTipAccounts()), lastValid); err != nil{
+      return nil, fmt.Errorf("failed to journal bundle: %w", err)
+    }
+  }
   
-  return cl.SearcherService.SendBundle(cl.Auth.GrpcCtx, &jito_pb.SendBundleRequest{Bundle: bundle}, opts...)
+  ctx, sendSpan := tracing.Start(ctx, "jito.bundle.send", cl.bundleAttributes(transactions)...)
+  start := time.Now()
+  resp, err := cl.SearcherService.SendBundle(tracing.WithSpan(cl.Auth.GrpcCtx, ctx), &jito_pb.SendBundleRequest{Bundle: bundle}, opts...)
+  cl.Metrics.Submitted(metrics.KindBundle, metrics.OutcomeOf(err, metrics.OutcomeAccepted), time.Since(start))
+  if err == nil{
+    sendSpan.SetAttributes(tracing.BundleUUIDKey.String(resp.Uuid))
+  }
+  tracing.End(sendSpan, err)
+  if err != nil{
+    cl.Logger.Warn("send bundle failed",
+      slog.String("method", "SendBundle"),
+      slog.String("region", cl.region),
+      slog.Any("signatures", pkg.SignatureStrings(pkg.BatchExtractSigFromTx(transactions))),
+      slog.Duration("latency", time.Since(start)),
+      slog.Any("error", err),
+    )
+    // the bundle stays pending: the block engine may have received it before the call failed
+    cl.journalUpdate(transactions, journal.Update{State: journal.StatePending, Reason: err.Error()})
+    return nil, err
+  }
+  cl.journalUpdate(transactions, journal.Update{State: journal.StateSent, UUID: resp.Uuid})
+  
+  cl.Logger.Info("bundle sent",
+    slog.String("method", "SendBundle"),
+    slog.String("region", cl.region),
+    slog.String("bundle_uuid", resp.Uuid),
+    slog.Any("signatures", pkg.SignatureStrings(pkg.BatchExtractSigFromTx(transactions))),
+    slog.Duration("latency", time.Since(start)),
+  )
+  return resp, nil
+}
+
+// lastValidBlockHeight returns the expiry of the bundle's blockhash if it was handed out by cl.Blockhashes.
+func (cl *Client) lastValidBlockHeight(transactions []*solana.Transaction) (uint64, bool){
+  if cl.Blockhashes == nil || len(transactions) == 0{
+    return 0, false
+  }
+  return cl.Blockhashes.LastValidBlockHeight(transactions[0].Message.RecentBlockhash)
+}
+
+// journalUpdate records a transition of the bundle in the client's journal, if any. Failures are logged:
+// the bundle was journaled before it was sent, so recovery still finds it.
+func (cl *Client) journalUpdate(transactions []*solana.Transaction, u journal.Update){
+  if cl.Journal == nil || len(transactions) == 0 || len(transactions[0].Signatures) == 0{
+    return
+  }
+  id := transactions[0].Signatures[0].String()
+  if err := cl.Journal.Update(id, u); err != nil{
+    cl.Logger.Warn("failed to journal bundle transition",
+      slog.String("id", id),
+      slog.String("state", string(u.State)),
+      slog.Any("error", err),
+    )
+  }
+}
+
+// bundleAttributes returns the span attributes describing a bundle before it has a UUID.
+func (cl *Client) bundleAttributes(transactions []*solana.Transaction) []attribute.KeyValue{
+  return []attribute.KeyValue{
+    tracing.RegionKey.String(cl.region),
+    tracing.SignaturesKey.StringSlice(pkg.SignatureStrings(pkg.BatchExtractSigFromTx(transactions))),
+    tracing.TipLamportsKey.Int64(int64(pkg.TipLamports(transactions, cl.cachedTipAccounts()))),
+  }
 }
 
 // Converts an array of SOL transactions to a Jito bundle
 func (cl *Client) AssembleBundle(transactions []*solana.Transaction) (*jito_pb.Bundle, error){
+  return assembleBundle(transactions)
+}
+
+func assembleBundle(transactions []*solana.Transaction) (*jito_pb.Bundle, error){
   packets := make([]*jito_pb.Packet, 0, len(transactions)) // <-- packets are encoded repr of srucutures data
   
   // converts an array of transactions to an array of protobuf packets
@@ -0,0 +1,160 @@
+package searcher_client
+import(
+  "context"
+  "errors"
+  "strings"
+  "testing"
+  "time"
+
+  "github.com/scatkit/gojito/jitotest"
+  jito_pb "github.com/scatkit/gojito/pb"
+  "github.com/scatkit/gojito/pkg"
+  "github.com/scatkit/pumpdexer/programs/system"
+  "github.com/scatkit/pumpdexer/rpc"
+  "github.com/scatkit/pumpdexer/solana"
+)
+
+// newFakeClient connects a client to a fake block engine and a fake Solana node.
+func newFakeClient(t *testing.T, opts ...jitotest.Option) (*Client, *jitotest.BlockEngine, *jitotest.SolanaRPC){
+  t.Helper()
+  be := jitotest.NewBlockEngine(opts...)
+  if err := be.Start(); err != nil{
+    t.Fatal(err)
+  }
+  t.Cleanup(be.Close)
+  jitoSrv := be.NewHTTPServer()
+  t.Cleanup(jitoSrv.Close)
+  sol := jitotest.NewSolanaRPC()
+  solURL := sol.Start()
+  t.Cleanup(sol.Close)
+
+  ctx, cancel := context.WithCancel(context.Background())
+  t.Cleanup(cancel)
+  cl, err := NewNoAuth(ctx, be.Target(), rpc.New(jitoSrv.URL), rpc.New(solURL), be.TLSConfig(), "", be.DialOptions()...)
+  if err != nil{
+    t.Fatal(err)
+  }
+  t.Cleanup(func(){ cl.GrpcConn.Close() })
+  cl.PollInterval = 20 * time.Millisecond
+  cl.Blockhashes = pkg.NewBlockhashProvider(cl.RpcConn)
+  cl.Blockhashes.RefreshInterval = 20 * time.Millisecond
+
+  // results emitted before the subscription is open would never reach the client
+  deadline := time.Now().Add(2 * time.Second)
+  for be.Subscribers() == 0{
+    if time.Now().After(deadline){
+      t.Fatal("bundle results subscription not opened")
+    }
+    time.Sleep(5 * time.Millisecond)
+  }
+  return cl, be, sol
+}
+
+// transferBundle builds n signed transfers on a blockhash handed out by the client's provider.
+func transferBundle(t *testing.T, cl *Client, n int) ([]*solana.Transaction, []string){
+  t.Helper()
+  latest, err := cl.Blockhashes.Latest(context.Background())
+  if err != nil{
+    t.Fatal(err)
+  }
+  var txs []*solana.Transaction
+  var sigs []string
+  for i := 0; i < n; i++{
+    from := solana.NewWallet()
+    to := solana.NewWallet()
+    tx, err := solana.NewTransaction(
+      []solana.Instruction{system.NewTransferInstruction(uint64(1000+i), from.PublicKey(), to.PublicKey()).Build()},
+      latest.Hash,
+      solana.TransactionPayer(from.PublicKey()),
+    )
+    if err != nil{
+      t.Fatal(err)
+    }
+    if _, err := tx.Sign(func(pk solana.PublicKey) *solana.PrivateKey{
+      if pk.Equals(from.PublicKey()){
+        return &from.PrivateKey
+      }
+      return nil
+    }); err != nil{
+      t.Fatal(err)
+    }
+    txs = append(txs, tx)
+    sigs = append(sigs, tx.Signatures[0].String())
+  }
+  return txs, sigs
+}
+
+// landWhenReceived lands the bundle's signatures once the block engine has recorded it.
+func landWhenReceived(be *jitotest.BlockEngine, sol *jitotest.SolanaRPC, sigs []string){
+  go func(){
+    for len(be.Bundles()) == 0{
+      time.Sleep(5 * time.Millisecond)
+    }
+    sol.Land(sigs...)
+  }()
+}
+
+func TestBroadcastBundleWithConfirmationLands(t *testing.T){
+  cl, be, sol := newFakeClient(t)
+  txs, sigs := transferBundle(t, cl, 2)
+  landWhenReceived(be, sol, sigs)
+
+  resp, err := cl.BroadcastBundleWithConfirmation(context.Background(), txs)
+  if err != nil{
+    t.Fatal(err)
+  }
+  bundles := be.Bundles()
+  if len(bundles) != 1 || resp.Uuid != bundles[0].UUID{
+    t.Fatalf("response uuid %q, recorded bundles %+v", resp.Uuid, bundles)
+  }
+  if len(bundles[0].Signatures) != 2 || bundles[0].Signatures[0] != sigs[0] || bundles[0].Signatures[1] != sigs[1]{
+    t.Errorf("recorded signatures %v, want %v", bundles[0].Signatures, sigs)
+  }
+}
+
+func TestBroadcastBundleWithConfirmationRejected(t *testing.T){
+  cl, _, sol := newFakeClient(t, jitotest.WithScript(jitotest.Reject("outbid"), 0))
+  txs, _ := transferBundle(t, cl, 1)
+
+  _, err := cl.BroadcastBundleWithConfirmation(context.Background(), txs)
+  var rejection BundleRejectionError
+  if !errors.As(err, &rejection) || !strings.Contains(err.Error(), "state auction"){
+    t.Fatalf("error %v, want a state auction rejection", err)
+  }
+  if n := sol.Calls("getSignatureStatuses"); n != 0{
+    t.Errorf("polled signature statuses %d times after a rejection", n)
+  }
+}
+
+func TestBroadcastBundleWithConfirmationDropped(t *testing.T){
+  dropped := func(_ string, _ *jito_pb.Bundle) []*jito_pb.BundleResult{
+    return []*jito_pb.BundleResult{{Result: &jito_pb.BundleResult_Dropped{Dropped: &jito_pb.Dropped{Reason: jito_pb.DroppedReason_BlockhashExpired}}}}
+  }
+  cl, _, sol := newFakeClient(t, jitotest.WithScript(dropped, 0))
+  txs, _ := transferBundle(t, cl, 1)
+
+  _, err := cl.BroadcastBundleWithConfirmation(context.Background(), txs)
+  var rejection BundleRejectionError
+  if !errors.As(err, &rejection) || !strings.Contains(err.Error(), "BlockhashExpired"){
+    t.Fatalf("error %v, want a dropped bundle", err)
+  }
+  if n := sol.Calls("getSignatureStatuses"); n != 0{
+    t.Errorf("polled signature statuses %d times after a drop", n)
+  }
+}
+
+func TestBroadcastBundleWithConfirmationSkipsOtherBundles(t *testing.T){
+  // another bundle's rejection arrives first on the shared stream
+  script := func(uuid string, b *jito_pb.Bundle) []*jito_pb.BundleResult{
+    other := jitotest.Reject("outbid")(uuid, b)[0]
+    other.BundleId = "another-bundle"
+    return append([]*jito_pb.BundleResult{other}, jitotest.AcceptAndLand(uuid, b)...)
+  }
+  cl, be, sol := newFakeClient(t, jitotest.WithScript(script, 0))
+  txs, sigs := transferBundle(t, cl, 1)
+  landWhenReceived(be, sol, sigs)
+
+  if _, err := cl.BroadcastBundleWithConfirmation(context.Background(), txs); err != nil{
+    t.Fatal(err)
+  }
+}
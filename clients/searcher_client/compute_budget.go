@@ -0,0 +1,66 @@
+package searcher_client
+import(
+  "errors"
+  "fmt"
+
+  "github.com/scatkit/pumpdexer/solana"
+  "github.com/scatkit/gojito/pkg"
+)
+
+// DefaultComputeUnitMarginPercent is added on top of the simulated compute units when no margin is configured.
+const DefaultComputeUnitMarginPercent = 10
+
+type ComputeBudgetOpts struct{
+  MarginPercent        uint64  // extra units on top of the simulated consumption, in percent
+  MicroLamportsPerUnit *uint64 // when set, SetComputeUnitPrice is added (or replaced) with this price
+  Signer               func(key solana.PublicKey) *solana.PrivateKey // re-signs the rebuilt transactions
+}
+
+// RightSizeComputeBudget returns copies of transactions whose compute unit limit is set to the units consumed
+// in sim plus a margin. The copies are re-signed with opts.Signer and checked against the packet size limit.
+// A failed simulation returns its error: the units consumed up to the failure would undersize the limits.
+func RightSizeComputeBudget(sim *BundleSimulation, transactions []*solana.Transaction, opts *ComputeBudgetOpts,
+) ([]*solana.Transaction, error){
+  if err := sim.Summary.Err(); err != nil{
+    return nil, err
+  }
+  if opts == nil || opts.Signer == nil{
+    return nil, errors.New("a signer is required to re-sign the transactions")
+  }
+  if len(sim.TransactionResults) != len(transactions){
+    return nil, fmt.Errorf("simulation has %d results for %d transactions", len(sim.TransactionResults), len(transactions))
+  }
+  margin := opts.MarginPercent
+  if margin == 0{
+    margin = DefaultComputeUnitMarginPercent
+  }
+
+  out := make([]*solana.Transaction, 0, len(transactions))
+  for i, tx := range transactions{
+    units := sim.TransactionResults[i].UnitsConsumed
+    // budget instructions that weren't part of the simulated transaction cost units too
+    if limit, price := pkg.ComputeBudget(tx); limit == 0{
+      units += pkg.ComputeBudgetInstructionUnits
+      if price == 0 && opts.MicroLamportsPerUnit != nil{
+        units += pkg.ComputeBudgetInstructionUnits
+      }
+    }
+    units += units * margin / 100
+    if units > pkg.MaxComputeUnitLimit{
+      units = pkg.MaxComputeUnitLimit
+    }
+
+    rebuilt := pkg.CloneTransaction(tx)
+    if err := pkg.SetComputeBudget(rebuilt, uint32(units), opts.MicroLamportsPerUnit); err != nil{
+      return nil, fmt.Errorf("%d: %w", i, err)
+    }
+    if _, err := rebuilt.Sign(opts.Signer); err != nil{
+      return nil, fmt.Errorf("%d: failed to re-sign transaction: %w", i, err)
+    }
+    if err := pkg.CheckPacketSize(rebuilt); err != nil{
+      return nil, fmt.Errorf("%d: %w", i, err)
+    }
+    out = append(out, rebuilt)
+  }
+  return out, nil
+}
@@ -0,0 +1,21 @@
+package searcher_client
+import(
+  "errors"
+  "testing"
+
+  "github.com/scatkit/pumpdexer/solana"
+)
+
+func TestRightSizeComputeBudgetRejectsFailedSimulation(t *testing.T){
+  sim := &BundleSimulation{
+    Summary:            BundleSimulationSummary{Failed: &BundleSimulationFailure{Error: "TransactionFailure: insufficient funds", TxSignature: "5h3k"}},
+    TransactionResults: []SimulatedTransaction{{UnitsConsumed: 300}},
+  }
+  signer := func(solana.PublicKey) *solana.PrivateKey{ return nil }
+
+  out, err := RightSizeComputeBudget(sim, []*solana.Transaction{{}}, &ComputeBudgetOpts{Signer: signer})
+  var rejection BundleRejectionError
+  if out != nil || !errors.As(err, &rejection){
+    t.Fatalf("sized %d transactions from a failed simulation, error %v", len(out), err)
+  }
+}
@@ -0,0 +1,148 @@
+package searcher_client
+import(
+  "context"
+  "encoding/json"
+  "errors"
+  "strings"
+  "testing"
+  "time"
+
+  "github.com/scatkit/gojito/jitotest"
+  "github.com/scatkit/pumpdexer/solana"
+)
+
+type confirmation struct{
+  err     error
+  elapsed time.Duration
+}
+
+// confirmAsync runs BroadcastBundleWithConfirmation in the background so the test can move the node meanwhile.
+func confirmAsync(cl *Client, bundle []*solana.Transaction) <-chan confirmation{
+  done := make(chan confirmation, 1)
+  go func(){
+    start := time.Now()
+    _, err := cl.BroadcastBundleWithConfirmation(context.Background(), bundle)
+    done <- confirmation{err, time.Since(start)}
+  }()
+  return done
+}
+
+// waitPolls blocks until the client has polled the node's signature statuses at least n times.
+func waitPolls(t *testing.T, sol *jitotest.SolanaRPC, n int){
+  t.Helper()
+  deadline := time.Now().Add(2 * time.Second)
+  for sol.Calls("getSignatureStatuses") < n{
+    if time.Now().After(deadline){
+      t.Fatalf("signature statuses polled %d times, want %d", sol.Calls("getSignatureStatuses"), n)
+    }
+    time.Sleep(5 * time.Millisecond)
+  }
+}
+
+func assertPending(t *testing.T, done <-chan confirmation){
+  t.Helper()
+  select{
+  case c := <-done:
+    t.Fatalf("confirmation returned early after %s: %v", c.elapsed, c.err)
+  case <-time.After(100 * time.Millisecond):
+  }
+}
+
+func awaitConfirmation(t *testing.T, done <-chan confirmation) confirmation{
+  t.Helper()
+  select{
+  case c := <-done:
+    return c
+  case <-time.After(5 * time.Second):
+    t.Fatal("confirmation didn't return")
+    return confirmation{}
+  }
+}
+
+func TestConfirmationWaitsForLand(t *testing.T){
+  cl, _, sol := newFakeClient(t)
+  txs, sigs := transferBundle(t, cl, 2)
+  done := confirmAsync(cl, txs)
+
+  waitPolls(t, sol, 2)
+  sol.Advance(10)
+  assertPending(t, done)
+  // one signature alone doesn't confirm the bundle
+  sol.Land(sigs[0])
+  assertPending(t, done)
+  sol.Land(sigs[1])
+  if c := awaitConfirmation(t, done); c.err != nil{
+    t.Fatal(c.err)
+  }
+}
+
+func TestConfirmationExpiresPastMaxBlockhashAge(t *testing.T){
+  cl, _, sol := newFakeClient(t)
+  // expiry, not the timeout, has to end the wait
+  cl.ConfirmTimeout = time.Minute
+  txs, _ := transferBundle(t, cl, 1)
+  done := confirmAsync(cl, txs)
+
+  waitPolls(t, sol, 2)
+  // the blockhash is still valid at its last valid block height
+  sol.Advance(jitotest.MaxBlockhashAge)
+  assertPending(t, done)
+  sol.Advance(1)
+
+  c := awaitConfirmation(t, done)
+  var rejection BundleRejectionError
+  if !errors.As(c.err, &rejection) || !strings.Contains(c.err.Error(), "bundle expired"){
+    t.Fatalf("error %v, want an expired bundle", c.err)
+  }
+}
+
+func TestConfirmationIgnoresExpiryOfLandedBundle(t *testing.T){
+  cl, _, sol := newFakeClient(t)
+  cl.ConfirmTimeout = time.Minute
+  txs, sigs := transferBundle(t, cl, 2)
+  done := confirmAsync(cl, txs)
+
+  waitPolls(t, sol, 2)
+  // a partially reported bundle landed before its blockhash expired
+  sol.Land(sigs[0])
+  sol.ExpireBlockhashes()
+  assertPending(t, done)
+  // landed again in the current slot: the expiry finalized the first signature, which doesn't count as confirmed
+  sol.Land(sigs...)
+  if c := awaitConfirmation(t, done); c.err != nil{
+    t.Fatal(c.err)
+  }
+}
+
+func TestConfirmationTimesOutWithoutKnownBlockhash(t *testing.T){
+  cl, _, sol := newFakeClient(t)
+  txs, _ := transferBundle(t, cl, 1)
+  cl.Blockhashes = nil
+  cl.ConfirmTimeout = 200 * time.Millisecond
+  heightCalls := sol.Calls("getBlockHeight")
+
+  c := awaitConfirmation(t, confirmAsync(cl, txs))
+  if c.err == nil || !strings.Contains(c.err.Error(), "timed out"){
+    t.Fatalf("error %v, want a timeout", c.err)
+  }
+  if c.elapsed < cl.ConfirmTimeout{
+    t.Errorf("timed out after %s, before ConfirmTimeout", c.elapsed)
+  }
+  if sol.Calls("getBlockHeight") != heightCalls{
+    t.Error("block height checked without a known blockhash")
+  }
+}
+
+func TestConfirmationTimesOutWhenExpiryCheckFails(t *testing.T){
+  cl, _, sol := newFakeClient(t)
+  txs, _ := transferBundle(t, cl, 1)
+  cl.ConfirmTimeout = 200 * time.Millisecond
+  sol.Handle("getBlockHeight", func(_ []json.RawMessage) (any, error){
+    return nil, errors.New("node is behind")
+  })
+
+  c := awaitConfirmation(t, confirmAsync(cl, txs))
+  if c.err == nil || !strings.Contains(c.err.Error(), "timed out"){
+    t.Fatalf("error %v, want a timeout", c.err)
+  }
+}
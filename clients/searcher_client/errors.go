@@ -39,6 +39,23 @@ func NewDroppedBundle(message string) error {
 	}
 }
 
+func NewBlockhashExpiredError(blockhash string, lastValidBlockHeight, blockHeight uint64) error {
+	if lastValidBlockHeight == 0 {
+		return BundleRejectionError{
+			Message: fmt.Sprintf("bundle expired, blockhash %s is no longer valid", blockhash),
+		}
+	}
+	return BundleRejectionError{
+		Message: fmt.Sprintf("bundle expired, blockhash %s was last valid at block height %d, now at %d", blockhash, lastValidBlockHeight, blockHeight),
+	}
+}
+
+func NewUnprofitableBundleError(profit, minProfit int64) error {
+	return BundleRejectionError{
+		Message: fmt.Sprintf("bundle not sent, projected profit %d lamports is below %d lamports", profit, minProfit),
+	}
+}
+
 //type BundleRejectionError struct{
 //  Message string
 //}
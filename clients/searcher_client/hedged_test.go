@@ -0,0 +1,181 @@
+package searcher_client
+import(
+  "context"
+  "errors"
+  "strings"
+  "testing"
+  "time"
+
+  "github.com/scatkit/gojito/jitorpc"
+  "github.com/scatkit/gojito/jitotest"
+  "github.com/scatkit/gojito/journal"
+  "github.com/scatkit/pumpdexer/solana"
+)
+
+// newHedgedSender hedges over two gRPC clients and a JSON-RPC client, each with its own fake block engine, in
+// that order. None of the block engines lands the bundle by itself, and the Solana node is the first client's.
+func newHedgedSender(t *testing.T, jsonOpts ...jitotest.Option) (*HedgedSender, *Client, *jitotest.SolanaRPC, []*jitotest.BlockEngine){
+  t.Helper()
+  cl, be, sol := newFakeClient(t, jitotest.WithScript(jitotest.Silent, 0))
+  cl2, be2, _ := newFakeClient(t, jitotest.WithScript(jitotest.Silent, 0))
+  jsonBE := jitotest.NewBlockEngine(append([]jitotest.Option{jitotest.WithScript(jitotest.Silent, 0)}, jsonOpts...)...)
+  if err := jsonBE.Start(); err != nil{
+    t.Fatal(err)
+  }
+  t.Cleanup(jsonBE.Close)
+  srv := jsonBE.NewHTTPServer()
+  t.Cleanup(srv.Close)
+
+  h := NewHedgedSender([]*Client{cl, cl2}, []*jitorpc.JitoClient{jitorpc.NewJito(srv.URL, "")})
+  h.Blockhashes = cl.Blockhashes
+  h.PollInterval = 20 * time.Millisecond
+  return h, cl, sol, []*jitotest.BlockEngine{be, be2, jsonBE}
+}
+
+type hedgedConfirmation struct{
+  res *HedgedResult
+  err error
+}
+
+func confirmHedgedAsync(ctx context.Context, h *HedgedSender, bundle []*solana.Transaction) <-chan hedgedConfirmation{
+  done := make(chan hedgedConfirmation, 1)
+  go func(){
+    res, err := h.SendWithConfirmation(ctx, bundle)
+    done <- hedgedConfirmation{res, err}
+  }()
+  return done
+}
+
+func awaitHedged(t *testing.T, done <-chan hedgedConfirmation) hedgedConfirmation{
+  t.Helper()
+  select{
+  case c := <-done:
+    return c
+  case <-time.After(5 * time.Second):
+    t.Fatal("hedged confirmation didn't return")
+    return hedgedConfirmation{}
+  }
+}
+
+func TestHedgedSenderFansOut(t *testing.T){
+  h, cl, _, engines := newHedgedSender(t)
+  txs, sigs := transferBundle(t, cl, 2)
+
+  sub, err := h.Send(context.Background(), txs)
+  if err != nil{
+    t.Fatal(err)
+  }
+  for i, be := range engines{
+    bundles := be.Bundles()
+    if len(bundles) != 1 || bundles[0].Signatures[0] != sigs[0] || bundles[0].Signatures[1] != sigs[1]{
+      t.Fatalf("block engine %d received %v, want the bundle once", i, bundles)
+    }
+  }
+  // the fakes' endpoints don't name a region
+  paths := []string{"grpc", "grpc", "jsonrpc"}
+  if len(sub.Attempts) != len(paths){
+    t.Fatalf("%d attempts, want %d", len(sub.Attempts), len(paths))
+  }
+  for i, a := range sub.Attempts{
+    if a.Path() != paths[i] || a.Err != nil || a.BundleID != engines[i].Bundles()[0].UUID{
+      t.Errorf("attempt %d through %s: bundle %q, error %v", i, a.Path(), a.BundleID, a.Err)
+    }
+  }
+  // every endpoint identifies the same bundle alike
+  if len(sub.BundleIDs) != 1{
+    t.Errorf("bundle IDs %v, want one", sub.BundleIDs)
+  }
+}
+
+func TestHedgedSenderSucceedsThroughAnyEndpoint(t *testing.T){
+  // the JSON-RPC endpoint hangs past the send timeout
+  h, cl, _, engines := newHedgedSender(t, jitotest.WithLatency(time.Second))
+  h.SendTimeout = 100 * time.Millisecond
+  txs, _ := transferBundle(t, cl, 1)
+
+  start := time.Now()
+  sub, err := h.Send(context.Background(), txs)
+  if err != nil{
+    t.Fatal(err)
+  }
+  if elapsed := time.Since(start); elapsed > 500*time.Millisecond{
+    t.Errorf("send took %s, the hanging endpoint held up the others", elapsed)
+  }
+  if sub.Attempts[2].Err == nil || len(engines[2].Bundles()) != 0{
+    t.Errorf("hanging endpoint succeeded with bundle %q", sub.Attempts[2].BundleID)
+  }
+  if sub.Attempts[0].Err != nil || sub.Attempts[1].Err != nil || len(sub.BundleIDs) != 1{
+    t.Fatalf("attempts %v %v, bundle IDs %v, want both gRPC endpoints to succeed", sub.Attempts[0].Err, sub.Attempts[1].Err, sub.BundleIDs)
+  }
+
+  // the send fails only once every endpoint did
+  h.Clients = nil
+  if _, err = h.Send(context.Background(), txs); err == nil{
+    t.Fatal("send succeeded through the hanging endpoint alone")
+  }
+}
+
+func TestHedgedSenderDetectsLandedSignatures(t *testing.T){
+  h, cl, sol, _ := newHedgedSender(t)
+  txs, sigs := transferBundle(t, cl, 2)
+  done := confirmHedgedAsync(context.Background(), h, txs)
+
+  waitPolls(t, sol, 2)
+  sol.Land(sigs...)
+  c := awaitHedged(t, done)
+  if c.err != nil{
+    t.Fatal(c.err)
+  }
+  if c.res.State != journal.StateLanded || c.res.Source != "signatures" || c.res.Slot != sol.Slot(){
+    t.Fatalf("state %s from %q in slot %d, want landed from the signatures in slot %d", c.res.State, c.res.Source, c.res.Slot, sol.Slot())
+  }
+}
+
+func TestHedgedSenderDetectsLandedInflightStatus(t *testing.T){
+  h, cl, sol, engines := newHedgedSender(t)
+  txs, _ := transferBundle(t, cl, 1)
+  done := confirmHedgedAsync(context.Background(), h, txs)
+
+  // the Solana node lags behind the block engine
+  waitPolls(t, sol, 2)
+  uuid := engines[2].Bundles()[0].UUID
+  engines[2].SetBundleStatus(uuid, "Landed", 4242)
+  c := awaitHedged(t, done)
+  if c.err != nil{
+    t.Fatal(c.err)
+  }
+  if c.res.State != journal.StateLanded || c.res.Source != "jsonrpc" || c.res.Slot != 4242{
+    t.Fatalf("state %s from %q in slot %d, want landed from jsonrpc in slot 4242", c.res.State, c.res.Source, c.res.Slot)
+  }
+}
+
+func TestHedgedSenderDetectsExpiry(t *testing.T){
+  h, cl, sol, _ := newHedgedSender(t)
+  txs, _ := transferBundle(t, cl, 1)
+  done := confirmHedgedAsync(context.Background(), h, txs)
+
+  waitPolls(t, sol, 2)
+  sol.ExpireBlockhashes()
+  c := awaitHedged(t, done)
+  var rejection BundleRejectionError
+  if c.res.State != journal.StateExpired || !errors.As(c.err, &rejection) || !strings.Contains(c.err.Error(), "bundle expired"){
+    t.Fatalf("state %s, error %v, want an expired blockhash", c.res.State, c.err)
+  }
+}
+
+func TestHedgedSenderCancellation(t *testing.T){
+  h, cl, sol, _ := newHedgedSender(t)
+  txs, _ := transferBundle(t, cl, 1)
+  ctx, cancel := context.WithCancel(context.Background())
+  done := confirmHedgedAsync(ctx, h, txs)
+
+  waitPolls(t, sol, 2)
+  cancel()
+  c := awaitHedged(t, done)
+  if !errors.Is(c.err, context.Canceled) || c.res.State != journal.StateSent{
+    t.Fatalf("state %s, error %v, want sent and canceled", c.res.State, c.err)
+  }
+  if len(c.res.BundleIDs) != 1{
+    t.Errorf("bundle IDs %v, want the submission kept", c.res.BundleIDs)
+  }
+}
@@ -0,0 +1,226 @@
+package searcher_client
+import(
+  "context"
+  "encoding/json"
+  "errors"
+  "fmt"
+  "log/slog"
+
+  "github.com/scatkit/pumpdexer/rpc"
+  "github.com/scatkit/pumpdexer/solana"
+  "github.com/scatkit/gojito/pkg"
+)
+
+// BundleVerdict is the reconciled state of a bundle, see GetBundleOutcome.
+type BundleVerdict string
+
+const(
+  VerdictLanded    BundleVerdict = "landed"    // every transaction landed without error
+  VerdictFailed    BundleVerdict = "failed"    // every transaction landed but one failed
+  VerdictPartial   BundleVerdict = "partial"   // some transactions landed: outside the bundle, or the others' statuses lag
+  VerdictPending   BundleVerdict = "pending"   // the block engine still holds the bundle
+  VerdictRejected  BundleVerdict = "rejected"  // the block engine gave up on the bundle and none of its transactions landed
+  VerdictUnknown   BundleVerdict = "unknown"   // no source knows the bundle, e.g. the inflight status of an old bundle expired
+)
+
+// BundleOutcome is a bundle's verdict together with the answers it was reconciled from.
+type BundleOutcome struct{
+  UUID               string
+  Verdict            BundleVerdict
+  Slot               uint64                     // of the landed transactions
+  ConfirmationStatus rpc.ConfirmationStatusType // the lowest among the landed transactions, or the block engine's
+  TxErr              any                        // the first transaction error, for VerdictFailed
+
+  BundleStatus   string                         // confirmation status from getBundleStatuses, empty if it doesn't know the bundle
+  InflightStatus string                         // Pending, Landed, Failed or Invalid from getInflightBundleStatuses
+  Signatures     []solana.Signature
+  Statuses       []*rpc.SignatureStatusesResult // per signature, nil while unknown to the Solana RPC node
+  Conflicts      []string                       // disagreements between the sources, resolved in favour of the chain
+}
+
+type bundleStatusesResult struct{
+  Value []*struct{
+    BundleID           string          `json:"bundle_id"`
+    Transactions       []string        `json:"transactions"`
+    Slot               uint64          `json:"slot"`
+    ConfirmationStatus string          `json:"confirmation_status"`
+    Err                json.RawMessage `json:"err"` // {"Ok": null} on success
+  } `json:"value"`
+}
+
+type inflightStatusesResult struct{
+  Value []struct{
+    BundleID   string  `json:"bundle_id"`
+    Status     string  `json:"status"`
+    LandedSlot *uint64 `json:"landed_slot"`
+  } `json:"value"`
+}
+
+// GetBundleOutcome looks up the outcome of a bundle by its UUID, e.g. when the result stream missed it. The block
+// engine's getBundleStatuses and getInflightBundleStatuses (queried on JitoRpcConn, which must point at its
+// JSON-RPC bundles endpoint) are reconciled with the Solana signature statuses, which win when they disagree.
+// signatures may be nil if getBundleStatuses still knows the bundle. A source failing is tolerated as long as
+// another one answers.
+func (cl *Client) GetBundleOutcome(ctx context.Context, uuid string, signatures []solana.Signature) (*BundleOutcome, error){
+  out := &BundleOutcome{UUID: uuid, Signatures: signatures}
+  var errs []error
+  var bundleOK, inflightOK, signaturesOK bool // the source answered
+
+  var landed bundleStatusesResult
+  if err := cl.JitoRpcConn.RPCCallForInfo(ctx, &landed, "getBundleStatuses", []interface{}{[]string{uuid}}); err != nil{
+    errs = append(errs, fmt.Errorf("getBundleStatuses: %w", err))
+  } else{
+    bundleOK = true
+  }
+  var bundleTxErr any
+  for _, v := range landed.Value{
+    if v == nil || v.BundleID != uuid{
+      continue
+    }
+    out.BundleStatus, out.Slot = v.ConfirmationStatus, v.Slot
+    bundleTxErr = bundleStatusErr(v.Err)
+    if len(out.Signatures) == 0{
+      sigs, err := pkg.DecodeSignatures(v.Transactions)
+      if err != nil{
+        errs = append(errs, fmt.Errorf("getBundleStatuses: %w", err))
+      }
+      out.Signatures = sigs
+    }
+  }
+
+  var inflight inflightStatusesResult
+  if err := cl.JitoRpcConn.RPCCallForInfo(ctx, &inflight, "getInflightBundleStatuses", []interface{}{[]string{uuid}}); err != nil{
+    errs = append(errs, fmt.Errorf("getInflightBundleStatuses: %w", err))
+  } else{
+    inflightOK = true
+  }
+  var inflightSlot uint64
+  for _, v := range inflight.Value{
+    if v.BundleID == uuid{
+      out.InflightStatus = v.Status
+      if v.LandedSlot != nil{
+        inflightSlot = *v.LandedSlot
+      }
+    }
+  }
+
+  // without signatures the Solana RPC node isn't asked, which isn't a failure of its own
+  if len(out.Signatures) > 0{
+    cl.ensureRPC()
+    // history is searched: the outcome may be looked up long after the bundle was sent
+    statuses, err := cl.RpcConn.GetSignatureStatuses(ctx, true, out.Signatures...)
+    if err != nil{
+      errs = append(errs, fmt.Errorf("getSignatureStatuses: %w", err))
+    } else{
+      out.Statuses, signaturesOK = statuses.Value, true
+    }
+  }
+  if !bundleOK && !inflightOK && !signaturesOK{
+    return nil, errors.Join(errs...)
+  }
+  if len(errs) > 0{
+    cl.Logger.Debug("bundle outcome sources failed", slog.String("bundle_uuid", uuid), slog.Any("error", errors.Join(errs...)))
+  }
+
+  out.reconcile(bundleTxErr, inflightSlot)
+  return out, nil
+}
+
+// reconcile derives the verdict. The signature statuses come from the chain and win; the block engine's
+// answers decide only while none of the signatures landed.
+func (out *BundleOutcome) reconcile(bundleTxErr any, inflightSlot uint64){
+  landed := 0
+  var slot uint64
+  var confirmation rpc.ConfirmationStatusType
+  for _, st := range out.Statuses{
+    if st == nil{
+      continue
+    }
+    landed++
+    slot = max(slot, st.Slot)
+    if confirmation == "" || confirmationRank(st.ConfirmationStatus) < confirmationRank(confirmation){
+      confirmation = st.ConfirmationStatus
+    }
+    if st.Err != nil && out.TxErr == nil{
+      out.TxErr = st.Err
+    }
+  }
+  conflict := func(format string, args ...any){
+    out.Conflicts = append(out.Conflicts, fmt.Sprintf(format, args...))
+  }
+
+  if landed > 0{
+    bundleSlot := out.Slot
+    out.Slot, out.ConfirmationStatus = slot, confirmation
+    switch{
+    case landed < len(out.Signatures):
+      out.Verdict = VerdictPartial
+    case out.TxErr != nil:
+      out.Verdict = VerdictFailed
+    default:
+      out.Verdict = VerdictLanded
+    }
+    switch out.InflightStatus{
+    case "Pending":
+      conflict("inflight status is Pending but %d of %d transactions landed", landed, len(out.Signatures))
+    case "Failed":
+      conflict("inflight status is Failed but %d of %d transactions landed", landed, len(out.Signatures))
+    }
+    if out.BundleStatus != "" && bundleSlot != slot{
+      conflict("getBundleStatuses reports slot %d, the transactions landed in slot %d", bundleSlot, slot)
+    }
+    return
+  }
+
+  // none of the signatures landed as far as the Solana RPC node knows
+  switch{
+  case out.BundleStatus != "":
+    out.Verdict, out.ConfirmationStatus, out.TxErr = VerdictLanded, rpc.ConfirmationStatusType(out.BundleStatus), bundleTxErr
+    if bundleTxErr != nil{
+      out.Verdict = VerdictFailed
+    }
+    if len(out.Statuses) > 0{
+      conflict("getBundleStatuses reports the bundle %s but the Solana RPC node doesn't know its transactions", out.BundleStatus)
+    }
+  case out.InflightStatus == "Landed":
+    out.Verdict, out.Slot = VerdictLanded, inflightSlot
+    if len(out.Statuses) > 0{
+      conflict("inflight status is Landed but the Solana RPC node doesn't know the transactions")
+    }
+  case out.InflightStatus == "Pending":
+    out.Verdict = VerdictPending
+  case out.InflightStatus == "Failed":
+    out.Verdict = VerdictRejected
+  default:
+    out.Verdict = VerdictUnknown
+  }
+}
+
+func confirmationRank(status rpc.ConfirmationStatusType) int{
+  switch status{
+  case rpc.ConfirmationStatusProcessed:
+    return 0
+  case rpc.ConfirmationStatusConfirmed:
+    return 1
+  case rpc.ConfirmationStatusFinalized:
+    return 2
+  }
+  return -1
+}
+
+// bundleStatusErr returns the error of a getBundleStatuses value, nil for {"Ok": null}.
+func bundleStatusErr(raw json.RawMessage) any{
+  if len(raw) == 0 || string(raw) == "null"{
+    return nil
+  }
+  var result map[string]any
+  if err := json.Unmarshal(raw, &result); err == nil{
+    if _, ok := result["Ok"]; ok{
+      return nil
+    }
+    if e, ok := result["Err"]; ok{
+      return e
+    }
+  }
+  return string(raw)
+}
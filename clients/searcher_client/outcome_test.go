@@ -0,0 +1,136 @@
+package searcher_client
+import(
+  "testing"
+
+  "github.com/scatkit/pumpdexer/rpc"
+  "github.com/scatkit/pumpdexer/solana"
+)
+
+func signatureStatus(slot uint64, status rpc.ConfirmationStatusType, err any) *rpc.SignatureStatusesResult{
+  return &rpc.SignatureStatusesResult{Slot: slot, ConfirmationStatus: status, Err: err}
+}
+
+func TestReconcileBundleOutcome(t *testing.T){
+  const (
+    processed = rpc.ConfirmationStatusProcessed
+    confirmed = rpc.ConfirmationStatusConfirmed
+    finalized = rpc.ConfirmationStatusFinalized
+  )
+  txErr := map[string]any{"InstructionError": []any{0, "Custom"}}
+
+  tests := []struct{
+    name         string
+    bundleStatus string
+    bundleSlot   uint64
+    bundleTxErr  any
+    inflight     string
+    inflightSlot uint64
+    statuses     []*rpc.SignatureStatusesResult // nil when the Solana RPC node wasn't asked
+
+    verdict      BundleVerdict
+    slot         uint64
+    confirmation rpc.ConfirmationStatusType
+    failed       bool
+    conflicts    int
+  }{
+    {
+      name:         "every source agrees",
+      bundleStatus: "confirmed", bundleSlot: 100, inflight: "Landed", inflightSlot: 100,
+      statuses:     []*rpc.SignatureStatusesResult{signatureStatus(100, confirmed, nil), signatureStatus(100, confirmed, nil)},
+      verdict:      VerdictLanded, slot: 100, confirmation: confirmed,
+    },
+    {
+      name:         "inflight Landed but the signatures are unknown",
+      inflight:     "Landed", inflightSlot: 90,
+      statuses:     []*rpc.SignatureStatusesResult{nil, nil},
+      verdict:      VerdictLanded, slot: 90, conflicts: 1,
+    },
+    {
+      name:         "some signatures landed and others failed",
+      inflight:     "Landed", inflightSlot: 100,
+      statuses:     []*rpc.SignatureStatusesResult{signatureStatus(100, confirmed, nil), signatureStatus(100, confirmed, txErr)},
+      verdict:      VerdictFailed, slot: 100, confirmation: confirmed, failed: true,
+    },
+    {
+      name:         "inflight Failed but a signature landed",
+      inflight:     "Failed",
+      statuses:     []*rpc.SignatureStatusesResult{signatureStatus(100, processed, nil), nil},
+      verdict:      VerdictPartial, slot: 100, confirmation: processed, conflicts: 1,
+    },
+    {
+      name:         "inflight Pending but every signature landed",
+      inflight:     "Pending",
+      statuses:     []*rpc.SignatureStatusesResult{signatureStatus(100, confirmed, nil), signatureStatus(100, confirmed, nil)},
+      verdict:      VerdictLanded, slot: 100, confirmation: confirmed, conflicts: 1,
+    },
+    {
+      name:         "the least confirmed signature sets the status",
+      statuses:     []*rpc.SignatureStatusesResult{signatureStatus(99, finalized, nil), signatureStatus(100, processed, nil)},
+      verdict:      VerdictLanded, slot: 100, confirmation: processed,
+    },
+    {
+      name:         "getBundleStatuses reports another slot",
+      bundleStatus: "confirmed", bundleSlot: 98,
+      statuses:     []*rpc.SignatureStatusesResult{signatureStatus(100, confirmed, nil)},
+      verdict:      VerdictLanded, slot: 100, confirmation: confirmed, conflicts: 1,
+    },
+    {
+      name:         "getBundleStatuses reports a failure the Solana RPC node doesn't know",
+      bundleStatus: "finalized", bundleSlot: 100, bundleTxErr: txErr,
+      statuses:     []*rpc.SignatureStatusesResult{nil},
+      verdict:      VerdictFailed, slot: 100, confirmation: finalized, failed: true, conflicts: 1,
+    },
+    {
+      name:         "getBundleStatuses alone",
+      bundleStatus: "confirmed", bundleSlot: 100,
+      verdict:      VerdictLanded, slot: 100, confirmation: confirmed,
+    },
+    {
+      name:         "still pending",
+      inflight:     "Pending",
+      statuses:     []*rpc.SignatureStatusesResult{nil},
+      verdict:      VerdictPending,
+    },
+    {
+      name:         "rejected without landing",
+      inflight:     "Failed",
+      statuses:     []*rpc.SignatureStatusesResult{nil, nil},
+      verdict:      VerdictRejected,
+    },
+    {
+      name:         "no source knows the bundle",
+      inflight:     "Invalid",
+      statuses:     []*rpc.SignatureStatusesResult{nil},
+      verdict:      VerdictUnknown,
+    },
+  }
+  for _, tt := range tests{
+    t.Run(tt.name, func(t *testing.T){
+      out := &BundleOutcome{
+        UUID:           "bundle",
+        BundleStatus:   tt.bundleStatus,
+        Slot:           tt.bundleSlot,
+        InflightStatus: tt.inflight,
+        Signatures:     make([]solana.Signature, max(len(tt.statuses), 1)),
+        Statuses:       tt.statuses,
+      }
+      out.reconcile(tt.bundleTxErr, tt.inflightSlot)
+
+      if out.Verdict != tt.verdict{
+        t.Errorf("verdict %s, want %s", out.Verdict, tt.verdict)
+      }
+      if out.Slot != tt.slot{
+        t.Errorf("slot %d, want %d", out.Slot, tt.slot)
+      }
+      if out.ConfirmationStatus != tt.confirmation{
+        t.Errorf("confirmation status %q, want %q", out.ConfirmationStatus, tt.confirmation)
+      }
+      if (out.TxErr != nil) != tt.failed{
+        t.Errorf("transaction error %v, want failed %v", out.TxErr, tt.failed)
+      }
+      if len(out.Conflicts) != tt.conflicts{
+        t.Errorf("conflicts %q, want %d", out.Conflicts, tt.conflicts)
+      }
+    })
+  }
+}
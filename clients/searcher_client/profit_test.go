@@ -0,0 +1,172 @@
+package searcher_client
+import(
+  "encoding/binary"
+  "testing"
+
+  "github.com/scatkit/pumpdexer/programs/system"
+  "github.com/scatkit/pumpdexer/solana"
+)
+
+var (
+  profitWallet = solana.NewWallet().PublicKey()
+  profitVictim = solana.NewWallet().PublicKey()
+  profitTip    = solana.NewWallet().PublicKey()
+  profitToken  = solana.NewWallet().PublicKey() // the wallet's wrapped SOL account
+  profitMint   = solana.NewWallet().PublicKey()
+)
+
+func lamports(n uint64) *SimulatedAccount{
+  return &SimulatedAccount{Lamports: n}
+}
+
+func tokenAccountOf(mint, owner solana.PublicKey, amount uint64) *SimulatedAccount{
+  data := make([]byte, tokenAccountSize)
+  copy(data[0:32], mint[:])
+  copy(data[32:64], owner[:])
+  binary.LittleEndian.PutUint64(data[64:72], amount)
+  return &SimulatedAccount{Owner: TokenProgramID, Lamports: 2_039_280, Data: data}
+}
+
+func transferTx(t *testing.T, from, to solana.PublicKey, amount uint64) *solana.Transaction{
+  t.Helper()
+  tx, err := solana.NewTransaction(
+    []solana.Instruction{system.NewTransferInstruction(amount, from, to).Build()},
+    solana.Hash{1},
+    solana.TransactionPayer(from),
+  )
+  if err != nil{
+    t.Fatal(err)
+  }
+  return tx
+}
+
+// profitSimulation simulates a two transaction bundle with the watched accounts going from pre to post.
+func profitSimulation(pre, post []*SimulatedAccount) *BundleSimulation{
+  return &BundleSimulation{TransactionResults: []SimulatedTransaction{
+    {PreExecutionAccounts: pre, PostExecutionAccounts: make([]*SimulatedAccount, len(pre)), UnitsConsumed: 150},
+    {PreExecutionAccounts: make([]*SimulatedAccount, len(pre)), PostExecutionAccounts: post, UnitsConsumed: 300},
+  }}
+}
+
+func TestAnalyzeSimulation(t *testing.T){
+  watched := []solana.PublicKey{profitWallet, profitVictim, profitToken}
+  // the victim's transaction, then the wallet's backrun paying a 10k tip
+  txs := []*solana.Transaction{
+    transferTx(t, profitVictim, profitWallet, 1),
+    transferTx(t, profitWallet, profitTip, 10_000),
+  }
+  tipAccounts := []string{profitTip.String()}
+
+  tests := []struct{
+    name       string
+    pre, post  []*SimulatedAccount
+    cfg        ProfitConfig
+    net        int64
+    tokens     int
+    profitable bool
+  }{
+    {
+      name:       "lamport profit above threshold",
+      pre:        []*SimulatedAccount{lamports(1_000_000), lamports(5_000_000), nil},
+      post:       []*SimulatedAccount{lamports(1_085_000), lamports(4_900_000), nil},
+      cfg:        ProfitConfig{Wallet: profitWallet, MinProfitLamports: 50_000},
+      net:        85_000,
+      profitable: true,
+    },
+    {
+      name:       "lamport profit below threshold",
+      pre:        []*SimulatedAccount{lamports(1_000_000), lamports(5_000_000), nil},
+      post:       []*SimulatedAccount{lamports(1_020_000), lamports(4_980_000), nil},
+      cfg:        ProfitConfig{Wallet: profitWallet, MinProfitLamports: 50_000},
+      net:        20_000,
+    },
+    {
+      name:       "loss paying the tip",
+      pre:        []*SimulatedAccount{lamports(1_000_000), lamports(5_000_000), nil},
+      post:       []*SimulatedAccount{lamports(985_000), lamports(5_000_000), nil},
+      cfg:        ProfitConfig{Wallet: profitWallet},
+      net:        -15_000,
+    },
+    {
+      name:       "wrapped SOL counts towards profit",
+      pre:        []*SimulatedAccount{lamports(1_000_000), lamports(5_000_000), tokenAccountOf(NativeMint, profitWallet, 0)},
+      post:       []*SimulatedAccount{lamports(985_000), lamports(5_000_000), tokenAccountOf(NativeMint, profitWallet, 400_000)},
+      cfg:        ProfitConfig{Wallet: profitWallet, MinProfitLamports: 100_000},
+      net:        385_000,
+      tokens:     1,
+      profitable: true,
+    },
+    {
+      name:       "other mints are reported but don't count",
+      pre:        []*SimulatedAccount{lamports(1_000_000), lamports(5_000_000), tokenAccountOf(profitMint, profitWallet, 10)},
+      post:       []*SimulatedAccount{lamports(985_000), lamports(5_000_000), tokenAccountOf(profitMint, profitWallet, 900)},
+      cfg:        ProfitConfig{Wallet: profitWallet},
+      net:        -15_000,
+      tokens:     1,
+    },
+    {
+      name:       "unwatched mints are left out",
+      pre:        []*SimulatedAccount{lamports(1_000_000), lamports(5_000_000), tokenAccountOf(profitMint, profitWallet, 10)},
+      post:       []*SimulatedAccount{lamports(1_000_000), lamports(5_000_000), tokenAccountOf(profitMint, profitWallet, 900)},
+      cfg:        ProfitConfig{Wallet: profitWallet, WatchedMints: []solana.PublicKey{NativeMint}},
+      profitable: true,
+    },
+  }
+  for _, tt := range tests{
+    t.Run(tt.name, func(t *testing.T){
+      report, err := AnalyzeSimulation(profitSimulation(tt.pre, tt.post), watched, txs, tipAccounts, &tt.cfg)
+      if err != nil{
+        t.Fatal(err)
+      }
+      if report.NetProfitLamports != tt.net{
+        t.Errorf("net profit %d, want %d", report.NetProfitLamports, tt.net)
+      }
+      if report.Profitable(tt.cfg.MinProfitLamports) != tt.profitable{
+        t.Errorf("profitable %v, want %v", !tt.profitable, tt.profitable)
+      }
+      if len(report.TokenDeltas) != tt.tokens{
+        t.Errorf("%d token deltas, want %d", len(report.TokenDeltas), tt.tokens)
+      }
+      if report.TipLamports != 10_000{
+        t.Errorf("tip %d, want 10000", report.TipLamports)
+      }
+      // only the wallet's transaction is paid for by the wallet
+      if report.FeeLamports != LamportsPerSignature{
+        t.Errorf("fee %d, want %d", report.FeeLamports, LamportsPerSignature)
+      }
+      if report.ComputeUnits != 450 || len(report.LamportDeltas) != len(watched){
+        t.Errorf("compute units %d, %d lamport deltas", report.ComputeUnits, len(report.LamportDeltas))
+      }
+    })
+  }
+}
+
+func TestAnalyzeSimulationRejectsInvalidInput(t *testing.T){
+  watched := []solana.PublicKey{profitWallet}
+  txs := []*solana.Transaction{transferTx(t, profitWallet, profitTip, 1)}
+  sim := profitSimulation([]*SimulatedAccount{lamports(1)}, []*SimulatedAccount{lamports(1)})
+  failed := profitSimulation([]*SimulatedAccount{lamports(1)}, []*SimulatedAccount{lamports(1)})
+  failed.Summary.Failed = &BundleSimulationFailure{Error: "BundleExecutionTimeout"}
+  cfg := &ProfitConfig{Wallet: profitWallet}
+
+  tests := []struct{
+    name    string
+    sim     *BundleSimulation
+    watched []solana.PublicKey
+    cfg     *ProfitConfig
+  }{
+    {"nil config", sim, watched, nil},
+    {"no wallet", sim, watched, &ProfitConfig{}},
+    {"nil simulation", nil, watched, cfg},
+    {"failed simulation", failed, watched, cfg},
+    {"no transaction results", &BundleSimulation{}, watched, cfg},
+    {"accounts don't match", sim, []solana.PublicKey{profitWallet, profitVictim}, cfg},
+  }
+  for _, tt := range tests{
+    t.Run(tt.name, func(t *testing.T){
+      if _, err := AnalyzeSimulation(tt.sim, tt.watched, txs, nil, tt.cfg); err == nil{
+        t.Fatal("analysis succeeded")
+      }
+    })
+  }
+}
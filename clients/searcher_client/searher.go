@@ -9,11 +9,17 @@ import (
   "strings"
   "fmt"
   "bufio"
+  "log/slog"
+  "sync"
   
   "github.com/scatkit/pumpdexer/rpc"
   "github.com/scatkit/pumpdexer/solana"
   "github.com/scatkit/gojito/pb"
   "github.com/scatkit/gojito/pkg"
+  "github.com/scatkit/gojito/dedup"
+  "github.com/scatkit/gojito/journal"
+  "github.com/scatkit/gojito/metrics"
+  "github.com/scatkit/gojito/tracing"
   
   "google.golang.org/grpc"
   "google.golang.org/grpc/credentials"
@@ -28,6 +34,17 @@ type Client struct{
   BundleStreamSubscription   jito_pb.SearcherService_SubscribeBundleResultsClient // Used for receiving *jito_pb.BundleResult (bundle broadcast status info).
   Auth *pkg.AuthenticationService 
   ErrChan chan error // ErrChan is used for dispatching errors from functions executed within goroutines.
+  Logger  *slog.Logger // discards all records unless set via SetLogger
+  Metrics metrics.Recorder // discards all measurements unless set via SetMetrics
+  ConfirmTimeout time.Duration // how long BroadcastBundleWithConfirmation waits for signature statuses (default 15s), counted from the last successful expiry check when Blockhashes knows the bundle's blockhash
+  Blockhashes    *pkg.BlockhashProvider // when set, bundles built from its blockhashes are awaited until the block height passes their last valid height
+  PollInterval   time.Duration // delay between signature status polls (default 1s)
+  Journal        *journal.Journal // when set, every bundle is journaled before it's sent (see the journal package)
+  Dedup          *dedup.Guard     // when set, repeat submissions of the same bundle or transactions are rejected or coalesced
+  region  string
+  
+  tipMu       sync.RWMutex
+  tipAccounts []string // cached by GetTipAccounts
 }
 
 // Creates a New Searcher client instance
@@ -46,10 +63,13 @@ func New(
   } else{
     opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{})))
   }
+  // spans are only recorded once the application installs an OpenTelemetry tracer provider
+  opts = append(opts, tracing.DialOption())
+  
+  cl := newClient(grpcDialURL, jitoRpcClient, rpcClient)
   
   // Ensure error monitoring for observable gRPC connection
-  chErr := make(chan error)
-  conn, err := pkg.CreateAndObserveGRPCConn(ctx, chErr, grpcDialURL, opts...)
+  conn, err := pkg.CreateAndObserveGRPCConnWithHook(ctx, cl.ErrChan, cl.onReconnect, grpcDialURL, opts...)
   if err != nil{
     return nil, err
   }
@@ -68,15 +88,52 @@ func New(
 		return nil, err
 	}
   
+  cl.GrpcConn = conn
+  cl.SearcherService = searcherService
+  cl.BundleStreamSubscription = subBundleRes
+  cl.Auth = authService
+  return cl, nil
+}
+
+// newClient returns a Client with everything but the gRPC services populated.
+func newClient(grpcDialURL string, jitoRpcClient, rpcClient *rpc.Client) *Client{
   return &Client{
-    GrpcConn: conn,
     RpcConn: rpcClient,
     JitoRpcConn: jitoRpcClient,
-    SearcherService: searcherService,
-    BundleStreamSubscription: subBundleRes,
-    Auth: authService,
-    ErrChan: chErr,
-  }, nil
+    ErrChan: make(chan error),
+    Logger: pkg.NopLogger(),
+    Metrics: metrics.Nop{},
+    ConfirmTimeout: 15 * time.Second,
+    PollInterval: time.Second,
+    region: pkg.RegionFromURL(grpcDialURL),
+  }
+}
+
+func (cl *Client) onReconnect(){
+  cl.Metrics.Reconnected(cl.region)
+}
+
+// SetLogger sets the structured logger used by the client and its authentication service.
+func (cl *Client) SetLogger(logger *slog.Logger){
+  if logger == nil{
+    logger = pkg.NopLogger()
+  }
+  cl.Logger = logger
+  if cl.Auth != nil{
+    cl.Auth.Logger = logger
+  }
+}
+
+// SetMetrics sets the metrics recorder used by the client and its authentication service.
+// It should be called before the client is used.
+func (cl *Client) SetMetrics(recorder metrics.Recorder){
+  if recorder == nil{
+    recorder = metrics.Nop{}
+  }
+  cl.Metrics = recorder
+  if cl.Auth != nil{
+    cl.Auth.Metrics = recorder
+  }
 }
 
 func (cl *Client) Close() error{
@@ -125,8 +182,10 @@ func NewNoAuth(
 		)
   }
   
-  chErr := make(chan error)
-	conn, err := pkg.CreateAndObserveGRPCConn(ctx, chErr, grpcDialURL, opts...)
+  opts = append(opts, tracing.DialOption())
+  
+  cl := newClient(grpcDialURL, jitoRpcClient, rpcClient)
+	conn, err := pkg.CreateAndObserveGRPCConnWithHook(ctx, cl.ErrChan, cl.onReconnect, grpcDialURL, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -134,15 +193,11 @@ func NewNoAuth(
   searcherService := jito_pb.NewSearcherServiceClient(conn)
 	subBundleRes, err := searcherService.SubscribeBundleResults(ctx, &jito_pb.SubscribeBundleResultsRequest{})
   
-  return &Client{
-		GrpcConn:                 conn,
-		RpcConn:                  rpcClient,
-		JitoRpcConn:              jitoRpcClient,
-		SearcherService:          searcherService,
-		BundleStreamSubscription: subBundleRes,
-		ErrChan:                  chErr,
-		Auth:                     &pkg.AuthenticationService{GrpcCtx: ctx},
-	}, nil
+  cl.GrpcConn = conn
+  cl.SearcherService = searcherService
+  cl.BundleStreamSubscription = subBundleRes
+  cl.Auth = &pkg.AuthenticationService{GrpcCtx: ctx, Logger: pkg.NopLogger(), Metrics: metrics.Nop{}}
+  return cl, nil
 }
 
 func createContextDialer(proxyStr string) (func(context.Context, string) (net.Conn, error), error) {
@@ -227,8 +282,9 @@ func parseProxyString(proxyStr string) (host string, port string, username strin
 	return parts[0], parts[1], parts[2], parts[3], nil
 }
 
-func isRPCNil(client *rpc.Client){
-  if client == nil{
-    client = rpc.New("https://api.mainnet-beta.solana.com")
+// ensureRPC falls back to the public mainnet-beta endpoint when no Solana RPC client was provided.
+func (cl *Client) ensureRPC(){
+  if cl.RpcConn == nil{
+    cl.RpcConn = rpc.New("https://api.mainnet-beta.solana.com")
   }
 }
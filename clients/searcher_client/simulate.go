@@ -0,0 +1,312 @@
+package searcher_client
+import(
+  "context"
+  "encoding/base64"
+  "encoding/json"
+  "errors"
+  "fmt"
+
+  "github.com/mr-tron/base58"
+  "github.com/scatkit/pumpdexer/rpc"
+  "github.com/scatkit/pumpdexer/solana"
+  "github.com/scatkit/gojito/tracing"
+)
+
+// SimulationBank selects the bank a bundle is simulated on. The zero value simulates on the RPC's working bank (tip).
+type SimulationBank struct{
+  Commitment rpc.CommitmentType // simulate on top of the bank with this commitment
+  Slot       uint64             // simulate on this slot's bank
+}
+
+func (b SimulationBank) MarshalJSON() ([]byte, error){
+  switch{
+  case b.Commitment != "":
+    return json.Marshal(map[string]any{"commitment": map[string]any{"commitment": b.Commitment}})
+  case b.Slot != 0:
+    return json.Marshal(map[string]any{"slot": b.Slot})
+  default:
+    return json.Marshal("tip")
+  }
+}
+
+type SimulateBundleOpts struct{
+  // WatchedAccounts are returned before and after the execution of every transaction in the bundle.
+  WatchedAccounts        []solana.PublicKey
+  SimulationBank         *SimulationBank
+  SkipSigVerify          bool
+  ReplaceRecentBlockhash bool
+}
+
+// BundleSimulation is the decoded result of simulateBundle.
+type BundleSimulation struct{
+  Slot               uint64
+  Summary            BundleSimulationSummary
+  TransactionResults []SimulatedTransaction
+}
+
+// BundleSimulationSummary is either "succeeded" or {"failed": {"error": ..., "tx_signature": ...}}.
+type BundleSimulationSummary struct{
+  Failed *BundleSimulationFailure
+}
+
+type BundleSimulationFailure struct{
+  Error       string // e.g. "TransactionFailure: ..." or "BundleExecutionTimeout"
+  TxSignature string // empty when the failure isn't tied to a transaction
+}
+
+func (s BundleSimulationSummary) Succeeded() bool{ return s.Failed == nil }
+
+// Err returns the simulation failure as an error (nil if the bundle succeeded).
+func (s BundleSimulationSummary) Err() error{
+  if s.Failed == nil{
+    return nil
+  }
+  if s.Failed.TxSignature != ""{
+    return NewSimulationFailureError(s.Failed.TxSignature, s.Failed.Error)
+  }
+  return fmt.Errorf("bundle simulation failed: %s", s.Failed.Error)
+}
+
+func (s *BundleSimulationSummary) UnmarshalJSON(data []byte) error{
+  var str string
+  if err := json.Unmarshal(data, &str); err == nil{
+    if str != "succeeded"{
+      return fmt.Errorf("unknown bundle simulation summary %q", str)
+    }
+    s.Failed = nil
+    return nil
+  }
+
+  var failed struct{
+    Failed *struct{
+      Error       json.RawMessage `json:"error"`
+      TxSignature *string         `json:"tx_signature"`
+    } `json:"failed"`
+  }
+  if err := json.Unmarshal(data, &failed); err != nil{
+    return err
+  }
+  if failed.Failed == nil{
+    return fmt.Errorf("unknown bundle simulation summary %s", data)
+  }
+  s.Failed = &BundleSimulationFailure{Error: bundleExecutionError(failed.Failed.Error)}
+  if failed.Failed.TxSignature != nil{
+    s.Failed.TxSignature = *failed.Failed.TxSignature
+  }
+  return nil
+}
+
+// bundleExecutionError flattens a serialized RpcBundleExecutionError, e.g. {"TransactionFailure": [sig, "msg"]}.
+func bundleExecutionError(raw json.RawMessage) string{
+  var str string
+  if err := json.Unmarshal(raw, &str); err == nil{
+    return str
+  }
+  var variant map[string]json.RawMessage
+  if err := json.Unmarshal(raw, &variant); err != nil || len(variant) != 1{
+    return string(raw)
+  }
+  for name, details := range variant{
+    var fields []json.RawMessage
+    if err := json.Unmarshal(details, &fields); err == nil && len(fields) > 0{
+      // the last field carries the message; the signature is reported in tx_signature
+      var msg string
+      if err := json.Unmarshal(fields[len(fields)-1], &msg); err == nil{
+        return fmt.Sprintf("%s: %s", name, msg)
+      }
+    }
+    return fmt.Sprintf("%s: %s", name, details)
+  }
+  return string(raw)
+}
+
+type SimulatedTransaction struct{
+  Err                   any
+  Logs                  []string
+  PreExecutionAccounts  []*SimulatedAccount // nil entries for accounts that don't exist
+  PostExecutionAccounts []*SimulatedAccount
+  UnitsConsumed         uint64
+  ReturnData            *SimulatedReturnData
+}
+
+type SimulatedAccount struct{
+  Executable bool
+  Owner      solana.PublicKey
+  Lamports   uint64
+  Data       []byte
+  RentEpoch  uint64
+}
+
+type SimulatedReturnData struct{
+  ProgramID solana.PublicKey
+  Data      []byte
+}
+
+type rawSimulatedAccount struct{
+  Executable bool             `json:"executable"`
+  Owner      solana.PublicKey `json:"owner"`
+  Lamports   uint64           `json:"lamports"`
+  Data       []string         `json:"data"`
+  RentEpoch  uint64           `json:"rentEpoch"`
+}
+
+type rawSimulatedTransaction struct{
+  Err                   any                    `json:"err"`
+  Logs                  []string               `json:"logs"`
+  PreExecutionAccounts  []*rawSimulatedAccount `json:"preExecutionAccounts"`
+  PostExecutionAccounts []*rawSimulatedAccount `json:"postExecutionAccounts"`
+  UnitsConsumed         *uint64                `json:"unitsConsumed"`
+  ReturnData            *struct{
+    ProgramID solana.PublicKey `json:"programId"`
+    Data      []string         `json:"data"`
+  } `json:"returnData"`
+}
+
+type rawBundleSimulation struct{
+  Context struct{
+    Slot uint64 `json:"slot"`
+  } `json:"context"`
+  Value struct{
+    Summary            BundleSimulationSummary   `json:"summary"`
+    TransactionResults []rawSimulatedTransaction `json:"transactionResults"`
+  } `json:"value"`
+}
+
+// decodeEncodedData decodes a [data, encoding] pair as returned by the RPC.
+func decodeEncodedData(pair []string) ([]byte, error){
+  if len(pair) == 0{
+    return nil, nil
+  }
+  encoding := "base58"
+  if len(pair) > 1{
+    encoding = pair[1]
+  }
+  switch encoding{
+  case "base64":
+    return base64.StdEncoding.DecodeString(pair[0])
+  case "base58":
+    return base58.Decode(pair[0])
+  default:
+    return nil, fmt.Errorf("unsupported data encoding %q", encoding)
+  }
+}
+
+func decodeSimulatedAccounts(raw []*rawSimulatedAccount) ([]*SimulatedAccount, error){
+  out := make([]*SimulatedAccount, 0, len(raw))
+  for _, acc := range raw{
+    if acc == nil{
+      out = append(out, nil)
+      continue
+    }
+    data, err := decodeEncodedData(acc.Data)
+    if err != nil{
+      return nil, err
+    }
+    out = append(out, &SimulatedAccount{
+      Executable: acc.Executable,
+      Owner:      acc.Owner,
+      Lamports:   acc.Lamports,
+      Data:       data,
+      RentEpoch:  acc.RentEpoch,
+    })
+  }
+  return out, nil
+}
+
+func (raw *rawBundleSimulation) decode() (*BundleSimulation, error){
+  out := &BundleSimulation{Slot: raw.Context.Slot, Summary: raw.Value.Summary}
+  for i, res := range raw.Value.TransactionResults{
+    tx := SimulatedTransaction{Err: res.Err, Logs: res.Logs}
+    if res.UnitsConsumed != nil{
+      tx.UnitsConsumed = *res.UnitsConsumed
+    }
+    var err error
+    if tx.PreExecutionAccounts, err = decodeSimulatedAccounts(res.PreExecutionAccounts); err != nil{
+      return nil, fmt.Errorf("%d: pre-execution accounts: %w", i, err)
+    }
+    if tx.PostExecutionAccounts, err = decodeSimulatedAccounts(res.PostExecutionAccounts); err != nil{
+      return nil, fmt.Errorf("%d: post-execution accounts: %w", i, err)
+    }
+    if res.ReturnData != nil{
+      data, err := decodeEncodedData(res.ReturnData.Data)
+      if err != nil{
+        return nil, fmt.Errorf("%d: return data: %w", i, err)
+      }
+      tx.ReturnData = &SimulatedReturnData{ProgramID: res.ReturnData.ProgramID, Data: data}
+    }
+    out.TransactionResults = append(out.TransactionResults, tx)
+  }
+  return out, nil
+}
+
+// SimulateBundle simulates a bundle on a Jito-Solana RPC (JitoRpcConn) and decodes the result.
+// A failed simulation is not an error: check Summary.Succeeded or Summary.Err.
+func (cl *Client) SimulateBundle(ctx context.Context, transactions []*solana.Transaction, opts *SimulateBundleOpts) (out *BundleSimulation, err error){
+  if len(transactions) == 0{
+    return nil, errors.New("bundle must contain at least one transaction")
+  }
+  if opts == nil{
+    opts = &SimulateBundleOpts{}
+  }
+  ctx, span := tracing.Start(ctx, "jito.bundle.simulate", cl.bundleAttributes(transactions)...)
+  defer func(){ tracing.End(span, err) }()
+
+  encoded := make([]string, 0, len(transactions))
+  for i, tx := range transactions{
+    data, err := tx.MarshalBinary()
+    if err != nil{
+      return nil, fmt.Errorf("%d: failed to encode transaction: %w", i, err)
+    }
+    encoded = append(encoded, base64.StdEncoding.EncodeToString(data))
+  }
+
+  // one (possibly null) accounts config per transaction
+  var accountsConfig *ExecutionAccounts
+  if len(opts.WatchedAccounts) > 0{
+    addresses := make([]string, 0, len(opts.WatchedAccounts))
+    for _, pk := range opts.WatchedAccounts{
+      addresses = append(addresses, pk.String())
+    }
+    accountsConfig = &ExecutionAccounts{Encoding: "base64", Addresses: addresses}
+  }
+  accountsConfigs := make([]*ExecutionAccounts, len(transactions))
+  for i := range accountsConfigs{
+    accountsConfigs[i] = accountsConfig
+  }
+
+  config := map[string]any{
+    "preExecutionAccountsConfigs":  accountsConfigs,
+    "postExecutionAccountsConfigs": accountsConfigs,
+    "transactionEncoding":          "base64",
+    "skipSigVerify":                opts.SkipSigVerify,
+    "replaceRecentBlockhash":       opts.ReplaceRecentBlockhash,
+  }
+  if opts.SimulationBank != nil{
+    config["simulationBank"] = opts.SimulationBank
+  }
+
+  var raw rawBundleSimulation
+  params := []interface{}{SimulateBundleParams{EncodedTransactions: encoded}, config}
+  if err = cl.JitoRpcConn.RPCCallForInfo(ctx, &raw, "simulateBundle", params); err != nil{
+    return nil, err
+  }
+  return raw.decode()
+}
+
+// SimulateRawBundle simulates already encoded transactions and returns the undecoded response.
+// Like SimulateBundle it's exclusively available on Jito-Solana RPC nodes.
+func (cl *Client) SimulateRawBundle(ctx context.Context, bundleParams SimulateBundleParams, simulationConfigs SimulateBundleConfig) (*SimulatedBundleResponse, error) {
+	if len(bundleParams.EncodedTransactions) != len(simulationConfigs.PreExecutionAccountsConfigs) ||
+		len(bundleParams.EncodedTransactions) != len(simulationConfigs.PostExecutionAccountsConfigs) {
+		return nil, errors.New("pre/post execution account config length must match bundle length")
+	}
+	var out SimulatedBundleResponse
+  params := []interface{}{
+    bundleParams,
+    simulationConfigs,
+  }
+  ctx, span := tracing.Start(ctx, "jito.bundle.simulate", tracing.RegionKey.String(cl.region))
+	err := cl.JitoRpcConn.RPCCallForInfo(ctx, &out, "simulateBundle", params)
+  tracing.End(span, err)
+	return &out, err
+}
@@ -0,0 +1,138 @@
+package searcher_client
+import(
+  "strings"
+  "testing"
+
+  "github.com/scatkit/pumpdexer/solana"
+  "github.com/scatkit/gojito/pb"
+)
+
+var (
+  verifyPayer = solana.NewWallet().PublicKey()
+  verifyTip   = solana.NewWallet().PublicKey()
+)
+
+// blockTx is a transaction of a confirmed block signed with sig, paying the 5000 lamports fee and tip to the tip
+// account.
+func blockTx(sig solana.Signature, tip uint64, txErr string) *jito_pb.ConfirmedTransaction{
+  units := uint64(1_000)
+  meta := &jito_pb.TransactionStatusMeta{
+    Fee:                  5_000,
+    PreBalances:          []uint64{1_000_000, 0},
+    PostBalances:         []uint64{1_000_000 - 5_000 - tip, tip},
+    ComputeUnitsConsumed: &units,
+  }
+  if txErr != ""{
+    meta.Err = &jito_pb.TransactionError{Err: []byte(txErr)}
+  }
+  return &jito_pb.ConfirmedTransaction{
+    Transaction: &jito_pb.Transaction{
+      Signatures: [][]byte{sig[:]},
+      Message:    &jito_pb.Message{AccountKeys: [][]byte{verifyPayer[:], verifyTip[:]}},
+    },
+    Meta: meta,
+  }
+}
+
+func TestVerifyBundleInBlock(t *testing.T){
+  a, b, other := solana.Signature{1}, solana.Signature{2}, solana.Signature{3}
+  bundle := []solana.Signature{a, b}
+  tipAccounts := []string{verifyTip.String()}
+  const failure = `{"InstructionError":[0,{"Custom":1}]}`
+
+  tests := []struct{
+    name        string
+    block       []*jito_pb.ConfirmedTransaction
+    tipAccounts []string
+    positions   []int
+    landed      int
+    contiguous  bool
+    inOrder     bool
+    failedIndex int
+    tip         uint64
+    err         string // in the error of the verification, empty if it passes
+  }{
+    {
+      name:        "landed atomically",
+      block:       []*jito_pb.ConfirmedTransaction{blockTx(other, 0, ""), blockTx(a, 0, ""), blockTx(b, 10_000, "")},
+      tipAccounts: tipAccounts,
+      positions:   []int{1, 2}, landed: 2, contiguous: true, inOrder: true, failedIndex: -1, tip: 10_000,
+    },
+    {
+      name:        "not contiguous",
+      block:       []*jito_pb.ConfirmedTransaction{blockTx(a, 0, ""), blockTx(other, 0, ""), blockTx(b, 10_000, "")},
+      tipAccounts: tipAccounts,
+      positions:   []int{0, 2}, landed: 2, inOrder: true, failedIndex: -1, tip: 10_000,
+      err:         "not contiguous",
+    },
+    {
+      name:        "different order",
+      block:       []*jito_pb.ConfirmedTransaction{blockTx(b, 10_000, ""), blockTx(a, 0, "")},
+      tipAccounts: tipAccounts,
+      positions:   []int{1, 0}, landed: 2, failedIndex: -1, tip: 10_000,
+      err:         "out of order",
+    },
+    {
+      name:        "tip missing",
+      block:       []*jito_pb.ConfirmedTransaction{blockTx(a, 0, ""), blockTx(b, 0, "")},
+      tipAccounts: tipAccounts,
+      positions:   []int{0, 1}, landed: 2, contiguous: true, inOrder: true, failedIndex: -1,
+    },
+    {
+      name:        "tip accounts unknown",
+      block:       []*jito_pb.ConfirmedTransaction{blockTx(a, 0, ""), blockTx(b, 10_000, "")},
+      positions:   []int{0, 1}, landed: 2, contiguous: true, inOrder: true, failedIndex: -1,
+    },
+    {
+      name:        "failed transaction",
+      block:       []*jito_pb.ConfirmedTransaction{blockTx(a, 0, ""), blockTx(b, 10_000, failure)},
+      tipAccounts: tipAccounts,
+      positions:   []int{0, 1}, landed: 2, contiguous: true, inOrder: true, failedIndex: 1, tip: 10_000,
+      err:         "failed: " + failure,
+    },
+    {
+      name:        "partially landed",
+      block:       []*jito_pb.ConfirmedTransaction{blockTx(other, 0, ""), blockTx(a, 0, "")},
+      tipAccounts: tipAccounts,
+      positions:   []int{1, -1}, landed: 1, contiguous: true, inOrder: true, failedIndex: -1,
+      err:         "partially landed",
+    },
+    {
+      name:        "not in the block",
+      block:       []*jito_pb.ConfirmedTransaction{blockTx(other, 10_000, "")},
+      tipAccounts: tipAccounts,
+      positions:   []int{-1, -1}, contiguous: true, inOrder: true, failedIndex: -1,
+      err:         "not found",
+    },
+  }
+  for _, tt := range tests{
+    t.Run(tt.name, func(t *testing.T){
+      v := VerifyBundleInBlock(&jito_pb.ConfirmedBlock{Blockhash: "hash", Transactions: tt.block}, 42, bundle, tt.tipAccounts)
+
+      for i := range tt.positions{
+        if v.Positions[i] != tt.positions[i]{
+          t.Fatalf("positions %v, want %v", v.Positions, tt.positions)
+        }
+      }
+      if v.Landed != tt.landed || v.Contiguous != tt.contiguous || v.InOrder != tt.inOrder{
+        t.Errorf("landed %d, contiguous %v, in order %v, want %d, %v, %v", v.Landed, v.Contiguous, v.InOrder, tt.landed, tt.contiguous, tt.inOrder)
+      }
+      if v.FailedIndex != tt.failedIndex{
+        t.Errorf("failed index %d, want %d", v.FailedIndex, tt.failedIndex)
+      }
+      if v.TipLamports != tt.tip{
+        t.Errorf("tip %d, want %d", v.TipLamports, tt.tip)
+      }
+      if v.FeeLamports != 5_000*uint64(tt.landed) || v.ComputeUnits != 1_000*uint64(tt.landed){
+        t.Errorf("fee %d and %d compute units for %d transactions", v.FeeLamports, v.ComputeUnits, tt.landed)
+      }
+      err := v.Err()
+      switch{
+      case tt.err == "" && err != nil:
+        t.Errorf("verification failed: %v", err)
+      case tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)):
+        t.Errorf("error %v, want %q", err, tt.err)
+      }
+    })
+  }
+}
@@ -0,0 +1,207 @@
+package validator_client
+import(
+  "context"
+  "crypto/tls"
+  "fmt"
+  "log/slog"
+  "time"
+
+  "github.com/scatkit/pumpdexer/solana"
+  "github.com/scatkit/gojito/pb"
+  "github.com/scatkit/gojito/pkg"
+  "github.com/scatkit/gojito/tracing"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/credentials"
+)
+
+type Client struct{
+  GrpcConn         *grpc.ClientConn
+  ValidatorService jito_pb.BlockEngineValidatorClient
+  Auth             *pkg.AuthenticationService
+  ErrChan          chan error // ErrChan is used for dispatching errors from functions executed within goroutines.
+  Logger           *slog.Logger
+}
+
+// New creates a block engine client authenticated with the validator role.
+// The private key must be the validator's identity.
+func New(
+  ctx context.Context,
+  grpcDialURL string,
+  privateKey solana.PrivateKey,
+  tlsConfig *tls.Config,
+  opts ...grpc.DialOption,
+) (*Client, error){
+  if tlsConfig != nil{
+    opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+  } else{
+    opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{})))
+  }
+  opts = append(opts, tracing.DialOption())
+
+  chErr := make(chan error)
+  conn, err := pkg.CreateAndObserveGRPCConn(ctx, chErr, grpcDialURL, opts...)
+  if err != nil{
+    return nil, err
+  }
+
+  authService := pkg.NewAuthenticationService(conn, privateKey)
+  if err = authService.AuthenticateAndRefresh(jito_pb.Role_VALIDATOR); err != nil{
+    return nil, err
+  }
+
+  return &Client{
+    GrpcConn:         conn,
+    ValidatorService: jito_pb.NewBlockEngineValidatorClient(conn),
+    Auth:             authService,
+    ErrChan:          chErr,
+    Logger:           pkg.NopLogger(),
+  }, nil
+}
+
+// SetLogger sets the structured logger used by the client and its authentication service.
+func (c *Client) SetLogger(logger *slog.Logger){
+  if logger == nil{
+    logger = pkg.NopLogger()
+  }
+  c.Logger = logger
+  c.Auth.Logger = logger
+}
+
+func (c *Client) Close() error{
+  return c.GrpcConn.Close()
+}
+
+// BlockBuilderFeeInfo is the fee the block builder collects from the blocks it builds.
+type BlockBuilderFeeInfo struct{
+  Pubkey     solana.PublicKey
+  Commission uint64 // percentage
+}
+
+// GetBlockBuilderFeeInfo returns the block builder's fee account and commission.
+func (c *Client) GetBlockBuilderFeeInfo(ctx context.Context, opts ...grpc.CallOption) (*BlockBuilderFeeInfo, error){
+  resp, err := c.ValidatorService.GetBlockBuilderFeeInfo(c.Auth.Context(ctx), &jito_pb.BlockBuilderFeeInfoRequest{}, opts...)
+  if err != nil{
+    return nil, err
+  }
+  pubkey, err := solana.PublicKeyFromBase58(resp.Pubkey)
+  if err != nil{
+    return nil, fmt.Errorf("invalid block builder pubkey %q: %w", resp.Pubkey, err)
+  }
+  return &BlockBuilderFeeInfo{Pubkey: pubkey, Commission: resp.Commission}, nil
+}
+
+// Packet is a transaction forwarded by the block engine.
+type Packet struct{
+  Transaction *solana.Transaction
+  Meta        *jito_pb.Meta
+}
+
+type PacketBatch struct{
+  Timestamp time.Time // set by the block engine
+  Packets   []*Packet
+}
+
+// Bundle is a simulated bundle forwarded by the block engine.
+type Bundle struct{
+  UUID         string
+  Timestamp    time.Time // set by the block engine
+  Transactions []*solana.Transaction
+}
+
+// SubscribePackets streams the packets sent by the block engine on the returned channel. The channel is closed when
+// ctx is done or the stream fails, in which case the error is dispatched on ErrChan.
+// Packets that don't decode to a transaction are skipped.
+func (c *Client) SubscribePackets(ctx context.Context, buffer int, opts ...grpc.CallOption) (<-chan *PacketBatch, error){
+  stream, err := c.ValidatorService.SubscribePackets(c.Auth.Context(ctx), &jito_pb.SubscribePacketsRequestBlockEngine{}, opts...)
+  if err != nil{
+    return nil, err
+  }
+  out := make(chan *PacketBatch, buffer)
+  go func(){
+    defer close(out)
+    for{
+      resp, err := stream.Recv()
+      if err != nil{
+        c.dispatchErr(ctx, fmt.Errorf("block engine packet stream: %w", err))
+        return
+      }
+      batch := &PacketBatch{Timestamp: timestamp(resp.GetHeader())}
+      for _, packet := range resp.GetBatch().GetPackets(){
+        tx, err := pkg.ConvertProtobufPacketToTransaction(packet)
+        if err != nil{
+          c.Logger.Debug("skipping undecodable packet", slog.Any("error", err))
+          continue
+        }
+        batch.Packets = append(batch.Packets, &Packet{Transaction: tx, Meta: packet.GetMeta()})
+      }
+      select{
+      case out <- batch:
+      case <-ctx.Done():
+        return
+      }
+    }
+  }()
+  return out, nil
+}
+
+// SubscribeBundles streams the bundles sent by the block engine on the returned channel. The channel is closed when
+// ctx is done or the stream fails, in which case the error is dispatched on ErrChan.
+// Bundles containing a packet that doesn't decode to a transaction are skipped.
+func (c *Client) SubscribeBundles(ctx context.Context, buffer int, opts ...grpc.CallOption) (<-chan *Bundle, error){
+  stream, err := c.ValidatorService.SubscribeBundles(c.Auth.Context(ctx), &jito_pb.SubscribeBundlesRequest{}, opts...)
+  if err != nil{
+    return nil, err
+  }
+  out := make(chan *Bundle, buffer)
+  go func(){
+    defer close(out)
+    for{
+      resp, err := stream.Recv()
+      if err != nil{
+        c.dispatchErr(ctx, fmt.Errorf("block engine bundle stream: %w", err))
+        return
+      }
+      for _, uuid := range resp.GetBundles(){
+        bundle, err := decodeBundle(uuid)
+        if err != nil{
+          c.Logger.Debug("skipping undecodable bundle", slog.String("uuid", uuid.GetUuid()), slog.Any("error", err))
+          continue
+        }
+        select{
+        case out <- bundle:
+        case <-ctx.Done():
+          return
+        }
+      }
+    }
+  }()
+  return out, nil
+}
+
+func decodeBundle(uuid *jito_pb.BundleUuid) (*Bundle, error){
+  bundle := &Bundle{UUID: uuid.GetUuid(), Timestamp: timestamp(uuid.GetBundle().GetHeader())}
+  for i, packet := range uuid.GetBundle().GetPackets(){
+    tx, err := pkg.ConvertProtobufPacketToTransaction(packet)
+    if err != nil{
+      return nil, fmt.Errorf("packet %d: %w", i, err)
+    }
+    bundle.Transactions = append(bundle.Transactions, tx)
+  }
+  return bundle, nil
+}
+
+func timestamp(header *jito_pb.Header) time.Time{
+  if ts := header.GetTs(); ts != nil{
+    return ts.AsTime()
+  }
+  return time.Time{}
+}
+
+// dispatchErr sends err on ErrChan unless ctx is done first, which also covers the expected cancellation error.
+func (c *Client) dispatchErr(ctx context.Context, err error){
+  select{
+  case c.ErrChan <- err:
+  case <-ctx.Done():
+  }
+}
@@ -0,0 +1,575 @@
+package main
+import(
+  "context"
+  "errors"
+  "fmt"
+  "log/slog"
+  "os"
+  "path/filepath"
+  "strconv"
+  "strings"
+  "time"
+
+  "github.com/scatkit/pumpdexer/rpc"
+  "github.com/scatkit/pumpdexer/solana"
+  "github.com/scatkit/gojito/clients/searcher_client"
+  "github.com/scatkit/gojito/manifest"
+  "github.com/scatkit/gojito/pb"
+  "github.com/scatkit/gojito/pkg"
+
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+)
+
+// Outcome statuses reported by send-bundle and send-tx.
+const (
+  statusSent     = "sent"
+  statusAccepted = "accepted"
+  statusLanded   = "landed"
+  statusRejected = "rejected"
+  statusDropped  = "dropped"
+  statusFailed   = "failed" // landed but a transaction failed
+  statusTimeout  = "timeout"
+)
+
+// outcome is the final state of a submission.
+type outcome struct{
+  UUID       string   `json:"uuid,omitempty"`
+  Signatures []string `json:"signatures"`
+  Status     string   `json:"status"`
+  Slot       uint64   `json:"slot,omitempty"`
+  Validator  string   `json:"validator,omitempty"`
+  Reason     string   `json:"reason,omitempty"`
+}
+
+// err maps the outcome to the command's exit code.
+func (o *outcome) err() error{
+  switch o.Status{
+  case statusRejected, statusDropped, statusFailed:
+    return withExitCode(exitRejected, fmt.Errorf("%s: %s", o.Status, o.Reason))
+  case statusTimeout:
+    return withExitCode(exitNotLanded, errors.New("not landed before the timeout"))
+  }
+  return nil
+}
+
+func (o *outcome) print(p *printer){
+  p.print(o, func(){
+    if o.UUID != ""{
+      fmt.Printf("bundle:     %s\n", o.UUID)
+    }
+    fmt.Printf("signatures: %s\n", strings.Join(o.Signatures, ", "))
+    fmt.Printf("status:     %s\n", o.Status)
+    if o.Slot != 0{
+      fmt.Printf("slot:       %d\n", o.Slot)
+    }
+    if o.Validator != ""{
+      fmt.Printf("validator:  %s\n", o.Validator)
+    }
+    if o.Reason != ""{
+      fmt.Printf("reason:     %s\n", o.Reason)
+    }
+  })
+}
+
+func parseWait(wait string, allowed ...string) error{
+  for _, a := range allowed{
+    if wait == a{
+      return nil
+    }
+  }
+  return usageErrorf("-wait must be one of %s", strings.Join(allowed, ", "))
+}
+
+func runSendBundle(args []string) error{
+  var cfg config
+  fs := newFlagSet("send-bundle", "<file|manifest.{json,yaml}|->...", &cfg)
+  encoding := fs.String("encoding", "auto", "transaction encoding: auto, base64 or base58")
+  wait := fs.String("wait", "landed", "wait until the bundle is: none (sent), accepted or landed. Landing is observed with -rpc, or on Processed/Finalized results")
+  record := fs.String("record", "", "write the signed bundle to this manifest file (.json or .yaml) to replay it exactly")
+  if err := cfg.parse(fs, args); err != nil{
+    return err
+  }
+  if err := parseWait(*wait, "none", "accepted", "landed"); err != nil{
+    return err
+  }
+  if len(cfg.args) == 0{
+    fs.Usage()
+    return usageErrorf("no transaction files given")
+  }
+  ctx, cancel := cfg.context()
+  defer cancel()
+
+  cl, err := cfg.searcher(ctx)
+  if err != nil{
+    return err
+  }
+  txns, manifests, err := cfg.readBundle(ctx, cfg.args, *encoding, func() ([]string, error){
+    resp, err := cl.GetTipAccounts()
+    if err != nil{
+      return nil, err
+    }
+    return resp.Accounts, nil
+  })
+  if err != nil{
+    return err
+  }
+  if *record != ""{
+    if err = recordBundle(*record, txns); err != nil{
+      return err
+    }
+  }
+  if requiresSimulation(manifests){
+    if cfg.rpc == ""{
+      return usageErrorf("the manifest requires a simulation, which needs -rpc pointing to a Jito-Solana RPC node")
+    }
+    sim, err := cfg.simulator().SimulateBundle(ctx, txns, simulateOpts(manifests, &searcher_client.SimulateBundleOpts{}))
+    if err != nil{
+      return err
+    }
+    if err = checkSimulation(sim, manifests); err != nil{
+      return withExitCode(exitRejected, fmt.Errorf("bundle not sent: %w", err))
+    }
+  }
+
+  // subscribed before sending so no result is missed, and closed as soon as the wait is over
+  var results jito_pb.SearcherService_SubscribeBundleResultsClient
+  streamCtx, closeStream := context.WithCancel(ctx)
+  defer closeStream()
+  if *wait != "none"{
+    results, err = cl.SearcherService.SubscribeBundleResults(cl.Auth.Context(streamCtx), &jito_pb.SubscribeBundleResultsRequest{})
+    if err != nil{
+      return err
+    }
+  }
+
+  resp, err := cl.BroadcastBundle(txns)
+  if status.Code(err) == codes.InvalidArgument{
+    return withExitCode(exitRejected, err)
+  }
+  if err != nil{
+    return err
+  }
+  sigs := pkg.BatchExtractSigFromTx(txns)
+  out := &outcome{UUID: resp.Uuid, Signatures: pkg.SignatureStrings(sigs), Status: statusSent}
+  if *wait != "none"{
+    err = waitForBundle(ctx, cl, results, out, sigs, *wait == "landed", cfg.solanaRPC())
+    closeStream()
+  }
+  if err != nil{
+    return err
+  }
+  out.print(cfg.printer())
+  return out.err()
+}
+
+// recordBundle writes txns to path as a manifest of prebuilt transactions.
+func recordBundle(path string, txns []*solana.Transaction) error{
+  m, err := manifest.Record(filepath.Base(path), txns)
+  if err != nil{
+    return err
+  }
+  format := strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+  if !isManifest(path){
+    format = "json"
+  }
+  data, err := m.Marshal(format)
+  if err != nil{
+    return err
+  }
+  return os.WriteFile(path, data, 0o644)
+}
+
+func requiresSimulation(manifests []*manifest.Manifest) bool{
+  for _, m := range manifests{
+    if m.SimulationRequired(){
+      return true
+    }
+  }
+  return false
+}
+
+// simulateOpts adds the simulation options of the manifests to opts.
+func simulateOpts(manifests []*manifest.Manifest, opts *searcher_client.SimulateBundleOpts) *searcher_client.SimulateBundleOpts{
+  for _, m := range manifests{
+    if mopts := m.SimulateOpts(); mopts != nil{
+      opts.SkipSigVerify = opts.SkipSigVerify || mopts.SkipSigVerify
+      opts.ReplaceRecentBlockhash = opts.ReplaceRecentBlockhash || mopts.ReplaceRecentBlockhash
+      opts.WatchedAccounts = append(opts.WatchedAccounts, mopts.WatchedAccounts...)
+    }
+  }
+  return opts
+}
+
+// checkSimulation returns the first simulation requirement of the manifests sim doesn't meet.
+func checkSimulation(sim *searcher_client.BundleSimulation, manifests []*manifest.Manifest) error{
+  if err := sim.Summary.Err(); err != nil{
+    return err
+  }
+  for _, m := range manifests{
+    if err := m.CheckSimulation(sim); err != nil{
+      return err
+    }
+  }
+  return nil
+}
+
+// waitForBundle follows the bundle's results on stream until it is accepted, or landed when untilLanded is set,
+// and updates out. With a Solana RPC client the signatures are also polled, which detects landing when the block
+// engine doesn't report it. The caller cancels the stream's context afterwards, which stops its reader.
+func waitForBundle(ctx context.Context, cl *searcher_client.Client, stream jito_pb.SearcherService_SubscribeBundleResultsClient, out *outcome, sigs []solana.Signature, untilLanded bool, solanaRPC *rpc.Client) error{
+  results := make(chan *jito_pb.BundleResult)
+  errs := make(chan error, 1)
+  go func(){
+    for{
+      result, err := stream.Recv()
+      if err != nil{
+        errs <- err
+        return
+      }
+      if result.GetBundleId() != out.UUID{
+        continue
+      }
+      select{
+      case results <- result:
+      case <-stream.Context().Done():
+        return
+      }
+    }
+  }()
+
+  var poll <-chan time.Time
+  if untilLanded && solanaRPC != nil{
+    ticker := time.NewTicker(time.Second)
+    defer ticker.Stop()
+    poll = ticker.C
+  }
+
+  for{
+    select{
+    case <-ctx.Done():
+      out.Status = statusTimeout
+      return nil
+    case err := <-errs:
+      return fmt.Errorf("bundle result stream: %w", err)
+    case <-poll:
+      landed, err := signaturesLanded(ctx, solanaRPC, sigs, out)
+      if err != nil{
+        cl.Logger.Debug("signature status poll failed", slog.Any("error", err))
+      }
+      if landed{
+        return nil
+      }
+    case result := <-results:
+      switch r := result.Result.(type){
+      case *jito_pb.BundleResult_Accepted:
+        out.Status, out.Slot, out.Validator = statusAccepted, r.Accepted.Slot, r.Accepted.ValidatorIdentity
+        if !untilLanded{
+          return nil
+        }
+      case *jito_pb.BundleResult_Processed:
+        out.Status, out.Slot, out.Validator = statusLanded, r.Processed.Slot, r.Processed.ValidatorIdentity
+        return nil
+      case *jito_pb.BundleResult_Finalized:
+        out.Status = statusLanded
+        return nil
+      case *jito_pb.BundleResult_Rejected:
+        out.Status, out.Reason = statusRejected, rejectionReason(r.Rejected)
+        return nil
+      case *jito_pb.BundleResult_Dropped:
+        out.Status, out.Reason = statusDropped, r.Dropped.Reason.String()
+        return nil
+      }
+    }
+  }
+}
+
+// rejectionReason describes a rejection with the searcher client's error messages.
+func rejectionReason(rejected *jito_pb.Rejected) string{
+  var err error
+  switch r := rejected.Reason.(type){
+  case *jito_pb.Rejected_SimulationFailure:
+    err = searcher_client.NewSimulationFailureError(r.SimulationFailure.TxSignature, r.SimulationFailure.GetMsg())
+  case *jito_pb.Rejected_StateAuctionBidRejected:
+    err = searcher_client.NewStateAuctionBidRejectedError(r.StateAuctionBidRejected.AuctionId, r.StateAuctionBidRejected.SimulatedBidLamports)
+  case *jito_pb.Rejected_WinningBatchBidRejected:
+    err = searcher_client.NewWinningBatchBidRejectedError(r.WinningBatchBidRejected.AuctionId, r.WinningBatchBidRejected.SimulatedBidLamports)
+  case *jito_pb.Rejected_InternalError:
+    err = searcher_client.NewInternalError(r.InternalError.Msg)
+  case *jito_pb.Rejected_DroppedBundle:
+    err = searcher_client.NewDroppedBundle(r.DroppedBundle.Msg)
+  default:
+    return "unknown rejection"
+  }
+  return err.Error()
+}
+
+// signaturesLanded reports whether every signature is confirmed, setting out to landed or failed if so.
+func signaturesLanded(ctx context.Context, solanaRPC *rpc.Client, sigs []solana.Signature, out *outcome) (bool, error){
+  statuses, err := solanaRPC.GetSignatureStatuses(ctx, false, sigs...)
+  if err != nil{
+    return false, err
+  }
+  if len(statuses.Value) != len(sigs){
+    return false, nil
+  }
+  for _, st := range statuses.Value{
+    if st == nil || (st.ConfirmationStatus != rpc.ConfirmationStatusConfirmed && st.ConfirmationStatus != rpc.ConfirmationStatusFinalized){
+      return false, nil
+    }
+  }
+  out.Status, out.Slot = statusLanded, statuses.Value[0].Slot
+  for i, st := range statuses.Value{
+    if st.Err != nil{
+      out.Status, out.Reason = statusFailed, fmt.Sprintf("transaction %s failed: %v", sigs[i], st.Err)
+      break
+    }
+  }
+  return true, nil
+}
+
+func runSendTx(args []string) error{
+  var cfg config
+  fs := newFlagSet("send-tx", "<file|manifest.{json,yaml}|->", &cfg)
+  encoding := fs.String("encoding", "auto", "transaction encoding: auto, base64 or base58")
+  bundleOnly := fs.Bool("bundle-only", false, "only send the transaction as a single transaction bundle")
+  wait := fs.String("wait", "none", "wait until the transaction is: none (sent) or landed, which requires -rpc")
+  if err := cfg.parse(fs, args); err != nil{
+    return err
+  }
+  if err := parseWait(*wait, "none", "landed"); err != nil{
+    return err
+  }
+  if *wait == "landed" && cfg.rpc == ""{
+    return usageErrorf("-wait landed requires -rpc")
+  }
+  if len(cfg.args) != 1{
+    fs.Usage()
+    return usageErrorf("expected a single transaction file")
+  }
+  ctx, cancel := cfg.context()
+  defer cancel()
+
+  txns, _, err := cfg.readBundle(ctx, cfg.args, *encoding, nil)
+  if err != nil{
+    return err
+  }
+  if len(txns) != 1{
+    return usageErrorf("expected a single transaction, got %d", len(txns))
+  }
+
+  resp, err := cfg.jito().SendTransaction(ctx, txns[0], *bundleOnly)
+  if err != nil{
+    return err
+  }
+  sig := resp.Signature()
+  out := &outcome{UUID: resp.BundleID(), Signatures: []string{sig.String()}, Status: statusSent}
+  if *wait == "landed"{
+    if err = waitForSignatures(ctx, cfg.solanaRPC(), []solana.Signature{sig}, out); err != nil{
+      return err
+    }
+  }
+  out.print(cfg.printer())
+  return out.err()
+}
+
+// waitForSignatures polls the signatures every second until they land or ctx is done.
+func waitForSignatures(ctx context.Context, solanaRPC *rpc.Client, sigs []solana.Signature, out *outcome) error{
+  ticker := time.NewTicker(time.Second)
+  defer ticker.Stop()
+  for{
+    landed, err := signaturesLanded(ctx, solanaRPC, sigs, out)
+    if landed{
+      return nil
+    }
+    select{
+    case <-ctx.Done():
+      if err != nil && !errors.Is(err, ctx.Err()){
+        return err
+      }
+      out.Status = statusTimeout
+      return nil
+    case <-ticker.C:
+    }
+  }
+}
+
+func runSimulateBundle(args []string) error{
+  var cfg config
+  fs := newFlagSet("simulate-bundle", "<file|manifest.{json,yaml}|->...", &cfg)
+  encoding := fs.String("encoding", "auto", "transaction encoding: auto, base64 or base58")
+  skipSigVerify := fs.Bool("skip-sig-verify", false, "skip signature verification")
+  replaceBlockhash := fs.Bool("replace-blockhash", false, "replace the transactions' recent blockhash")
+  accounts := fs.String("accounts", "", "comma separated accounts returned before and after every transaction")
+  logs := fs.Bool("logs", false, "print the transaction logs")
+  if err := cfg.parse(fs, args); err != nil{
+    return err
+  }
+  if cfg.rpc == ""{
+    return usageErrorf("simulate-bundle requires -rpc pointing to a Jito-Solana RPC node")
+  }
+  if len(cfg.args) == 0{
+    fs.Usage()
+    return usageErrorf("no transaction files given")
+  }
+  opts := &searcher_client.SimulateBundleOpts{SkipSigVerify: *skipSigVerify, ReplaceRecentBlockhash: *replaceBlockhash}
+  if *accounts != ""{
+    for _, account := range strings.Split(*accounts, ","){
+      pubkey, err := solana.PublicKeyFromBase58(strings.TrimSpace(account))
+      if err != nil{
+        return usageErrorf("invalid account %q: %v", account, err)
+      }
+      opts.WatchedAccounts = append(opts.WatchedAccounts, pubkey)
+    }
+  }
+  ctx, cancel := cfg.context()
+  defer cancel()
+
+  // the block engine is only needed for the tip accounts of manifests tipping a random or the first account
+  txns, manifests, err := cfg.readBundle(ctx, cfg.args, *encoding, func() ([]string, error){
+    cl, err := cfg.searcher(ctx)
+    if err != nil{
+      return nil, err
+    }
+    defer cl.Close()
+    resp, err := cl.GetTipAccounts()
+    if err != nil{
+      return nil, err
+    }
+    return resp.Accounts, nil
+  })
+  if err != nil{
+    return err
+  }
+  sim, err := cfg.simulator().SimulateBundle(ctx, txns, simulateOpts(manifests, opts))
+  if err != nil{
+    return err
+  }
+
+  type txResult struct{
+    Signature     string   `json:"signature"`
+    UnitsConsumed uint64   `json:"units_consumed"`
+    Err           any      `json:"err,omitempty"`
+    Logs          []string `json:"logs,omitempty"`
+  }
+  out := struct{
+    Slot         uint64     `json:"slot"`
+    Succeeded    bool       `json:"succeeded"`
+    Error        string     `json:"error,omitempty"`
+    Transactions []txResult `json:"transactions"`
+  }{Slot: sim.Slot, Succeeded: sim.Summary.Succeeded()}
+  if err := checkSimulation(sim, manifests); err != nil{
+    out.Succeeded, out.Error = false, err.Error()
+  }
+  for i, res := range sim.TransactionResults{
+    r := txResult{UnitsConsumed: res.UnitsConsumed, Err: res.Err}
+    if i < len(txns){
+      r.Signature = txns[i].Signatures[0].String()
+    }
+    if *logs{
+      r.Logs = res.Logs
+    }
+    out.Transactions = append(out.Transactions, r)
+  }
+
+  cfg.printer().print(out, func(){
+    fmt.Printf("slot %d: ", out.Slot)
+    if out.Succeeded{
+      fmt.Println("simulation succeeded")
+    } else{
+      fmt.Printf("simulation failed: %s\n", out.Error)
+    }
+    rows := make([][]string, 0, len(out.Transactions))
+    for _, r := range out.Transactions{
+      errText := ""
+      if r.Err != nil{
+        errText = fmt.Sprint(r.Err)
+      }
+      rows = append(rows, []string{r.Signature, strconv.FormatUint(r.UnitsConsumed, 10), errText})
+    }
+    table([]string{"SIGNATURE", "UNITS", "ERROR"}, rows)
+    for _, r := range out.Transactions{
+      for _, line := range r.Logs{
+        fmt.Printf("%s  %s\n", shortSignature(r.Signature), line)
+      }
+    }
+  })
+  if !out.Succeeded{
+    return withExitCode(exitRejected, errors.New("simulation failed"))
+  }
+  return nil
+}
+
+// shortSignature abbreviates a signature for log prefixes.
+func shortSignature(sig string) string{
+  if len(sig) > 8{
+    return sig[:8]
+  }
+  return sig
+}
+
+func runBundleStatus(args []string) error{
+  var cfg config
+  fs := newFlagSet("bundle-status", "<bundle-id>...", &cfg)
+  if err := cfg.parse(fs, args); err != nil{
+    return err
+  }
+  if len(cfg.args) == 0{
+    fs.Usage()
+    return usageErrorf("no bundle IDs given")
+  }
+  ctx, cancel := cfg.context()
+  defer cancel()
+
+  resp, err := cfg.jito().GetInflightBundleStatuses(ctx, cfg.args)
+  if err != nil{
+    return err
+  }
+  if resp == nil{
+    return errors.New("empty response")
+  }
+
+  type bundleStatus struct{
+    UUID       string `json:"uuid"`
+    Status     string `json:"status"`
+    LandedSlot uint64 `json:"landed_slot,omitempty"`
+  }
+  statuses := make(map[string]bundleStatus, len(resp.Value))
+  for _, v := range resp.Value{
+    statuses[v.BundleId] = bundleStatus{UUID: v.BundleId, Status: v.Status, LandedSlot: v.LandedSlot}
+  }
+  out := make([]bundleStatus, 0, len(cfg.args))
+  landed, failed := 0, 0
+  for _, id := range cfg.args{
+    st, ok := statuses[id]
+    if !ok{
+      st = bundleStatus{UUID: id, Status: "Unknown"}
+    }
+    switch st.Status{
+    case "Landed":
+      landed++
+    case "Failed", "Invalid":
+      failed++
+    }
+    out = append(out, st)
+  }
+
+  cfg.printer().print(out, func(){
+    rows := make([][]string, 0, len(out))
+    for _, st := range out{
+      slot := ""
+      if st.LandedSlot != 0{
+        slot = strconv.FormatUint(st.LandedSlot, 10)
+      }
+      rows = append(rows, []string{st.UUID, st.Status, slot})
+    }
+    table([]string{"BUNDLE", "STATUS", "LANDED SLOT"}, rows)
+  })
+  switch{
+  case failed > 0:
+    return withExitCode(exitRejected, fmt.Errorf("%d of %d bundles failed or are invalid", failed, len(out)))
+  case landed < len(out):
+    return withExitCode(exitNotLanded, fmt.Errorf("%d of %d bundles have not landed", len(out)-landed, len(out)))
+  }
+  return nil
+}
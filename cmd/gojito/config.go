@@ -0,0 +1,220 @@
+package main
+import(
+  "bytes"
+  "context"
+  "crypto/ed25519"
+  "crypto/tls"
+  "crypto/x509"
+  "encoding/json"
+  "errors"
+  "flag"
+  "fmt"
+  "os"
+  "slices"
+  "strconv"
+  "strings"
+  "time"
+
+  "github.com/scatkit/pumpdexer/rpc"
+  "github.com/scatkit/pumpdexer/solana"
+  "github.com/scatkit/gojito/clients/searcher_client"
+  "github.com/scatkit/gojito/jitorpc"
+  "github.com/scatkit/gojito/pkg"
+  "github.com/scatkit/gojito/regions"
+)
+
+// config holds the flags shared by every command. Each flag defaults to the environment variable named in its usage.
+type config struct{
+  blockEngine string
+  jitoRPC     string
+  rpc         string
+  keypair     string
+  uuid        string
+  caFile      string
+  insecure    bool
+  output      string
+  timeout     time.Duration
+
+  args []string // positional arguments
+}
+
+func envOr(key, fallback string) string{
+  if v, ok := os.LookupEnv(key); ok && v != ""{
+    return v
+  }
+  return fallback
+}
+
+func envBool(key string) bool{
+  v, _ := strconv.ParseBool(os.Getenv(key))
+  return v
+}
+
+func envDuration(key string, fallback time.Duration) time.Duration{
+  if d, err := time.ParseDuration(os.Getenv(key)); err == nil{
+    return d
+  }
+  return fallback
+}
+
+// newFlagSet returns the flag set of a command with the shared flags registered on it.
+func newFlagSet(name, args string, cfg *config) *flag.FlagSet{
+  fs := flag.NewFlagSet(name, flag.ContinueOnError)
+  fs.StringVar(&cfg.blockEngine, "block-engine", envOr("GOJITO_BLOCK_ENGINE", "mainnet.block-engine.jito.wtf:443"), "block engine gRPC address, or auto for the closest mainnet region ($GOJITO_BLOCK_ENGINE)")
+  fs.StringVar(&cfg.jitoRPC, "jito-rpc", envOr("GOJITO_JITO_RPC", "https://mainnet.block-engine.jito.wtf"), "block engine JSON-RPC URL ($GOJITO_JITO_RPC)")
+  fs.StringVar(&cfg.rpc, "rpc", envOr("GOJITO_RPC", ""), "Solana RPC URL, used to confirm transactions and simulate bundles ($GOJITO_RPC)")
+  fs.StringVar(&cfg.keypair, "keypair", envOr("GOJITO_KEYPAIR", ""), "keypair file (JSON byte array) or base58 private key used to authenticate ($GOJITO_KEYPAIR)")
+  fs.StringVar(&cfg.uuid, "uuid", envOr("GOJITO_UUID", ""), "JSON-RPC API key ($GOJITO_UUID)")
+  fs.StringVar(&cfg.caFile, "ca", envOr("GOJITO_CA", ""), "PEM file of the CA trusted for the block engine's certificate ($GOJITO_CA)")
+  fs.BoolVar(&cfg.insecure, "insecure", envBool("GOJITO_INSECURE"), "skip verification of the block engine's certificate ($GOJITO_INSECURE)")
+  fs.StringVar(&cfg.output, "output", envOr("GOJITO_OUTPUT", "human"), "output format: human or json ($GOJITO_OUTPUT)")
+  fs.DurationVar(&cfg.timeout, "timeout", envDuration("GOJITO_TIMEOUT", 30*time.Second), "overall timeout of the command ($GOJITO_TIMEOUT)")
+  fs.Usage = func(){
+    fmt.Fprintf(fs.Output(), "usage: gojito %s [flags] %s\n\nflags:\n", name, args)
+    fs.PrintDefaults()
+  }
+  return fs
+}
+
+// parse parses args and validates the shared flags. Flags may follow positional arguments, which are collected in cfg.args.
+func (cfg *config) parse(fs *flag.FlagSet, args []string) error{
+  for{
+    if err := fs.Parse(args); err != nil{
+      if errors.Is(err, flag.ErrHelp){
+        return withExitCode(exitUsage, err)
+      }
+      return usageErrorf("%v", err)
+    }
+    if fs.NArg() == 0{
+      break
+    }
+    cfg.args = append(cfg.args, fs.Arg(0))
+    args = fs.Args()[1:]
+  }
+  if cfg.output != "human" && cfg.output != "json"{
+    return usageErrorf("unknown output format %q", cfg.output)
+  }
+  if cfg.blockEngine == "auto"{
+    return cfg.pickRegion()
+  }
+  return nil
+}
+
+// probeRegions measures the latency to every mainnet region once.
+func (cfg *config) probeRegions() (*regions.Prober, error){
+  tlsConfig, err := cfg.tlsConfig()
+  if err != nil{
+    return nil, err
+  }
+  candidates := slices.Clone(regions.Mainnet)
+  for i := range candidates{
+    candidates[i].TLSConfig = tlsConfig
+  }
+  ctx, cancel := cfg.context()
+  defer cancel()
+  p := regions.NewProber(candidates, nil)
+  if err = p.Probe(ctx); err != nil{
+    p.Close()
+    return nil, fmt.Errorf("no block engine region could be reached: %w", err)
+  }
+  return p, nil
+}
+
+// pickRegion replaces -block-engine auto with the closest region, and the global JSON-RPC URL with the region's.
+func (cfg *config) pickRegion() error{
+  p, err := cfg.probeRegions()
+  if err != nil{
+    return err
+  }
+  defer p.Close()
+  best, _ := p.BestRegion()
+  cfg.blockEngine = best.GRPC
+  if cfg.jitoRPC == regions.Global.HTTP{
+    cfg.jitoRPC = best.HTTP
+  }
+  return nil
+}
+
+func (cfg *config) context() (context.Context, context.CancelFunc){
+  return context.WithTimeout(context.Background(), cfg.timeout)
+}
+
+// privateKey loads the keypair from a file holding a JSON byte array, or decodes it as a base58 private key.
+func (cfg *config) privateKey() (solana.PrivateKey, error){
+  if cfg.keypair == ""{
+    return nil, nil
+  }
+  data, err := os.ReadFile(cfg.keypair)
+  if errors.Is(err, os.ErrNotExist){
+    key, err := solana.PrivateKeyFromBase58(cfg.keypair)
+    if err != nil || len(key) != ed25519.PrivateKeySize || !bytes.Equal(ed25519.NewKeyFromSeed(key[:ed25519.SeedSize]), key){
+      return nil, fmt.Errorf("-keypair is neither a keypair file nor a base58 private key")
+    }
+    return key, nil
+  }
+  if err != nil{
+    return nil, err
+  }
+  var bts []byte
+  if err = json.Unmarshal(data, &bts); err != nil{
+    return nil, fmt.Errorf("invalid keypair file %s: %w", cfg.keypair, err)
+  }
+  if len(bts) != ed25519.PrivateKeySize || !bytes.Equal(ed25519.NewKeyFromSeed(bts[:ed25519.SeedSize]), bts){
+    return nil, fmt.Errorf("invalid keypair file %s: not an ed25519 keypair", cfg.keypair)
+  }
+  return solana.PrivateKey(bts), nil
+}
+
+func (cfg *config) tlsConfig() (*tls.Config, error){
+  tlsConfig := &tls.Config{InsecureSkipVerify: cfg.insecure}
+  if cfg.caFile != ""{
+    pem, err := os.ReadFile(cfg.caFile)
+    if err != nil{
+      return nil, err
+    }
+    pool := x509.NewCertPool()
+    if !pool.AppendCertsFromPEM(pem){
+      return nil, fmt.Errorf("no certificate found in %s", cfg.caFile)
+    }
+    tlsConfig.RootCAs = pool
+  }
+  return tlsConfig, nil
+}
+
+// searcher connects to the block engine, authenticated when a keypair is configured.
+func (cfg *config) searcher(ctx context.Context) (*searcher_client.Client, error){
+  key, err := cfg.privateKey()
+  if err != nil{
+    return nil, err
+  }
+  tlsConfig, err := cfg.tlsConfig()
+  if err != nil{
+    return nil, err
+  }
+  solanaRPC := cfg.rpc
+  if solanaRPC == ""{
+    solanaRPC = "https://api.mainnet-beta.solana.com"
+  }
+  if key == nil{
+    return searcher_client.NewNoAuth(ctx, cfg.blockEngine, rpc.New(cfg.jitoRPC), rpc.New(solanaRPC), tlsConfig, "")
+  }
+  return searcher_client.New(ctx, cfg.blockEngine, rpc.New(cfg.jitoRPC), rpc.New(solanaRPC), key, tlsConfig)
+}
+
+// simulator returns a client simulating bundles on the Jito-Solana RPC node of -rpc.
+func (cfg *config) simulator() *searcher_client.Client{
+  // simulation only needs the JSON-RPC side of the client
+  return &searcher_client.Client{JitoRpcConn: rpc.New(cfg.rpc), Logger: pkg.NopLogger()}
+}
+
+func (cfg *config) jito() *jitorpc.JitoClient{
+  return jitorpc.NewJito(strings.TrimSuffix(cfg.jitoRPC, "/"), cfg.uuid)
+}
+
+// solanaRPC returns the Solana RPC client, or nil when -rpc isn't set.
+func (cfg *config) solanaRPC() *rpc.Client{
+  if cfg.rpc == ""{
+    return nil
+  }
+  return rpc.New(cfg.rpc)
+}
@@ -0,0 +1,228 @@
+package main
+import(
+  "fmt"
+  "strconv"
+  "strings"
+  "time"
+
+  "github.com/scatkit/gojito/pb"
+  "github.com/scatkit/gojito/pkg"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/credentials"
+)
+
+func runTipAccounts(args []string) error{
+  var cfg config
+  fs := newFlagSet("tip-accounts", "", &cfg)
+  if err := cfg.parse(fs, args); err != nil{
+    return err
+  }
+  ctx, cancel := cfg.context()
+  defer cancel()
+
+  cl, err := cfg.searcher(ctx)
+  if err != nil{
+    return err
+  }
+  resp, err := cl.SearcherService.GetTipAccounts(cl.Auth.Context(ctx), &jito_pb.GetTipAccountsRequest{})
+  if err != nil{
+    return err
+  }
+  cfg.printer().print(map[string]any{"tip_accounts": resp.Accounts}, func(){
+    for _, account := range resp.Accounts{
+      fmt.Println(account)
+    }
+  })
+  return nil
+}
+
+func runRegions(args []string) error{
+  var cfg config
+  fs := newFlagSet("regions", "", &cfg)
+  probe := fs.Bool("probe", false, "rank the mainnet regions by measured latency instead of asking the block engine")
+  if err := cfg.parse(fs, args); err != nil{
+    return err
+  }
+  if *probe{
+    return probeRegions(&cfg)
+  }
+  ctx, cancel := cfg.context()
+  defer cancel()
+
+  cl, err := cfg.searcher(ctx)
+  if err != nil{
+    return err
+  }
+  resp, err := cl.SearcherService.GetRegions(cl.Auth.Context(ctx), &jito_pb.GetRegionsRequest{})
+  if err != nil{
+    return err
+  }
+  out := map[string]any{"current_region": resp.CurrentRegion, "available_regions": resp.AvailableRegions}
+  cfg.printer().print(out, func(){
+    fmt.Printf("current region: %s\n", resp.CurrentRegion)
+    fmt.Printf("available regions: %s\n", strings.Join(resp.AvailableRegions, ", "))
+  })
+  return nil
+}
+
+// probeRegions implements "regions -probe".
+func probeRegions(cfg *config) error{
+  p, err := cfg.probeRegions()
+  if err != nil{
+    return err
+  }
+  defer p.Close()
+  ranked := p.Ranked()
+  out := make([]map[string]any, 0, len(ranked))
+  rows := make([][]string, 0, len(ranked))
+  for _, s := range ranked{
+    entry := map[string]any{
+      "region":     s.Region.Name,
+      "grpc":       s.Region.GRPC,
+      "grpc_ms":    s.GRPC.Milliseconds(),
+      "http_ms":    s.HTTP.Milliseconds(),
+      "latency_ms": s.Latency().Milliseconds(),
+    }
+    errText := ""
+    if s.LastError != nil{
+      errText = s.LastError.Error()
+      entry["error"] = errText
+    }
+    out = append(out, entry)
+    rows = append(rows, []string{s.Region.Name, latencyText(s.GRPC), latencyText(s.HTTP), errText})
+  }
+  cfg.printer().print(map[string]any{"regions": out}, func(){
+    table([]string{"REGION", "GRPC", "HTTP", "ERROR"}, rows)
+  })
+  return nil
+}
+
+func latencyText(d time.Duration) string{
+  if d == 0{
+    return "-"
+  }
+  return d.Round(100 * time.Microsecond).String()
+}
+
+// runLeaders implements "leaders next", which shows the next scheduled leader connected to the block engine.
+func runLeaders(args []string) error{
+  var cfg config
+  fs := newFlagSet("leaders", "next", &cfg)
+  regions := fs.String("regions", "", "comma separated regions to look for the next leader in (default: the connected region)")
+  if err := cfg.parse(fs, args); err != nil{
+    return err
+  }
+  if len(cfg.args) != 1 || cfg.args[0] != "next"{
+    fs.Usage()
+    return usageErrorf("expected the subcommand \"next\"")
+  }
+  ctx, cancel := cfg.context()
+  defer cancel()
+
+  cl, err := cfg.searcher(ctx)
+  if err != nil{
+    return err
+  }
+  req := &jito_pb.NextScheduledLeaderRequest{}
+  if *regions != ""{
+    req.Regions = strings.Split(*regions, ",")
+  }
+  resp, err := cl.SearcherService.GetNextScheduledLeader(cl.Auth.Context(ctx), req)
+  if err != nil{
+    return err
+  }
+  out := map[string]any{
+    "current_slot":         resp.CurrentSlot,
+    "next_leader_slot":     resp.NextLeaderSlot,
+    "next_leader_identity": resp.NextLeaderIdentity,
+    "next_leader_region":   resp.NextLeaderRegion,
+    "slots_until_leader":   slotsUntil(resp.CurrentSlot, resp.NextLeaderSlot),
+  }
+  cfg.printer().print(out, func(){
+    if resp.NextLeaderIdentity == ""{
+      fmt.Printf("current slot %d, no connected leader scheduled\n", resp.CurrentSlot)
+      return
+    }
+    table([]string{"CURRENT SLOT", "LEADER SLOT", "IN", "IDENTITY", "REGION"}, [][]string{{
+      strconv.FormatUint(resp.CurrentSlot, 10),
+      strconv.FormatUint(resp.NextLeaderSlot, 10),
+      strconv.FormatUint(slotsUntil(resp.CurrentSlot, resp.NextLeaderSlot), 10),
+      resp.NextLeaderIdentity,
+      resp.NextLeaderRegion,
+    }})
+  })
+  return nil
+}
+
+func slotsUntil(current, next uint64) uint64{
+  if next < current{
+    return 0
+  }
+  return next - current
+}
+
+var roles = map[string]jito_pb.Role{
+  "searcher":    jito_pb.Role_SEARCHER,
+  "validator":   jito_pb.Role_VALIDATOR,
+  "relayer":     jito_pb.Role_RELAYER,
+  "shredstream": jito_pb.Role_SHREDSTREAM_SUBSCRIBER,
+}
+
+// runAuth implements "auth test", which runs the challenge/token flow with the configured keypair.
+func runAuth(args []string) error{
+  var cfg config
+  fs := newFlagSet("auth", "test", &cfg)
+  roleName := fs.String("role", "searcher", "role to authenticate with: searcher, validator, relayer or shredstream")
+  if err := cfg.parse(fs, args); err != nil{
+    return err
+  }
+  if len(cfg.args) != 1 || cfg.args[0] != "test"{
+    fs.Usage()
+    return usageErrorf("expected the subcommand \"test\"")
+  }
+  role, ok := roles[*roleName]
+  if !ok{
+    return usageErrorf("unknown role %q", *roleName)
+  }
+  key, err := cfg.privateKey()
+  if err != nil{
+    return err
+  }
+  if key == nil{
+    return usageErrorf("auth test requires -keypair")
+  }
+  tlsConfig, err := cfg.tlsConfig()
+  if err != nil{
+    return err
+  }
+  ctx, cancel := cfg.context()
+  defer cancel()
+
+  conn, err := pkg.CreateAndObserveGRPCConn(ctx, make(chan error, 1), cfg.blockEngine, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+  if err != nil{
+    return err
+  }
+  defer conn.Close()
+
+  auth := pkg.NewAuthenticationService(conn, key)
+  auth.GrpcCtx = ctx
+  start := time.Now()
+  if err = auth.AuthenticateAndRefresh(role); err != nil{
+    return err
+  }
+  latency := time.Since(start)
+  expiresAt := time.Unix(auth.ExpiresAt, 0).UTC()
+
+  out := map[string]any{
+    "pubkey":     key.PublicKey().String(),
+    "role":       role.String(),
+    "expires_at": expiresAt,
+    "latency_ms": latency.Milliseconds(),
+  }
+  cfg.printer().print(out, func(){
+    fmt.Printf("authenticated %s as %s in %s, access token expires at %s\n",
+      key.PublicKey(), role, latency.Round(time.Millisecond), expiresAt.Format(time.RFC3339))
+  })
+  return nil
+}
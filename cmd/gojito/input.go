@@ -0,0 +1,109 @@
+package main
+import(
+  "bufio"
+  "bytes"
+  "context"
+  "fmt"
+  "io"
+  "os"
+  "path/filepath"
+  "strings"
+
+  "github.com/scatkit/pumpdexer/rpc"
+  "github.com/scatkit/pumpdexer/solana"
+  "github.com/scatkit/gojito/manifest"
+)
+
+// isManifest reports whether path is a bundle manifest (see the manifest package) rather than a transaction file.
+func isManifest(path string) bool{
+  switch strings.ToLower(filepath.Ext(path)){
+  case ".json", ".yaml", ".yml":
+    return true
+  }
+  return false
+}
+
+// readBundle reads the transactions of paths in order. Manifests building transactions get the latest
+// blockhash from -rpc and, for the random and first tip strategies, the tip accounts from tipAccounts.
+// The manifests read are returned along with the transactions so their simulation requirements can be checked.
+func (cfg *config) readBundle(ctx context.Context, paths []string, encoding string, tipAccounts func() ([]string, error)) ([]*solana.Transaction, []*manifest.Manifest, error){
+  var txns []*solana.Transaction
+  var manifests []*manifest.Manifest
+  var opts manifest.BuildOpts
+  for _, path := range paths{
+    if !isManifest(path){
+      read, err := readTransactions([]string{path}, encoding)
+      if err != nil{
+        return nil, nil, err
+      }
+      txns = append(txns, read...)
+      continue
+    }
+
+    m, err := manifest.Load(path)
+    if err != nil{
+      return nil, nil, err
+    }
+    if m.NeedsBlockhash() && opts.Blockhash.IsZero(){
+      if cfg.rpc == ""{
+        return nil, nil, usageErrorf("%s: building transactions requires -rpc to get a recent blockhash", path)
+      }
+      resp, err := cfg.solanaRPC().GetLatestBlockhash(ctx, rpc.CommitmentConfirmed)
+      if err != nil{
+        return nil, nil, fmt.Errorf("failed to get the latest blockhash: %w", err)
+      }
+      opts.Blockhash = resp.Value.Blockhash
+    }
+    if m.Tip != nil && len(opts.TipAccounts) == 0 && tipAccounts != nil{
+      if opts.TipAccounts, err = tipAccounts(); err != nil{
+        return nil, nil, fmt.Errorf("failed to get tip accounts: %w", err)
+      }
+    }
+    built, err := m.Build(&opts)
+    if err != nil{
+      return nil, nil, fmt.Errorf("%s: %w", path, err)
+    }
+    txns = append(txns, built...)
+    manifests = append(manifests, m)
+  }
+  return txns, manifests, nil
+}
+
+// readTransactions reads the signed transactions of files holding one encoded transaction per line,
+// skipping empty lines and # comments; "-" reads standard input.
+func readTransactions(paths []string, encoding string) ([]*solana.Transaction, error){
+  var txns []*solana.Transaction
+  for _, path := range paths{
+    var data []byte
+    var err error
+    if path == "-"{
+      data, err = io.ReadAll(os.Stdin)
+    } else{
+      data, err = os.ReadFile(path)
+    }
+    if err != nil{
+      return nil, err
+    }
+
+    var encoded []string
+    scanner := bufio.NewScanner(bytes.NewReader(data))
+    scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+    for scanner.Scan(){
+      if line := strings.TrimSpace(scanner.Text()); line != "" && !strings.HasPrefix(line, "#"){
+        encoded = append(encoded, line)
+      }
+    }
+    if err = scanner.Err(); err != nil{
+      return nil, fmt.Errorf("%s: %w", path, err)
+    }
+
+    for i, s := range encoded{
+      tx, err := manifest.DecodeTransaction(s, encoding)
+      if err != nil{
+        return nil, fmt.Errorf("%s: transaction %d: %w", path, i, err)
+      }
+      txns = append(txns, tx)
+    }
+  }
+  return txns, nil
+}
@@ -0,0 +1,93 @@
+// Command gojito talks to a Jito block engine: it lists tip accounts and regions, sends and simulates bundles,
+// looks up bundle statuses and checks authentication. Exit codes reflect the outcome so it can be scripted:
+//
+//	0  success (the bundle was accepted or landed, depending on -wait)
+//	1  error
+//	2  usage error
+//	3  the bundle was rejected or dropped, or a transaction failed on chain
+//	4  the bundle did not land before the timeout
+package main
+import(
+  "errors"
+  "fmt"
+  "os"
+)
+
+const (
+  exitOK        = 0
+  exitError     = 1
+  exitUsage     = 2
+  exitRejected  = 3
+  exitNotLanded = 4
+)
+
+// exitCodeError is returned by commands whose failure maps to a specific exit code.
+type exitCodeError struct{
+  code int
+  err  error
+}
+
+func (e *exitCodeError) Error() string{ return e.err.Error() }
+func (e *exitCodeError) Unwrap() error{ return e.err }
+
+func withExitCode(code int, err error) error{
+  return &exitCodeError{code: code, err: err}
+}
+
+func usageErrorf(format string, args ...any) error{
+  return withExitCode(exitUsage, fmt.Errorf(format, args...))
+}
+
+type command struct{
+  name  string
+  usage string
+  run   func(args []string) error
+}
+
+var commands = []command{
+  {"tip-accounts", "list the tip accounts", runTipAccounts},
+  {"send-bundle", "send a bundle of transactions read from files or a manifest", runSendBundle},
+  {"send-tx", "send a single transaction through the JSON-RPC API", runSendTx},
+  {"simulate-bundle", "simulate a bundle on a Jito-Solana RPC node", runSimulateBundle},
+  {"bundle-status", "look up the status of bundles by ID", runBundleStatus},
+  {"leaders", "show leader information (leaders next)", runLeaders},
+  {"regions", "list the block engine regions", runRegions},
+  {"auth", "check authentication against the block engine (auth test)", runAuth},
+}
+
+func usage(){
+  fmt.Fprintln(os.Stderr, "usage: gojito <command> [flags] [args]\n\ncommands:")
+  for _, cmd := range commands{
+    fmt.Fprintf(os.Stderr, "  %-16s %s\n", cmd.name, cmd.usage)
+  }
+  fmt.Fprintln(os.Stderr, "\nRun 'gojito <command> -h' for the flags of a command.")
+}
+
+func main(){
+  os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int{
+  if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help"{
+    usage()
+    return exitUsage
+  }
+  for _, cmd := range commands{
+    if cmd.name != args[0]{
+      continue
+    }
+    err := cmd.run(args[1:])
+    if err == nil{
+      return exitOK
+    }
+    fmt.Fprintf(os.Stderr, "gojito %s: %v\n", cmd.name, err)
+    var exitErr *exitCodeError
+    if errors.As(err, &exitErr){
+      return exitErr.code
+    }
+    return exitError
+  }
+  fmt.Fprintf(os.Stderr, "gojito: unknown command %q\n\n", args[0])
+  usage()
+  return exitUsage
+}
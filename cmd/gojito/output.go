@@ -0,0 +1,38 @@
+package main
+import(
+  "encoding/json"
+  "fmt"
+  "os"
+  "strings"
+  "text/tabwriter"
+)
+
+// printer writes the result of a command either as human readable text or as a single JSON document.
+type printer struct{
+  json bool
+}
+
+func (cfg *config) printer() *printer{
+  return &printer{json: cfg.output == "json"}
+}
+
+// print writes v as JSON, or calls human to write the text form.
+func (p *printer) print(v any, human func()){
+  if p.json{
+    enc := json.NewEncoder(os.Stdout)
+    enc.SetIndent("", "  ")
+    enc.Encode(v)
+    return
+  }
+  human()
+}
+
+// table writes rows as aligned columns under header.
+func table(header []string, rows [][]string){
+  w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+  fmt.Fprintln(w, strings.Join(header, "\t"))
+  for _, row := range rows{
+    fmt.Fprintln(w, strings.Join(row, "\t"))
+  }
+  w.Flush()
+}
@@ -0,0 +1,255 @@
+// Package dedup guards against submitting the same signed transactions more than once, whether in several
+// bundles or through both SendBundle and sendTransaction. Submissions are keyed by transaction signature and
+// bundle hash and remembered for the validity window of their blockhash, after which they can't land anymore.
+package dedup
+import(
+  "container/list"
+  "context"
+  "crypto/sha256"
+  "encoding/binary"
+  "encoding/hex"
+  "errors"
+  "fmt"
+  "slices"
+  "sync"
+  "time"
+
+  "github.com/scatkit/pumpdexer/solana"
+  "github.com/scatkit/gojito/metrics"
+  "github.com/scatkit/gojito/pb"
+)
+
+// DefaultTTL covers the validity of a blockhash: 150 blocks at 400ms, plus margin for slow slots.
+const DefaultTTL = 90 * time.Second
+
+const DefaultCapacity = 100_000
+
+// Mode selects what happens to a repeat submission.
+type Mode int
+
+const (
+  Reject   Mode = iota // fail with a *DuplicateError
+  Coalesce             // wait for the first submission and share its result
+)
+
+var ErrDuplicate = errors.New("duplicate submission")
+
+// DuplicateError is returned for a repeat submission. errors.Is(err, ErrDuplicate) matches it.
+type DuplicateError struct{
+  Key       string // the key submitted before, see SignatureKey and BundleKey
+  FirstSeen time.Time
+}
+
+func (e *DuplicateError) Error() string{
+  return fmt.Sprintf("duplicate submission: %s first submitted %s ago", e.Key, time.Since(e.FirstSeen).Round(time.Millisecond))
+}
+
+func (e *DuplicateError) Is(target error) bool{ return target == ErrDuplicate }
+
+type Opts struct{
+  Mode     Mode
+  TTL      time.Duration    // defaults to DefaultTTL
+  Capacity int              // keys remembered, least recently used first out; defaults to DefaultCapacity
+  Metrics  metrics.Recorder // nil discards all measurements
+}
+
+// Guard remembers recent submissions. It's safe for concurrent use, and a Guard can be shared by the searcher
+// and JSON-RPC clients to catch transactions sent through both.
+type Guard struct{
+  mode     Mode
+  ttl      time.Duration
+  capacity int
+  metrics  metrics.Recorder
+  now      func() time.Time
+
+  mu    sync.Mutex
+  ll    *list.List // of *entry, most recently used first
+  items map[string]*list.Element
+}
+
+type entry struct{
+  key     string
+  sub     *submission
+  expires time.Time
+}
+
+// submission is shared by the keys of a submission.
+type submission struct{
+  firstSeen time.Time
+  done      chan struct{}
+  result    any
+  err       error
+}
+
+func New(opts *Opts) *Guard{
+  if opts == nil{
+    opts = &Opts{}
+  }
+  g := &Guard{
+    mode:     opts.Mode,
+    ttl:      opts.TTL,
+    capacity: opts.Capacity,
+    metrics:  opts.Metrics,
+    now:      time.Now,
+    ll:       list.New(),
+    items:    make(map[string]*list.Element),
+  }
+  if g.ttl <= 0{
+    g.ttl = DefaultTTL
+  }
+  if g.capacity <= 0{
+    g.capacity = DefaultCapacity
+  }
+  if g.metrics == nil{
+    g.metrics = metrics.Nop{}
+  }
+  return g
+}
+
+// Do calls send unless one of keys was submitted within the TTL. A nil Guard always calls send.
+// Repeats fail with a *DuplicateError in Reject mode. In Coalesce mode they wait for the first submission and
+// return its result, or a *DuplicateError if it was made through another path (a different result type).
+// Failed submissions are forgotten so they can be retried.
+func Do[T any](ctx context.Context, g *Guard, kind string, keys []string, send func() (T, error)) (T, error){
+  var zero T
+  if g != nil{
+    keys = slices.DeleteFunc(slices.Clone(keys), func(key string) bool{ return key == "" })
+  }
+  if g == nil || len(keys) == 0{
+    return send()
+  }
+  for{
+    sub, dup := g.claim(keys)
+    if dup == nil{
+      res, err := send()
+      g.complete(keys, sub, res, err)
+      return res, err
+    }
+    if g.mode == Reject{
+      g.metrics.Deduplicated(kind, metrics.OutcomeRejected)
+      return zero, dup
+    }
+
+    g.metrics.Deduplicated(kind, metrics.OutcomeCoalesced)
+    prev := g.submission(dup.Key)
+    if prev == nil{
+      continue // evicted in the meantime
+    }
+    select{
+    case <-ctx.Done():
+      return zero, ctx.Err()
+    case <-prev.done:
+    }
+    if prev.err != nil{
+      continue
+    }
+    if res, ok := prev.result.(T); ok{
+      return res, nil
+    }
+    return zero, dup
+  }
+}
+
+// claim registers keys as a new submission, or returns the error for the first key already submitted.
+func (g *Guard) claim(keys []string) (*submission, *DuplicateError){
+  g.mu.Lock()
+  defer g.mu.Unlock()
+  now := g.now()
+  for _, key := range keys{
+    if el, ok := g.items[key]; ok{
+      e := el.Value.(*entry)
+      if now.Before(e.expires){
+        g.ll.MoveToFront(el)
+        return nil, &DuplicateError{Key: key, FirstSeen: e.sub.firstSeen}
+      }
+      g.remove(el)
+    }
+  }
+
+  sub := &submission{firstSeen: now, done: make(chan struct{})}
+  for _, key := range keys{
+    g.items[key] = g.ll.PushFront(&entry{key: key, sub: sub, expires: now.Add(g.ttl)})
+  }
+  for g.ll.Len() > g.capacity{
+    g.remove(g.ll.Back())
+  }
+  return sub, nil
+}
+
+func (g *Guard) complete(keys []string, sub *submission, result any, err error){
+  g.mu.Lock()
+  sub.result, sub.err = result, err
+  if err != nil{
+    for _, key := range keys{
+      if el, ok := g.items[key]; ok && el.Value.(*entry).sub == sub{
+        g.remove(el)
+      }
+    }
+  }
+  g.mu.Unlock()
+  close(sub.done)
+}
+
+func (g *Guard) submission(key string) *submission{
+  g.mu.Lock()
+  defer g.mu.Unlock()
+  if el, ok := g.items[key]; ok{
+    return el.Value.(*entry).sub
+  }
+  return nil
+}
+
+// Forget removes keys, e.g. to deliberately resend a bundle.
+func (g *Guard) Forget(keys ...string){
+  g.mu.Lock()
+  defer g.mu.Unlock()
+  for _, key := range keys{
+    if el, ok := g.items[key]; ok{
+      g.remove(el)
+    }
+  }
+}
+
+// Len returns the number of keys remembered, including expired ones not evicted yet.
+func (g *Guard) Len() int{
+  g.mu.Lock()
+  defer g.mu.Unlock()
+  return g.ll.Len()
+}
+
+func (g *Guard) remove(el *list.Element){
+  g.ll.Remove(el)
+  delete(g.items, el.Value.(*entry).key)
+}
+
+// SignatureKey identifies a transaction by its first signature.
+func SignatureKey(tx *solana.Transaction) string{
+  if len(tx.Signatures) == 0{
+    return ""
+  }
+  return "signature:" + tx.Signatures[0].String()
+}
+
+// BundleKey identifies a bundle by the SHA-256 of its packets.
+func BundleKey(bundle *jito_pb.Bundle) string{
+  h := sha256.New()
+  var size [8]byte
+  for _, packet := range bundle.GetPackets(){
+    binary.LittleEndian.PutUint64(size[:], uint64(len(packet.GetData())))
+    h.Write(size[:])
+    h.Write(packet.GetData())
+  }
+  return "bundle:" + hex.EncodeToString(h.Sum(nil))
+}
+
+// BundleKeys returns the key of the bundle followed by the signature keys of its transactions.
+func BundleKeys(bundle *jito_pb.Bundle, txns []*solana.Transaction) []string{
+  keys := make([]string, 0, len(txns)+1)
+  keys = append(keys, BundleKey(bundle))
+  for _, tx := range txns{
+    if key := SignatureKey(tx); key != ""{
+      keys = append(keys, key)
+    }
+  }
+  return keys
+}
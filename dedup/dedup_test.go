@@ -0,0 +1,189 @@
+package dedup
+import(
+  "context"
+  "errors"
+  "testing"
+  "time"
+
+  "github.com/scatkit/gojito/metrics"
+)
+
+// coalesced signals every repeat submission the guard waits on.
+type coalesced struct{
+  metrics.Nop
+  waiting chan struct{}
+}
+
+func (c coalesced) Deduplicated(_, outcome string){
+  if outcome == metrics.OutcomeCoalesced{
+    c.waiting <- struct{}{}
+  }
+}
+
+// newClockGuard returns a guard whose clock only moves through the returned func.
+func newClockGuard(opts *Opts) (*Guard, func(time.Duration)){
+  g := New(opts)
+  now := time.Unix(1_700_000_000, 0)
+  g.now = func() time.Time{ return now }
+  return g, func(d time.Duration){ now = now.Add(d) }
+}
+
+func sendString(s string, calls *int) func() (string, error){
+  return func() (string, error){
+    *calls++
+    return s, nil
+  }
+}
+
+func TestCoalesceRetriesAfterFailure(t *testing.T){
+  rec := coalesced{waiting: make(chan struct{}, 1)}
+  g := New(&Opts{Mode: Coalesce, Metrics: rec})
+  keys := []string{"signature:a"}
+
+  release := make(chan struct{})
+  first := make(chan error, 1)
+  go func(){
+    _, err := Do(context.Background(), g, "bundle", keys, func() (string, error){
+      <-release
+      return "", errors.New("connection reset")
+    })
+    first <- err
+  }()
+  // the first submission holds the key until it fails
+  for g.Len() == 0{
+    time.Sleep(time.Millisecond)
+  }
+
+  second := make(chan string, 1)
+  go func(){
+    res, err := Do(context.Background(), g, "bundle", keys, func() (string, error){ return "retried", nil })
+    if err != nil{
+      t.Error(err)
+    }
+    second <- res
+  }()
+  <-rec.waiting
+  close(release)
+
+  if err := <-first; err == nil{
+    t.Fatal("first submission succeeded")
+  }
+  select{
+  case res := <-second:
+    if res != "retried"{
+      t.Fatalf("coalesced submission returned %q, want its own retry", res)
+    }
+  case <-time.After(time.Second):
+    t.Fatal("coalesced submission didn't retry after the first one failed")
+  }
+  if g.Len() != 1{
+    t.Errorf("%d keys remembered, want the retry's", g.Len())
+  }
+}
+
+func TestCoalesceSharesResult(t *testing.T){
+  g := New(&Opts{Mode: Coalesce})
+  keys := []string{"bundle:1", "signature:a"}
+  calls := 0
+  if _, err := Do(context.Background(), g, "bundle", keys, sendString("uuid", &calls)); err != nil{
+    t.Fatal(err)
+  }
+  // any key of the first submission matches
+  res, err := Do(context.Background(), g, "bundle", []string{"signature:a"}, sendString("other", &calls))
+  if err != nil || res != "uuid" || calls != 1{
+    t.Fatalf("got %q, %v after %d sends, want the first result", res, err, calls)
+  }
+}
+
+func TestCoalesceTypeMismatch(t *testing.T){
+  g := New(&Opts{Mode: Coalesce})
+  keys := []string{"signature:a"}
+  calls := 0
+  if _, err := Do(context.Background(), g, "bundle", keys, sendString("uuid", &calls)); err != nil{
+    t.Fatal(err)
+  }
+  // the same transaction sent through another path, which returns a different result type
+  _, err := Do(context.Background(), g, "transaction", keys, func() (int, error){
+    calls++
+    return 0, nil
+  })
+  var dup *DuplicateError
+  if !errors.As(err, &dup) || dup.Key != "signature:a" || !errors.Is(err, ErrDuplicate){
+    t.Fatalf("error %v, want a *DuplicateError for signature:a", err)
+  }
+  if calls != 1{
+    t.Errorf("sent %d times, want once", calls)
+  }
+}
+
+func TestRejectDuplicate(t *testing.T){
+  g, _ := newClockGuard(&Opts{Mode: Reject})
+  first := g.now()
+  calls := 0
+  if _, err := Do(context.Background(), g, "bundle", []string{"", "signature:a"}, sendString("uuid", &calls)); err != nil{
+    t.Fatal(err)
+  }
+  _, err := Do(context.Background(), g, "bundle", []string{"signature:a"}, sendString("uuid", &calls))
+  var dup *DuplicateError
+  if !errors.As(err, &dup) || !dup.FirstSeen.Equal(first){
+    t.Fatalf("error %v, want a *DuplicateError first seen at %s", err, first)
+  }
+  if calls != 1 || g.Len() != 1{
+    t.Errorf("sent %d times with %d keys remembered, want once with one key", calls, g.Len())
+  }
+}
+
+func TestTTLExpiry(t *testing.T){
+  g, advance := newClockGuard(&Opts{TTL: time.Minute})
+  keys := []string{"signature:a"}
+  calls := 0
+  if _, err := Do(context.Background(), g, "bundle", keys, sendString("uuid", &calls)); err != nil{
+    t.Fatal(err)
+  }
+
+  advance(time.Minute - time.Nanosecond)
+  if _, err := Do(context.Background(), g, "bundle", keys, sendString("uuid", &calls)); !errors.Is(err, ErrDuplicate){
+    t.Fatalf("error %v just before the TTL, want a duplicate", err)
+  }
+  advance(time.Nanosecond)
+  if _, err := Do(context.Background(), g, "bundle", keys, sendString("uuid", &calls)); err != nil{
+    t.Fatalf("error %v after the TTL, want a new submission", err)
+  }
+  if calls != 2 || g.Len() != 1{
+    t.Errorf("sent %d times with %d keys remembered, want twice with one key", calls, g.Len())
+  }
+}
+
+func TestLRUEviction(t *testing.T){
+  g, advance := newClockGuard(&Opts{Capacity: 2})
+  calls := 0
+  send := func(key string) error{
+    advance(time.Second)
+    _, err := Do(context.Background(), g, "bundle", []string{key}, sendString(key, &calls))
+    return err
+  }
+  for _, key := range []string{"a", "b"}{
+    if err := send(key); err != nil{
+      t.Fatal(err)
+    }
+  }
+  // the repeat makes a the most recently used, so c evicts b
+  if err := send("a"); !errors.Is(err, ErrDuplicate){
+    t.Fatalf("error %v, want a duplicate", err)
+  }
+  if err := send("c"); err != nil{
+    t.Fatal(err)
+  }
+  if g.Len() != 2{
+    t.Fatalf("%d keys remembered, want the capacity of 2", g.Len())
+  }
+  if g.submission("b") != nil || g.submission("a") == nil || g.submission("c") == nil{
+    t.Fatal("evicted a key other than the least recently used")
+  }
+  if err := send("b"); err != nil{
+    t.Fatalf("error %v for an evicted key, want a new submission", err)
+  }
+  if calls != 4{
+    t.Errorf("sent %d times, want 4", calls)
+  }
+}
@@ -2,31 +2,54 @@ module github.com/scatkit/gojito
 
 go 1.23.1
 
+require (
+	github.com/davecgh/go-spew v1.1.1
+	github.com/gagliardetto/binary v0.8.0
+	github.com/klauspost/reedsolomon v1.12.4
+	github.com/mr-tron/base58 v1.2.0
+	github.com/prometheus/client_golang v1.20.5 // direct
+	github.com/scatkit/pumpdexer v0.0.0-20250101140745-b2f8fd8ca090
+	go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc v0.58.0 // direct
+	go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp v0.58.0 // direct
+	go.opentelemetry.io/otel v1.33.0 // direct
+	go.opentelemetry.io/otel/metric v1.33.0 // direct
+	go.opentelemetry.io/otel/trace v1.33.0 // direct
+	golang.org/x/time v0.9.0
+	google.golang.org/grpc v1.69.2 // direct
+	google.golang.org/protobuf v1.36.2 // direct
+	gopkg.in/yaml.v3 v3.0.1
+)
+
 require (
 	filippo.io/edwards25519 v1.1.0 // indirect
+	github.com/beorn7/perks v1.0.1 // indirect
 	github.com/blendle/zapdriver v1.3.1 // indirect
 	github.com/buger/jsonparser v1.1.1 // indirect
-	github.com/davecgh/go-spew v1.1.1 // indirect
-	github.com/gagliardetto/binary v0.8.0 // indirect
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/felixge/httpsnoop v1.0.4 // indirect
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
 	github.com/gorilla/websocket v1.5.3 // indirect
 	github.com/json-iterator/go v1.1.12 // indirect
+	github.com/klauspost/cpuid/v2 v2.2.8 // indirect
 	github.com/logrusorgru/aurora v2.0.3+incompatible // indirect
 	github.com/mitchellh/go-testing-interface v1.14.1 // indirect
 	github.com/modern-go/concurrent v0.0.0-20180306012644-bacd9c7ef1dd // indirect
 	github.com/modern-go/reflect2 v1.0.2 // indirect
-	github.com/mr-tron/base58 v1.2.0 // indirect
-	github.com/scatkit/pumpdexer v0.0.0-20250101140745-b2f8fd8ca090 // indirect
+	github.com/munnerz/goautoneg v0.0.0-20191010083416-a7dc8b61c822 // indirect
+	github.com/prometheus/client_model v0.6.1 // indirect
+	github.com/prometheus/common v0.55.0 // indirect
+	github.com/prometheus/procfs v0.15.1 // indirect
 	github.com/streamingfast/logging v0.0.0-20230608130331-f22c91403091 // indirect
+	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
 	go.uber.org/multierr v1.10.0 // indirect
 	go.uber.org/zap v1.27.0 // indirect
-	golang.org/x/crypto v0.28.0 // indirect
-	golang.org/x/net v0.30.0 // indirect
-	golang.org/x/sys v0.26.0 // indirect
-	golang.org/x/term v0.25.0 // indirect
-	golang.org/x/text v0.19.0 // indirect
-	google.golang.org/genproto/googleapis/rpc v0.0.0-20241015192408-796eee8c2d53 // indirect
-	google.golang.org/grpc v1.69.2 // direct
-	google.golang.org/protobuf v1.36.2 // direct
+	golang.org/x/crypto v0.30.0 // indirect
+	golang.org/x/net v0.32.0 // indirect
+	golang.org/x/sys v0.28.0 // indirect
+	golang.org/x/term v0.27.0 // indirect
+	golang.org/x/text v0.21.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20241209162323-e6fa225c2576 // indirect
 )
 
 replace github.com/scatkit/pumpdexer v0.0.0-20250101140745-b2f8fd8ca090 => ../pumpdexer
@@ -0,0 +1,288 @@
+package searcher_client
+import(
+  "context"
+  "errors"
+  "fmt"
+  "log/slog"
+  "slices"
+  "sync"
+  "time"
+
+  "github.com/scatkit/pumpdexer/rpc"
+  "github.com/scatkit/pumpdexer/solana"
+  "github.com/scatkit/gojito/dedup"
+  "github.com/scatkit/gojito/jitorpc"
+  "github.com/scatkit/gojito/journal"
+  "github.com/scatkit/gojito/metrics"
+  "github.com/scatkit/gojito/pkg"
+  "github.com/scatkit/gojito/tracing"
+)
+
+// HedgeAttempt is the submission of a hedged bundle through one endpoint.
+type HedgeAttempt struct{
+  Transport string // "grpc" or "jsonrpc"
+  Region    string // empty for the global endpoint
+  BundleID  string
+  Err       error
+  Latency   time.Duration
+}
+
+// Path names the endpoint of the attempt, e.g. "grpc/ny".
+func (a *HedgeAttempt) Path() string{
+  if a.Region == ""{
+    return a.Transport
+  }
+  return a.Transport + "/" + a.Region
+}
+
+// HedgedSubmission is a bundle sent through several endpoints.
+type HedgedSubmission struct{
+  BundleIDs []string        // distinct IDs returned by the endpoints; a bundle normally gets the same ID everywhere
+  Attempts  []*HedgeAttempt // gRPC clients first, then JSON-RPC clients, in the order of the HedgedSender's
+}
+
+// HedgedResult is the outcome of a hedged bundle.
+type HedgedResult struct{
+  *HedgedSubmission
+  State  journal.State // landed, failed or expired; sent if tracking was given up
+  Slot   uint64
+  Source string // the status source that reported first: "signatures" or the path of a JSON-RPC endpoint
+}
+
+// HedgedSender sends the same bundle through several gRPC and JSON-RPC endpoints at once, so that a degraded
+// transport or region doesn't cost the bundle. Hedging is safe: transactions with identical signatures can only
+// land once. Every endpoint sends the same bundle, so its clients must not share a dedup.Guard; set Dedup on the
+// HedgedSender instead.
+type HedgedSender struct{
+  Clients      []*Client             // gRPC searcher clients, e.g. one per region
+  JSONRPC      []*jitorpc.JitoClient // JSON-RPC clients, also polled for inflight bundle statuses
+  RPC          *rpc.Client           // Solana RPC polled for signature statuses, defaults to the first client's
+  Blockhashes  *pkg.BlockhashProvider // when it knows the bundle's blockhash, expiry is tracked by block height
+  SendTimeout  time.Duration         // per endpoint, default 5s, so a hanging endpoint doesn't hold up the others
+  PollInterval time.Duration         // default 1s
+  MaxTrack     time.Duration         // how long to track a bundle whose blockhash expiry can't be determined, default 2m
+  Dedup        *dedup.Guard          // when set, repeat submissions of the same bundle are rejected or coalesced
+  Logger       *slog.Logger
+  Metrics      metrics.Recorder
+}
+
+func NewHedgedSender(clients []*Client, jsonrpc []*jitorpc.JitoClient) *HedgedSender{
+  h := &HedgedSender{
+    Clients:      clients,
+    JSONRPC:      jsonrpc,
+    SendTimeout:  5 * time.Second,
+    PollInterval: time.Second,
+    MaxTrack:     2 * time.Minute,
+    Logger:       pkg.NopLogger(),
+    Metrics:      metrics.Nop{},
+  }
+  if len(clients) > 0{
+    clients[0].ensureRPC()
+    h.RPC = clients[0].RpcConn
+  }
+  return h
+}
+
+// Send sends the bundle through every endpoint concurrently and returns once each of them answered. It fails
+// only if every endpoint did.
+func (h *HedgedSender) Send(ctx context.Context, transactions []*solana.Transaction) (*HedgedSubmission, error){
+  if len(h.Clients)+len(h.JSONRPC) == 0{
+    return nil, errors.New("hedged sender has no endpoints")
+  }
+  bundle, err := assembleBundle(transactions)
+  if err != nil{
+    return nil, err
+  }
+  return dedup.Do(ctx, h.Dedup, metrics.KindBundle, dedup.BundleKeys(bundle, transactions), func() (*HedgedSubmission, error){
+    return h.send(ctx, transactions)
+  })
+}
+
+func (h *HedgedSender) send(ctx context.Context, transactions []*solana.Transaction) (*HedgedSubmission, error){
+  ctx, span := tracing.Start(ctx, "jito.bundle.hedged",
+    tracing.SignaturesKey.StringSlice(pkg.SignatureStrings(pkg.BatchExtractSigFromTx(transactions))),
+  )
+  sub := &HedgedSubmission{Attempts: make([]*HedgeAttempt, 0, len(h.Clients)+len(h.JSONRPC))}
+  var wg sync.WaitGroup
+  attempt := func(a *HedgeAttempt, send func(ctx context.Context) (string, error)){
+    sub.Attempts = append(sub.Attempts, a)
+    wg.Add(1)
+    go func(){
+      defer wg.Done()
+      ctx, cancel := context.WithTimeout(ctx, h.SendTimeout)
+      defer cancel()
+      start := time.Now()
+      a.BundleID, a.Err = send(ctx)
+      a.Latency = time.Since(start)
+    }()
+  }
+  for _, cl := range h.Clients{
+    attempt(&HedgeAttempt{Transport: "grpc", Region: cl.region}, func(ctx context.Context) (string, error){
+      resp, err := cl.broadcastBundle(ctx, transactions)
+      if err != nil{
+        return "", err
+      }
+      return resp.Uuid, nil
+    })
+  }
+  for _, jc := range h.JSONRPC{
+    attempt(&HedgeAttempt{Transport: "jsonrpc", Region: jc.Region()}, func(ctx context.Context) (string, error){
+      return jc.SendBundle(ctx, transactions)
+    })
+  }
+  wg.Wait()
+
+  var errs []error
+  for _, a := range sub.Attempts{
+    if a.Err != nil{
+      errs = append(errs, fmt.Errorf("%s: %w", a.Path(), a.Err))
+      continue
+    }
+    if !slices.Contains(sub.BundleIDs, a.BundleID){
+      sub.BundleIDs = append(sub.BundleIDs, a.BundleID)
+    }
+  }
+  err := errors.Join(errs...)
+  if len(sub.BundleIDs) == 0{
+    tracing.End(span, err)
+    return sub, err
+  }
+  span.SetAttributes(tracing.BundleUUIDKey.String(sub.BundleIDs[0]))
+  tracing.End(span, nil)
+
+  h.Logger.Info("hedged bundle sent",
+    slog.Any("bundle_uuids", sub.BundleIDs),
+    slog.Int("endpoints", len(sub.Attempts)),
+    slog.Int("failed", len(errs)),
+  )
+  if err != nil{
+    h.Logger.Warn("hedged bundle failed on some endpoints", slog.Any("error", err))
+  }
+  return sub, nil
+}
+
+type hedgeReport struct{
+  state  journal.State
+  slot   uint64
+  source string
+  err    error
+}
+
+// SendWithConfirmation sends the bundle like Send and waits until it lands or expires, according to whichever
+// status source reports first: the signature statuses, or the inflight bundle statuses of a JSON-RPC endpoint.
+// A rejection by one endpoint is not final, since the bundle may still land through another.
+func (h *HedgedSender) SendWithConfirmation(ctx context.Context, transactions []*solana.Transaction) (*HedgedResult, error){
+  submittedAt := time.Now()
+  sub, err := h.Send(ctx, transactions)
+  if err != nil{
+    return &HedgedResult{HedgedSubmission: sub}, err
+  }
+  if h.RPC == nil{
+    return &HedgedResult{HedgedSubmission: sub, State: journal.StateSent}, errors.New("hedged sender has no Solana RPC client to confirm with")
+  }
+  h.Metrics.InFlight(1)
+  defer h.Metrics.InFlight(-1)
+
+  ctx, cancel := context.WithCancel(ctx)
+  defer cancel()
+  reports := make(chan hedgeReport, 1+len(h.JSONRPC))
+  go h.pollSignatures(ctx, transactions, reports)
+  for _, jc := range h.JSONRPC{
+    go h.pollInflight(ctx, jc, sub.BundleIDs, reports)
+  }
+
+  res := &HedgedResult{HedgedSubmission: sub}
+  select{
+  case <-ctx.Done():
+    res.State = journal.StateSent
+    return res, ctx.Err()
+  case r := <-reports:
+    res.State, res.Slot, res.Source = r.state, r.slot, r.source
+    switch r.state{
+    case journal.StateLanded:
+      h.Metrics.BundleResult(metrics.OutcomeLanded, "")
+      h.Metrics.Landed(time.Since(submittedAt))
+      h.Logger.Info("hedged bundle confirmed",
+        slog.Any("bundle_uuids", sub.BundleIDs),
+        slog.String("source", r.source),
+        slog.Uint64("slot", r.slot),
+        slog.Duration("latency", time.Since(submittedAt)),
+      )
+    case journal.StateExpired:
+      h.Metrics.BundleResult(metrics.OutcomeExpired, "")
+    }
+    if r.err != nil{
+      h.Logger.Warn("hedged bundle did not land", slog.Any("bundle_uuids", sub.BundleIDs), slog.Any("error", r.err))
+    }
+    return res, r.err
+  }
+}
+
+// pollSignatures reports once the signatures of the bundle land or its blockhash expires.
+func (h *HedgedSender) pollSignatures(ctx context.Context, transactions []*solana.Transaction, reports chan<- hedgeReport){
+  signatures := pkg.BatchExtractSigFromTx(transactions)
+  start := time.Now()
+  ticker := time.NewTicker(h.PollInterval)
+  defer ticker.Stop()
+  for{
+    select{
+    case <-ctx.Done():
+      return
+    case <-ticker.C:
+    }
+    expired, lastValid, height, expErr := blockhashExpired(ctx, h.RPC, h.Blockhashes, transactions)
+    if expErr != nil{
+      h.Logger.Debug("failed to check blockhash expiry", slog.Any("error", expErr))
+    }
+    statuses, err := h.RPC.GetSignatureStatuses(ctx, false, signatures...)
+    if err != nil{
+      h.Logger.Debug("failed to get signature statuses", slog.Any("error", err))
+      continue
+    }
+    landed, slot, txErr := pkg.FoldSignatureStatuses(statuses.Value)
+    switch{
+    case txErr != nil:
+      reports <- hedgeReport{state: journal.StateFailed, slot: slot, source: "signatures", err: fmt.Errorf("bundle transaction failed: %v", txErr)}
+      return
+    case landed == len(signatures):
+      reports <- hedgeReport{state: journal.StateLanded, slot: slot, source: "signatures"}
+      return
+    case landed > 0:
+      continue // bundles land atomically: the remaining statuses will show up
+    case expired:
+      err := NewBlockhashExpiredError(transactions[0].Message.RecentBlockhash.String(), lastValid, height)
+      reports <- hedgeReport{state: journal.StateExpired, source: "signatures", err: err}
+      return
+    case time.Since(start) > h.MaxTrack:
+      reports <- hedgeReport{state: journal.StateSent, source: "signatures", err: fmt.Errorf("bundle unresolved after %s", h.MaxTrack)}
+      return
+    }
+  }
+}
+
+// pollInflight reports once a JSON-RPC endpoint reports one of the bundle IDs as landed.
+func (h *HedgedSender) pollInflight(ctx context.Context, jc *jitorpc.JitoClient, ids []string, reports chan<- hedgeReport){
+  source := (&HedgeAttempt{Transport: "jsonrpc", Region: jc.Region()}).Path()
+  ticker := time.NewTicker(h.PollInterval)
+  defer ticker.Stop()
+  for{
+    select{
+    case <-ctx.Done():
+      return
+    case <-ticker.C:
+    }
+    for start := 0; start < len(ids); start += 5{ // at most 5 bundle IDs per call
+      resp, err := jc.GetInflightBundleStatuses(ctx, ids[start:min(start+5, len(ids))])
+      if err != nil{
+        h.Logger.Debug("failed to get inflight bundle statuses", slog.String("source", source), slog.Any("error", err))
+        break
+      }
+      for _, v := range resp.Value{
+        if v.Status == "Landed"{
+          reports <- hedgeReport{state: journal.StateLanded, slot: v.LandedSlot, source: source}
+          return
+        }
+      }
+    }
+  }
+}
@@ -0,0 +1,239 @@
+package searcher_client
+import(
+  "context"
+  "encoding/binary"
+  "errors"
+  "fmt"
+
+  "github.com/scatkit/pumpdexer/solana"
+  "github.com/scatkit/gojito/pb"
+  "github.com/scatkit/gojito/pkg"
+  "google.golang.org/grpc"
+)
+
+var (
+  TokenProgramID     = solana.MustPubkeyFromBase58("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+  Token2022ProgramID = solana.MustPubkeyFromBase58("TokenzQdBNbLqP5VEhdkAS6EPFLC1PHnBqCXEpPxuEb")
+  NativeMint         = solana.MustPubkeyFromBase58("So11111111111111111111111111111111111111112")
+)
+
+// LamportsPerSignature is the base fee charged for every transaction signature.
+const LamportsPerSignature = 5000
+
+const defaultInstructionComputeUnits = 200_000
+
+// SPL token account layout: mint (32) | owner (32) | amount (u64) | ...
+const tokenAccountSize = 165
+
+var errNoProfitWallet = errors.New("profit config must specify the searcher wallet")
+
+type ProfitConfig struct{
+  Wallet              solana.PublicKey   // searcher wallet whose profit is computed
+  WatchedMints        []solana.PublicKey // token deltas are only reported for these mints (all mints when empty)
+  PriorityFeeLamports uint64             // overrides the priority fee the wallet pays, derived from the compute budget instructions
+  MinProfitLamports   int64              // bundles projected below this are refused
+}
+
+type LamportDelta struct{
+  Account solana.PublicKey
+  Pre     uint64
+  Post    uint64
+  Delta   int64
+}
+
+type TokenDelta struct{
+  Account solana.PublicKey // token account
+  Mint    solana.PublicKey
+  Owner   solana.PublicKey
+  Pre     uint64
+  Post    uint64
+  Delta   int64
+}
+
+// ProfitReport summarizes the balance changes of a simulated bundle.
+type ProfitReport struct{
+  LamportDeltas []LamportDelta
+  TokenDeltas   []TokenDelta
+  ComputeUnits  uint64
+  TipLamports   uint64
+  FeeLamports   uint64 // of the transactions paid for by the wallet
+  // NetProfitLamports is the wallet's lamport (and wrapped SOL) delta. The simulated balances already account for
+  // the fees and tips paid by the wallet, so they aren't subtracted again.
+  NetProfitLamports int64
+}
+
+// Profitable reports whether the projected profit reaches minProfit.
+func (r *ProfitReport) Profitable(minProfit int64) bool{
+  return r.NetProfitLamports >= minProfit
+}
+
+type tokenAccount struct{
+  mint   solana.PublicKey
+  owner  solana.PublicKey
+  amount uint64
+}
+
+func decodeTokenAccount(acc *SimulatedAccount) (*tokenAccount, bool){
+  if acc == nil || len(acc.Data) < tokenAccountSize{
+    return nil, false
+  }
+  if !acc.Owner.Equals(TokenProgramID) && !acc.Owner.Equals(Token2022ProgramID){
+    return nil, false
+  }
+  return &tokenAccount{
+    mint:   solana.PublicKeyFromBytes(acc.Data[0:32]),
+    owner:  solana.PublicKeyFromBytes(acc.Data[32:64]),
+    amount: binary.LittleEndian.Uint64(acc.Data[64:72]),
+  }, true
+}
+
+// AnalyzeSimulation computes balance deltas across the whole bundle, i.e. from the state before its first
+// transaction to the state after its last one. watched must be the WatchedAccounts the simulation was run with.
+func AnalyzeSimulation(sim *BundleSimulation, watched []solana.PublicKey, transactions []*solana.Transaction, tipAccounts []string, cfg *ProfitConfig) (*ProfitReport, error){
+  if cfg == nil || cfg.Wallet.IsZero(){
+    return nil, errNoProfitWallet
+  }
+  if sim == nil{
+    return nil, errors.New("no simulation to analyze")
+  }
+  if err := sim.Summary.Err(); err != nil{
+    return nil, err
+  }
+  if len(sim.TransactionResults) == 0{
+    return nil, errors.New("simulation returned no transaction results")
+  }
+  first := sim.TransactionResults[0]
+  last := sim.TransactionResults[len(sim.TransactionResults)-1]
+  if len(first.PreExecutionAccounts) != len(watched) || len(last.PostExecutionAccounts) != len(watched){
+    return nil, fmt.Errorf("simulation returned %d/%d accounts for %d watched accounts",
+      len(first.PreExecutionAccounts), len(last.PostExecutionAccounts), len(watched))
+  }
+
+  mints := make(map[solana.PublicKey]struct{}, len(cfg.WatchedMints))
+  for _, mint := range cfg.WatchedMints{
+    mints[mint] = struct{}{}
+  }
+
+  report := &ProfitReport{TipLamports: pkg.TipLamports(transactions, tipAccounts)}
+  var walletDelta int64
+  for i, account := range watched{
+    pre, post := first.PreExecutionAccounts[i], last.PostExecutionAccounts[i]
+    var preLamports, postLamports uint64
+    if pre != nil{
+      preLamports = pre.Lamports
+    }
+    if post != nil{
+      postLamports = post.Lamports
+    }
+    delta := LamportDelta{Account: account, Pre: preLamports, Post: postLamports, Delta: int64(postLamports) - int64(preLamports)}
+    report.LamportDeltas = append(report.LamportDeltas, delta)
+    if account.Equals(cfg.Wallet){
+      walletDelta += delta.Delta
+    }
+
+    preToken, preOk := decodeTokenAccount(pre)
+    postToken, postOk := decodeTokenAccount(post)
+    if !preOk && !postOk{
+      continue
+    }
+    token := TokenDelta{Account: account}
+    if preOk{
+      token.Mint, token.Owner, token.Pre = preToken.mint, preToken.owner, preToken.amount
+    }
+    if postOk{
+      token.Mint, token.Owner, token.Post = postToken.mint, postToken.owner, postToken.amount
+    }
+    if _, ok := mints[token.Mint]; len(mints) > 0 && !ok{
+      continue
+    }
+    token.Delta = int64(token.Post) - int64(token.Pre)
+    report.TokenDeltas = append(report.TokenDeltas, token)
+    // wrapped SOL held by the wallet counts towards profit
+    if token.Mint.Equals(NativeMint) && token.Owner.Equals(cfg.Wallet){
+      walletDelta += token.Delta
+    }
+  }
+
+  // only the fees of the wallet's own transactions are reported; other transactions of the bundle, e.g. the
+  // ones it backruns, are paid for by their fee payers
+  var signatures, priorityFee uint64
+  for _, tx := range transactions{
+    if len(tx.Message.AccountKeys) == 0 || !tx.Message.AccountKeys[0].Equals(cfg.Wallet){
+      continue
+    }
+    signatures += uint64(tx.Message.Header.NumRequiredSignatures)
+    priorityFee += transactionPriorityFee(tx)
+  }
+  if cfg.PriorityFeeLamports != 0{
+    priorityFee = cfg.PriorityFeeLamports
+  }
+  for _, res := range sim.TransactionResults{
+    report.ComputeUnits += res.UnitsConsumed
+  }
+  report.FeeLamports = signatures*LamportsPerSignature + priorityFee
+  report.NetProfitLamports = walletDelta
+  return report, nil
+}
+
+// transactionPriorityFee is the priority fee requested by tx's compute budget instructions.
+// Without an explicit limit every non compute budget instruction is allotted 200k units.
+func transactionPriorityFee(tx *solana.Transaction) uint64{
+  units, price := pkg.ComputeBudget(tx)
+  if price == 0{
+    return 0
+  }
+  if units == 0{
+    limit := uint64(0)
+    for _, inst := range tx.Message.Instructions{
+      if int(inst.ProgramIDIndex) < len(tx.Message.AccountKeys) && !tx.Message.AccountKeys[inst.ProgramIDIndex].Equals(pkg.ComputeBudgetProgramID){
+        limit += defaultInstructionComputeUnits
+      }
+    }
+    units = uint32(min(limit, pkg.MaxComputeUnitLimit))
+  }
+  return pkg.PriorityFeeLamports(units, price)
+}
+
+// SimulateBundleProfit simulates the bundle watching the wallet and the given accounts and analyzes the result.
+// It returns the report together with a BundleRejectionError if the projected profit is below cfg.MinProfitLamports.
+func (cl *Client) SimulateBundleProfit(ctx context.Context, transactions []*solana.Transaction, watched []solana.PublicKey, cfg *ProfitConfig,
+) (*ProfitReport, error){
+  if cfg == nil || cfg.Wallet.IsZero(){
+    return nil, errNoProfitWallet
+  }
+  // without the tip accounts the tip would count as 0 and overstate the profit
+  tipAccounts, err := cl.loadTipAccounts()
+  if err != nil{
+    return nil, fmt.Errorf("failed to get tip accounts: %w", err)
+  }
+  accounts := []solana.PublicKey{cfg.Wallet}
+  for _, account := range watched{
+    if !account.Equals(cfg.Wallet){
+      accounts = append(accounts, account)
+    }
+  }
+
+  sim, err := cl.SimulateBundle(ctx, transactions, &SimulateBundleOpts{WatchedAccounts: accounts})
+  if err != nil{
+    return nil, err
+  }
+  report, err := AnalyzeSimulation(sim, accounts, transactions, tipAccounts, cfg)
+  if err != nil{
+    return nil, err
+  }
+  if !report.Profitable(cfg.MinProfitLamports){
+    return report, NewUnprofitableBundleError(report.NetProfitLamports, cfg.MinProfitLamports)
+  }
+  return report, nil
+}
+
+// BroadcastBundleIfProfitable only sends the bundle if its simulated profit reaches cfg.MinProfitLamports.
+func (cl *Client) BroadcastBundleIfProfitable(ctx context.Context, transactions []*solana.Transaction, watched []solana.PublicKey, cfg *ProfitConfig, opts ...grpc.CallOption,
+) (*jito_pb.SendBundleResponse, *ProfitReport, error){
+  report, err := cl.SimulateBundleProfit(ctx, transactions, watched, cfg)
+  if err != nil{
+    return nil, report, err
+  }
+  resp, err := cl.broadcastBundle(ctx, transactions, opts...)
+  return resp, report, err
+}
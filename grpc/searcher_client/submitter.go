@@ -0,0 +1,440 @@
+package searcher_client
+import(
+  "container/heap"
+  "context"
+  "fmt"
+  "log/slog"
+  "sync"
+  "sync/atomic"
+  "time"
+
+  "github.com/scatkit/pumpdexer/rpc"
+  "github.com/scatkit/pumpdexer/solana"
+  "github.com/scatkit/gojito/journal"
+  "github.com/scatkit/gojito/metrics"
+  "github.com/scatkit/gojito/pb"
+  "github.com/scatkit/gojito/pkg"
+  "github.com/scatkit/gojito/tracing"
+
+  "go.opentelemetry.io/otel/trace"
+  "golang.org/x/time/rate"
+)
+
+// earlyResultTTL is how long results for bundles that aren't tracked (yet) are kept: the block engine may
+// report on a bundle before SendBundle returns its UUID.
+const earlyResultTTL = time.Minute
+
+// SubmitRequest is a bundle queued for a Submitter.
+type SubmitRequest struct{
+  Transactions  []*solana.Transaction
+  ExpectedValue int64 // lamports the bundle is expected to earn; queued bundles are submitted highest first
+  Tag           any   // returned untouched with the result, e.g. to correlate it with an opportunity
+}
+
+// SubmitResult is the outcome of a bundle handled by a Submitter.
+type SubmitResult struct{
+  Request *SubmitRequest
+  UUID    string        // empty if SendBundle failed
+  State   journal.State // landed, failed, rejected, dropped or expired; sent if tracking was given up
+  Slot    uint64        // of landed and failed bundles
+  Err     error         // why the bundle didn't land
+  Queued  time.Duration // spent waiting for an in-flight slot and rate budget
+  Latency time.Duration // from submission to the terminal state
+}
+
+type SubmitterOpts struct{
+  MaxInFlight int           // bundles submitted and not resolved yet, default 8
+  Rate        float64       // submissions per second, default 1 (the block engine's default limit); negative disables it
+  Burst       int           // submissions allowed at once within Rate, default 1
+  QueueSize   int           // bundles held for prioritization, default 64; a full queue stops reading the input
+  MaxTrack    time.Duration // how long to track a bundle whose blockhash expiry can't be determined, default 2m
+}
+
+// Submitter sends bundles through a Client with bounded concurrency and rate, highest expected value first,
+// and tracks each of them to a terminal state. Once started, it's the only reader of the client's bundle result
+// stream, so BroadcastBundleWithConfirmation must not be used on the same client.
+type Submitter struct{
+  cl      *Client
+  opts    SubmitterOpts
+  limiter *rate.Limiter
+  slots   chan struct{} // one per bundle in flight
+  queued  atomic.Int64
+
+  routeOnce sync.Once
+  mu        sync.Mutex
+  waiters   map[string]chan *jito_pb.BundleResult
+  early     map[string]*earlyResults
+}
+
+type earlyResults struct{
+  at      time.Time
+  results []*jito_pb.BundleResult
+}
+
+func NewSubmitter(cl *Client, opts *SubmitterOpts) *Submitter{
+  var o SubmitterOpts
+  if opts != nil{
+    o = *opts
+  }
+  if o.MaxInFlight <= 0{
+    o.MaxInFlight = 8
+  }
+  if o.Rate == 0{
+    o.Rate = 1
+  }
+  if o.Burst <= 0{
+    o.Burst = 1
+  }
+  if o.QueueSize <= 0{
+    o.QueueSize = 64
+  }
+  if o.MaxTrack <= 0{
+    o.MaxTrack = 2 * time.Minute
+  }
+  limit := rate.Limit(o.Rate)
+  if o.Rate < 0{
+    limit = rate.Inf
+  }
+  cl.ensureRPC() // once here: the trackers run concurrently
+  return &Submitter{
+    cl:      cl,
+    opts:    o,
+    limiter: rate.NewLimiter(limit, o.Burst),
+    slots:   make(chan struct{}, o.MaxInFlight),
+    waiters: make(map[string]chan *jito_pb.BundleResult),
+    early:   make(map[string]*earlyResults),
+  }
+}
+
+// InFlight returns the number of bundles submitted and not resolved yet.
+func (s *Submitter) InFlight() int{ return len(s.slots) }
+
+// Queued returns the number of bundles waiting to be submitted.
+func (s *Submitter) Queued() int{ return int(s.queued.Load()) }
+
+// Run submits the bundles received on in and publishes their outcomes on the returned channel, which is closed
+// once in is closed and every bundle is resolved. While MaxInFlight bundles are in flight or the rate budget is
+// spent, bundles queue up to QueueSize and in isn't read any further, blocking its senders. When ctx is done,
+// queued bundles are discarded and results not delivered yet are dropped.
+// Several Runs on the same Submitter share its in-flight and rate budgets.
+func (s *Submitter) Run(ctx context.Context, in <-chan *SubmitRequest) <-chan *SubmitResult{
+  results := make(chan *SubmitResult, s.opts.MaxInFlight)
+  s.routeOnce.Do(func(){ go s.routeResults() })
+  go s.dispatch(ctx, in, results)
+  return results
+}
+
+func (s *Submitter) dispatch(ctx context.Context, in <-chan *SubmitRequest, results chan<- *SubmitResult){
+  var wg sync.WaitGroup
+  defer func(){
+    wg.Wait()
+    close(results)
+  }()
+
+  queue := &submitQueue{}
+  var seq uint64
+  // once a slot is taken, the rate budget is reserved and the next bundle is popped when ready fires
+  var (
+    reservation *rate.Reservation
+    timer       *time.Timer
+    ready       <-chan time.Time
+  )
+  for in != nil || queue.Len() > 0{
+    receive := in
+    if queue.Len() >= s.opts.QueueSize{
+      receive = nil
+    }
+    var slots chan struct{}
+    if queue.Len() > 0 && ready == nil{
+      slots = s.slots
+    }
+
+    select{
+    case <-ctx.Done():
+      if ready != nil{
+        timer.Stop()
+        reservation.Cancel()
+        <-s.slots
+      }
+      s.queued.Add(-int64(queue.Len()))
+      return
+    case req, ok := <-receive:
+      if !ok{
+        in = nil
+        continue
+      }
+      if req == nil || len(req.Transactions) == 0{
+        continue
+      }
+      seq++
+      heap.Push(queue, &queuedBundle{req: req, seq: seq, at: time.Now()})
+      s.queued.Add(1)
+    case slots <- struct{}{}:
+      reservation = s.limiter.Reserve()
+      timer = time.NewTimer(reservation.Delay())
+      ready = timer.C
+    case <-ready:
+      ready = nil
+      // popped once the rate budget allows it, so that bundles received while waiting for it compete too
+      q := heap.Pop(queue).(*queuedBundle)
+      s.queued.Add(-1)
+      wg.Add(1)
+      go func(){
+        defer wg.Done()
+        res := s.track(ctx, q)
+        <-s.slots
+        select{
+        case results <- res:
+        case <-ctx.Done():
+        }
+      }()
+    }
+  }
+}
+
+// track submits a bundle and waits for its terminal state.
+func (s *Submitter) track(ctx context.Context, q *queuedBundle) (res *SubmitResult){
+  cl := s.cl
+  transactions := q.req.Transactions
+  res = &SubmitResult{Request: q.req, Queued: time.Since(q.at)}
+  start := time.Now()
+  defer func(){ res.Latency = time.Since(start) }()
+
+  lastValid, known := cl.lastValidBlockHeight(transactions)
+  // a bundle whose blockhash expired while it was queued can't land anymore
+  if known{
+    if expired, _, height, _ := cl.Blockhashes.Expired(ctx, transactions[0].Message.RecentBlockhash); expired{
+      cl.Metrics.BundleResult(metrics.OutcomeExpired, "")
+      res.State, res.Err = journal.StateExpired, NewBlockhashExpiredError(transactions[0].Message.RecentBlockhash.String(), lastValid, height)
+      return res
+    }
+  }
+
+  ctx, span := tracing.Start(ctx, "jito.bundle", cl.bundleAttributes(transactions)...)
+  defer func(){ tracing.End(span, res.Err) }()
+
+  bundle, err := cl.broadcastBundle(ctx, transactions)
+  if err != nil{
+    res.State, res.Err = journal.StateRejected, fmt.Errorf("Couldn't broadcast bundles: %w", err)
+    return res
+  }
+  res.UUID = bundle.Uuid
+  span.SetAttributes(tracing.BundleUUIDKey.String(bundle.Uuid))
+  bundleResults := s.await(bundle.Uuid) // includes results routed before SendBundle returned
+  defer s.release(bundle.Uuid)
+  cl.Metrics.InFlight(1)
+  defer cl.Metrics.InFlight(-1)
+
+  signatures := pkg.BatchExtractSigFromTx(transactions)
+  logger := cl.Logger.With(
+    slog.String("bundle_uuid", bundle.Uuid),
+    slog.Any("signatures", pkg.SignatureStrings(signatures)),
+    slog.String("region", cl.region),
+  )
+  resolve := func(state journal.State, slot uint64, err error){
+    res.State, res.Slot, res.Err = state, slot, err
+    u := journal.Update{State: state, Slot: slot}
+    if err != nil{
+      u.Reason = err.Error()
+    }
+    cl.journalUpdate(transactions, u)
+  }
+
+  ticker := time.NewTicker(cl.PollInterval)
+  defer ticker.Stop()
+  for{
+    select{
+    case <-ctx.Done():
+      res.State, res.Err = journal.StateSent, ctx.Err()
+      return res
+
+    case result := <-bundleResults:
+      outcome, reason := metrics.ClassifyBundleResult(result)
+      cl.Metrics.BundleResult(outcome, reason)
+      tracing.RecordBundleResult(span, result)
+      err := handleBundleResult(result, "")
+      if err == nil && outcome == metrics.OutcomeDropped{
+        err = NewDroppedBundle(reason)
+      }
+      if err != nil{
+        logger.Warn("bundle rejected", slog.Any("error", err))
+        state := journal.StateRejected
+        if outcome == metrics.OutcomeDropped{
+          state = journal.StateDropped
+        }
+        resolve(state, 0, err)
+        return res
+      }
+      if outcome == metrics.OutcomeAccepted{
+        cl.journalUpdate(transactions, journal.Update{State: journal.StateAccepted})
+      }
+
+    case <-ticker.C:
+      expired, lastValid, height, expErr := blockhashExpired(ctx, cl.RpcConn, cl.Blockhashes, transactions)
+      if expErr != nil{
+        logger.Debug("failed to check blockhash expiry", slog.Any("error", expErr))
+      }
+
+      statuses, err := cl.RpcConn.GetSignatureStatuses(ctx, false, signatures...)
+      if err != nil{
+        logger.Debug("failed to get signature statuses", slog.Any("error", err))
+        continue
+      }
+      landed, slot, txErr := pkg.FoldSignatureStatuses(statuses.Value)
+      switch{
+      case txErr != nil:
+        logger.Warn("bundle transaction failed", slog.Uint64("slot", slot), slog.Any("error", txErr))
+        resolve(journal.StateFailed, slot, fmt.Errorf("bundle transaction failed: %v", txErr))
+        return res
+      case landed == len(signatures):
+        cl.Metrics.BundleResult(metrics.OutcomeLanded, "")
+        cl.Metrics.Landed(time.Since(start))
+        span.AddEvent("landed", trace.WithAttributes(tracing.SlotKey.Int64(int64(slot))))
+        logger.Info("bundle confirmed", slog.Uint64("slot", slot), slog.Duration("latency", time.Since(start)))
+        resolve(journal.StateLanded, slot, nil)
+        return res
+      case landed > 0:
+        continue // bundles land atomically: the remaining statuses will show up
+      case expired:
+        logger.Warn("bundle expired", slog.Uint64("block_height", height), slog.Duration("latency", time.Since(start)))
+        cl.Metrics.BundleResult(metrics.OutcomeExpired, "")
+        resolve(journal.StateExpired, 0, NewBlockhashExpiredError(transactions[0].Message.RecentBlockhash.String(), lastValid, height))
+        return res
+      case time.Since(start) > s.opts.MaxTrack:
+        logger.Warn("gave up tracking bundle", slog.Duration("latency", time.Since(start)))
+        res.State, res.Err = journal.StateSent, fmt.Errorf("bundle unresolved after %s", s.opts.MaxTrack)
+        return res
+      }
+    }
+  }
+}
+
+// blockhashExpired checks the expiry of the bundle's blockhash with blockhashes, or with the node alone when
+// there is no provider.
+func blockhashExpired(ctx context.Context, client *rpc.Client, blockhashes *pkg.BlockhashProvider, transactions []*solana.Transaction,
+) (expired bool, lastValid, height uint64, err error){
+  hash := transactions[0].Message.RecentBlockhash
+  if blockhashes != nil{
+    return blockhashes.Expired(ctx, hash)
+  }
+  expired, height, err = pkg.BlockhashExpired(ctx, client, hash, 0, nil)
+  return expired, 0, height, err
+}
+
+// routeResults reads the client's bundle result stream for as long as it's open and hands every result to
+// the bundle it belongs to.
+func (s *Submitter) routeResults(){
+  for{
+    result, err := s.cl.BundleStreamSubscription.Recv()
+    if err != nil{
+      s.cl.Logger.Warn("bundle result stream failed, tracking bundles by signature status only", slog.Any("error", err))
+      return
+    }
+    id := result.GetBundleId()
+    s.mu.Lock()
+    if ch, ok := s.waiters[id]; ok{
+      deliver(ch, result)
+    } else{
+      s.pruneEarly()
+      e := s.early[id]
+      if e == nil{
+        e = &earlyResults{at: time.Now()}
+        s.early[id] = e
+      }
+      e.results = append(e.results, result)
+    }
+    s.mu.Unlock()
+  }
+}
+
+// pruneEarly drops results that were never claimed, e.g. of bundles sent by other means. s.mu must be held.
+func (s *Submitter) pruneEarly(){
+  for id, e := range s.early{
+    if time.Since(e.at) > earlyResultTTL{
+      delete(s.early, id)
+    }
+  }
+}
+
+// await returns the channel receiving the results of a bundle, starting with the ones received before.
+func (s *Submitter) await(uuid string) <-chan *jito_pb.BundleResult{
+  ch := make(chan *jito_pb.BundleResult, 8)
+  s.mu.Lock()
+  defer s.mu.Unlock()
+  if e, ok := s.early[uuid]; ok{
+    for _, result := range e.results{
+      deliver(ch, result)
+    }
+    delete(s.early, uuid)
+  }
+  s.waiters[uuid] = ch
+  return ch
+}
+
+// deliver hands a result to a tracker without blocking. When the tracker is behind, non-terminal results are
+// dropped and a terminal one takes the place of the oldest pending result, so the tracker always gets to see it.
+// s.mu must be held: results are only sent with it held, so a slot freed here stays free.
+func deliver(ch chan *jito_pb.BundleResult, result *jito_pb.BundleResult){
+  select{
+  case ch <- result:
+    return
+  default:
+  }
+  if !terminalResult(result){
+    return
+  }
+  select{
+  case <-ch:
+  default:
+  }
+  select{
+  case ch <- result:
+  default:
+  }
+}
+
+// terminalResult reports whether a result ends the tracking of its bundle, see track.
+func terminalResult(result *jito_pb.BundleResult) bool{
+  switch result.Result.(type){
+  case *jito_pb.BundleResult_Rejected, *jito_pb.BundleResult_Dropped:
+    return true
+  }
+  return false
+}
+
+func (s *Submitter) release(uuid string){
+  s.mu.Lock()
+  delete(s.waiters, uuid)
+  s.mu.Unlock()
+}
+
+type queuedBundle struct{
+  req *SubmitRequest
+  seq uint64 // keeps bundles of equal value first in, first out
+  at  time.Time
+}
+
+// submitQueue is a max-heap of queued bundles by expected value.
+type submitQueue []*queuedBundle
+
+func (q submitQueue) Len() int{ return len(q) }
+
+func (q submitQueue) Less(i, j int) bool{
+  if q[i].req.ExpectedValue != q[j].req.ExpectedValue{
+    return q[i].req.ExpectedValue > q[j].req.ExpectedValue
+  }
+  return q[i].seq < q[j].seq
+}
+
+func (q submitQueue) Swap(i, j int){ q[i], q[j] = q[j], q[i] }
+
+func (q *submitQueue) Push(x any){ *q = append(*q, x.(*queuedBundle)) }
+
+func (q *submitQueue) Pop() any{
+  old := *q
+  n := len(old)
+  item := old[n-1]
+  old[n-1] = nil
+  *q = old[:n-1]
+  return item
+}
@@ -0,0 +1,180 @@
+package searcher_client
+import(
+  "context"
+  "testing"
+  "time"
+
+  "github.com/scatkit/gojito/jitotest"
+  "github.com/scatkit/gojito/journal"
+)
+
+// landRecorded lands every bundle the block engine records until the test ends.
+func landRecorded(t *testing.T, be *jitotest.BlockEngine, sol *jitotest.SolanaRPC){
+  stop := make(chan struct{})
+  t.Cleanup(func(){ close(stop) })
+  go func(){
+    landed := 0
+    for{
+      select{
+      case <-stop:
+        return
+      case <-time.After(5 * time.Millisecond):
+      }
+      bundles := be.Bundles()
+      for _, b := range bundles[landed:]{
+        sol.Land(b.Signatures...)
+      }
+      landed = len(bundles)
+    }
+  }()
+}
+
+// submitRequest builds a one transaction bundle expected to earn value.
+func submitRequest(t *testing.T, cl *Client, value int64) *SubmitRequest{
+  t.Helper()
+  txs, sigs := transferBundle(t, cl, 1)
+  return &SubmitRequest{Transactions: txs, ExpectedValue: value, Tag: sigs[0]}
+}
+
+// sentOrder returns the tags of the requests in the order the block engine received them.
+func sentOrder(be *jitotest.BlockEngine) []any{
+  var order []any
+  for _, b := range be.Bundles(){
+    order = append(order, b.Signatures[0])
+  }
+  return order
+}
+
+func waitFor(t *testing.T, what string, cond func() bool){
+  t.Helper()
+  deadline := time.Now().Add(3 * time.Second)
+  for !cond(){
+    if time.Now().After(deadline){
+      t.Fatalf("timed out waiting for %s", what)
+    }
+    time.Sleep(5 * time.Millisecond)
+  }
+}
+
+func collect(t *testing.T, results <-chan *SubmitResult, n int) []*SubmitResult{
+  t.Helper()
+  var out []*SubmitResult
+  timeout := time.After(5 * time.Second)
+  for len(out) < n{
+    select{
+    case res, ok := <-results:
+      if !ok{
+        t.Fatalf("results closed after %d of %d", len(out), n)
+      }
+      out = append(out, res)
+    case <-timeout:
+      t.Fatalf("received %d of %d results", len(out), n)
+    }
+  }
+  return out
+}
+
+func TestSubmitterBoundsInFlight(t *testing.T){
+  cl, be, sol := newFakeClient(t, jitotest.WithScript(jitotest.Silent, 0))
+  s := NewSubmitter(cl, &SubmitterOpts{MaxInFlight: 2, Rate: -1})
+  in := make(chan *SubmitRequest, 4)
+  for i := 0; i < 4; i++{
+    in <- submitRequest(t, cl, 0)
+  }
+  close(in)
+  ctx, cancel := context.WithCancel(context.Background())
+  defer cancel()
+  results := s.Run(ctx, in)
+
+  waitFor(t, "two bundles in flight", func() bool{ return len(be.Bundles()) == 2 && s.Queued() == 2 })
+  time.Sleep(50 * time.Millisecond)
+  if n := len(be.Bundles()); n != 2 || s.InFlight() != 2{
+    t.Fatalf("%d bundles sent, %d in flight, want 2", n, s.InFlight())
+  }
+
+  landRecorded(t, be, sol)
+  for _, res := range collect(t, results, 4){
+    if res.State != journal.StateLanded || res.Err != nil{
+      t.Errorf("bundle %v: %s %v", res.Request.Tag, res.State, res.Err)
+    }
+  }
+  if _, ok := <-results; ok{
+    t.Error("results not closed after the input")
+  }
+  if s.InFlight() != 0 || s.Queued() != 0{
+    t.Errorf("%d in flight, %d queued after the run", s.InFlight(), s.Queued())
+  }
+}
+
+func TestSubmitterSendsHighestValueFirst(t *testing.T){
+  cl, be, sol := newFakeClient(t, jitotest.WithScript(jitotest.Silent, 0))
+  s := NewSubmitter(cl, &SubmitterOpts{MaxInFlight: 1, Rate: -1})
+  in := make(chan *SubmitRequest)
+  ctx, cancel := context.WithCancel(context.Background())
+  defer cancel()
+  results := s.Run(ctx, in)
+
+  // the first bundle occupies the only slot while the others queue up
+  first := submitRequest(t, cl, 0)
+  in <- first
+  waitFor(t, "the first bundle", func() bool{ return len(be.Bundles()) == 1 })
+  low, high, mid := submitRequest(t, cl, 1), submitRequest(t, cl, 3), submitRequest(t, cl, 2)
+  for _, req := range []*SubmitRequest{low, high, mid}{
+    in <- req
+  }
+  close(in)
+  waitFor(t, "queued bundles", func() bool{ return s.Queued() == 3 })
+
+  landRecorded(t, be, sol)
+  collect(t, results, 4)
+  order := sentOrder(be)
+  want := []any{first.Tag, high.Tag, mid.Tag, low.Tag}
+  for i := range want{
+    if order[i] != want[i]{
+      t.Fatalf("sent %v, want %v", order, want)
+    }
+  }
+}
+
+func TestSubmitterRateLimit(t *testing.T){
+  cl, be, sol := newFakeClient(t, jitotest.WithScript(jitotest.Silent, 0))
+  landRecorded(t, be, sol)
+  s := NewSubmitter(cl, &SubmitterOpts{Rate: 10, Burst: 1})
+  in := make(chan *SubmitRequest, 4)
+  for i := 0; i < 4; i++{
+    in <- submitRequest(t, cl, 0)
+  }
+  close(in)
+  collect(t, s.Run(context.Background(), in), 4)
+
+  bundles := be.Bundles()
+  for i := 1; i < len(bundles); i++{
+    // 100ms apart, minus the scheduling slack of the fake
+    if gap := bundles[i].ReceivedAt.Sub(bundles[i-1].ReceivedAt); gap < 80*time.Millisecond{
+      t.Errorf("bundles %d and %d sent %s apart at 10/s", i-1, i, gap)
+    }
+  }
+}
+
+func TestSubmitterPrioritizesBundlesReceivedWhileRateLimited(t *testing.T){
+  cl, be, sol := newFakeClient(t, jitotest.WithScript(jitotest.Silent, 0))
+  landRecorded(t, be, sol)
+  s := NewSubmitter(cl, &SubmitterOpts{Rate: 4, Burst: 1})
+  in := make(chan *SubmitRequest)
+  results := s.Run(context.Background(), in)
+
+  first, low, high := submitRequest(t, cl, 0), submitRequest(t, cl, 1), submitRequest(t, cl, 5)
+  in <- first
+  waitFor(t, "the first bundle", func() bool{ return len(be.Bundles()) == 1 })
+  // low waits for the rate budget, high arrives meanwhile and overtakes it
+  in <- low
+  time.Sleep(50 * time.Millisecond)
+  in <- high
+  close(in)
+  collect(t, results, 3)
+
+  order := sentOrder(be)
+  if len(order) != 3 || order[1] != high.Tag || order[2] != low.Tag{
+    t.Fatalf("sent %v, want %v", order, []any{first.Tag, high.Tag, low.Tag})
+  }
+}
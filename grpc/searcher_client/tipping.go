@@ -3,8 +3,10 @@ import (
   "github.com/scatkit/pumpdexer/solana"
   "github.com/scatkit/pumpdexer/programs/system"
   "github.com/scatkit/gojito/pb"
+  "github.com/scatkit/gojito/metrics"
   "google.golang.org/grpc"
   "math/rand"
+  "time"
 )
 
 func (cl *Client) GenerateTipRandomAccountInstruction(tipAmount uint64, from solana.PublicKey) (solana.Instruction, error) {
@@ -26,6 +28,32 @@ func (cl *Client) GetRandomTipAccount(opts ...grpc.CallOption) (string, error){
 }
  
 func (cl *Client) GetTipAccounts(opts ...grpc.CallOption) (*jito_pb.GetTipAccountsResponse, error){
-  return cl.SearcherService.GetTipAccounts(cl.Auth.GrpcCtx, &jito_pb.GetTipAccountsRequest{}, opts...)
+  start := time.Now()
+  resp, err := cl.SearcherService.GetTipAccounts(cl.Auth.GrpcCtx, &jito_pb.GetTipAccountsRequest{}, opts...)
+  cl.Metrics.TipAccountsFetched(metrics.OutcomeOf(err, metrics.OutcomeSuccess), time.Since(start))
+  if err == nil{
+    cl.tipMu.Lock()
+    cl.tipAccounts = resp.Accounts
+    cl.tipMu.Unlock()
+  }
+  return resp, err
 }
 
+// cachedTipAccounts returns the tip accounts from the last successful GetTipAccounts call.
+func (cl *Client) cachedTipAccounts() []string{
+  cl.tipMu.RLock()
+  defer cl.tipMu.RUnlock()
+  return cl.tipAccounts
+}
+
+// loadTipAccounts returns the cached tip accounts, fetching them when GetTipAccounts wasn't called yet.
+func (cl *Client) loadTipAccounts() ([]string, error){
+  if accounts := cl.cachedTipAccounts(); len(accounts) > 0{
+    return accounts, nil
+  }
+  resp, err := cl.GetTipAccounts()
+  if err != nil{
+    return nil, err
+  }
+  return resp.Accounts, nil
+}
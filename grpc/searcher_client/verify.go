@@ -0,0 +1,325 @@
+package searcher_client
+import(
+  "context"
+  "encoding/json"
+  "errors"
+  "fmt"
+  "log/slog"
+  "time"
+
+  "github.com/mr-tron/base58"
+  "github.com/scatkit/pumpdexer/rpc"
+  "github.com/scatkit/pumpdexer/rpc/jsonrpc"
+  "github.com/scatkit/pumpdexer/solana"
+  "github.com/scatkit/gojito/pb"
+)
+
+// blockRetryInterval paces VerifyLandedBundle while the block isn't available yet: a Processed result usually
+// arrives before the block is confirmed.
+const blockRetryInterval = 500 * time.Millisecond
+
+// JSON-RPC error codes of getBlock for slots in which no block was produced.
+const(
+  rpcErrSlotSkipped                = -32007
+  rpcErrLongTermStorageSlotSkipped = -32009
+)
+
+// BundleVerification is the result of checking a bundle against the block it landed in, see VerifyBundleInBlock.
+type BundleVerification struct{
+  Slot         uint64
+  Blockhash    string
+  Signatures   []solana.Signature
+  Positions    []int  // index of each transaction in the block, -1 if it isn't in the block
+  Landed       int    // transactions found in the block
+  Contiguous   bool   // the landed transactions are adjacent in the block
+  InOrder      bool   // the landed transactions appear in bundle order
+  FailedIndex  int    // bundle index of the first landed transaction that failed, -1 if none did
+  TxErr        string // its error, as JSON
+  FeeLamports  uint64 // paid by the landed transactions
+  TipLamports  uint64 // received by the tip accounts in the landed transactions
+  ComputeUnits uint64
+}
+
+// Partial reports whether only some of the bundle's transactions are in the block. Bundles execute atomically,
+// so the others landed outside of it, e.g. because they were also sent as plain transactions.
+func (v *BundleVerification) Partial() bool{
+  return v.Landed > 0 && v.Landed < len(v.Signatures)
+}
+
+// Err describes why the bundle didn't land atomically, nil if every transaction is in the block, in order,
+// next to each other and without error.
+func (v *BundleVerification) Err() error{
+  switch{
+  case v.Landed == 0:
+    return fmt.Errorf("bundle not found in block at slot %d", v.Slot)
+  case v.Partial():
+    return fmt.Errorf("bundle partially landed, %d of %d transactions in block at slot %d", v.Landed, len(v.Signatures), v.Slot)
+  case !v.InOrder:
+    return fmt.Errorf("bundle transactions out of order in block at slot %d, positions %v", v.Slot, v.Positions)
+  case !v.Contiguous:
+    return fmt.Errorf("bundle transactions not contiguous in block at slot %d, positions %v", v.Slot, v.Positions)
+  case v.FailedIndex >= 0:
+    return fmt.Errorf("bundle transaction %d (%s) failed: %s", v.FailedIndex, v.Signatures[v.FailedIndex], v.TxErr)
+  }
+  return nil
+}
+
+// VerifyBundleInBlock locates the bundle's transactions, by their first signature, in the block at slot and checks
+// that they landed atomically. The tip is the lamports the tip accounts gained in the bundle's transactions.
+func VerifyBundleInBlock(block *jito_pb.ConfirmedBlock, slot uint64, signatures []solana.Signature, tipAccounts []string) *BundleVerification{
+  v := &BundleVerification{
+    Slot:        slot,
+    Blockhash:   block.GetBlockhash(),
+    Signatures:  signatures,
+    Positions:   make([]int, len(signatures)),
+    FailedIndex: -1,
+  }
+  index := make(map[string]int, len(block.Transactions))
+  for i, tx := range block.Transactions{
+    if sigs := tx.GetTransaction().GetSignatures(); len(sigs) > 0{
+      index[string(sigs[0])] = i
+    }
+  }
+  tips := make(map[string]struct{}, len(tipAccounts))
+  for _, acc := range tipAccounts{
+    if pubkey, err := solana.PublicKeyFromBase58(acc); err == nil{
+      tips[string(pubkey[:])] = struct{}{}
+    }
+  }
+
+  v.Contiguous, v.InOrder = true, true
+  prev := -1
+  for i, sig := range signatures{
+    pos, ok := index[string(sig[:])]
+    if !ok{
+      v.Positions[i] = -1
+      continue
+    }
+    v.Positions[i] = pos
+    if prev >= 0{
+      v.InOrder = v.InOrder && pos > prev
+      v.Contiguous = v.Contiguous && pos == prev+1
+    }
+    prev = pos
+    v.Landed++
+
+    tx := block.Transactions[pos]
+    meta := tx.GetMeta()
+    if meta == nil{
+      continue
+    }
+    v.FeeLamports += meta.Fee
+    v.ComputeUnits += meta.GetComputeUnitsConsumed()
+    if meta.Err != nil && v.FailedIndex < 0{
+      v.FailedIndex, v.TxErr = i, string(meta.Err.Err)
+    }
+    keys := append(append(append([][]byte(nil), tx.GetTransaction().GetMessage().GetAccountKeys()...),
+      meta.LoadedWritableAddresses...), meta.LoadedReadonlyAddresses...)
+    for k, key := range keys{
+      if _, ok := tips[string(key)]; !ok || k >= len(meta.PreBalances) || k >= len(meta.PostBalances){
+        continue
+      }
+      if meta.PostBalances[k] > meta.PreBalances[k]{
+        v.TipLamports += meta.PostBalances[k] - meta.PreBalances[k]
+      }
+    }
+  }
+  return v
+}
+
+// VerifyLandedBundle fetches the block at slot, e.g. the one of a Processed result, and verifies the bundle
+// against it. The block is retried until it's available or ctx is done, so ctx should be bounded; a skipped slot
+// fails right away.
+func (cl *Client) VerifyLandedBundle(ctx context.Context, slot uint64, signatures []solana.Signature) (*BundleVerification, error){
+  var (
+    block *jito_pb.ConfirmedBlock
+    err   error
+  )
+  for{
+    if block, err = cl.GetConfirmedBlock(ctx, slot); err == nil{
+      break
+    }
+    if slotSkipped(err){
+      return nil, fmt.Errorf("getBlock %d: %w", slot, err)
+    }
+    cl.Logger.Debug("block not available yet", slog.Uint64("slot", slot), slog.Any("error", err))
+    select{
+    case <-ctx.Done():
+      return nil, fmt.Errorf("getBlock %d: %w", slot, err)
+    case <-time.After(blockRetryInterval):
+    }
+  }
+
+  tipAccounts, err := cl.loadTipAccounts()
+  if err != nil{
+    cl.Logger.Debug("failed to get tip accounts, tip not verified", slog.Any("error", err))
+  }
+  v := VerifyBundleInBlock(block, slot, signatures, tipAccounts)
+  if err := v.Err(); err != nil{
+    cl.Logger.Warn("bundle verification failed", slog.Uint64("slot", slot), slog.Any("error", err))
+  } else{
+    cl.Logger.Info("bundle verified",
+      slog.Uint64("slot", slot),
+      slog.Int("position", v.Positions[0]),
+      slog.Uint64("fee_lamports", v.FeeLamports),
+      slog.Uint64("tip_lamports", v.TipLamports),
+    )
+  }
+  return v, nil
+}
+
+// slotSkipped reports whether getBlock failed because no block was produced in the slot.
+func slotSkipped(err error) bool{
+  var rpcErr *jsonrpc.RPCError
+  if !errors.As(err, &rpcErr){
+    return false
+  }
+  return rpcErr.Code == rpcErrSlotSkipped || rpcErr.Code == rpcErrLongTermStorageSlotSkipped
+}
+
+type blockResult struct{
+  Blockhash         string  `json:"blockhash"`
+  PreviousBlockhash string  `json:"previousBlockhash"`
+  ParentSlot        uint64  `json:"parentSlot"`
+  BlockTime         *int64  `json:"blockTime"`
+  BlockHeight       *uint64 `json:"blockHeight"`
+  Transactions      []struct{
+    Transaction struct{
+      Signatures []string `json:"signatures"`
+      Message    struct{
+        AccountKeys []string `json:"accountKeys"`
+        Header      struct{
+          NumRequiredSignatures       uint32 `json:"numRequiredSignatures"`
+          NumReadonlySignedAccounts   uint32 `json:"numReadonlySignedAccounts"`
+          NumReadonlyUnsignedAccounts uint32 `json:"numReadonlyUnsignedAccounts"`
+        } `json:"header"`
+        RecentBlockhash string `json:"recentBlockhash"`
+        Instructions    []struct{
+          ProgramIDIndex uint32 `json:"programIdIndex"`
+          Accounts       []byte `json:"accounts"`
+          Data           string `json:"data"` // base58
+        } `json:"instructions"`
+        AddressTableLookups []struct{
+          AccountKey      string `json:"accountKey"`
+          WritableIndexes []byte `json:"writableIndexes"`
+          ReadonlyIndexes []byte `json:"readonlyIndexes"`
+        } `json:"addressTableLookups"`
+      } `json:"message"`
+    } `json:"transaction"`
+    Meta *struct{
+      Err                  json.RawMessage `json:"err"`
+      Fee                  uint64          `json:"fee"`
+      PreBalances          []uint64        `json:"preBalances"`
+      PostBalances         []uint64        `json:"postBalances"`
+      LogMessages          []string        `json:"logMessages"`
+      ComputeUnitsConsumed *uint64         `json:"computeUnitsConsumed"`
+      LoadedAddresses      *struct{
+        Writable []string `json:"writable"`
+        Readonly []string `json:"readonly"`
+      } `json:"loadedAddresses"`
+    } `json:"meta"`
+    Version json.RawMessage `json:"version"` // "legacy" or 0
+  } `json:"transactions"`
+}
+
+// GetConfirmedBlock fetches a confirmed block with its transactions from the Solana RPC node. Transaction errors
+// are kept as the node's JSON rather than bincode. It fails while the block isn't confirmed or if the slot was
+// skipped.
+func (cl *Client) GetConfirmedBlock(ctx context.Context, slot uint64) (*jito_pb.ConfirmedBlock, error){
+  cl.ensureRPC()
+  var res *blockResult
+  params := []interface{}{slot, map[string]any{
+    "commitment":                     rpc.CommitmentConfirmed,
+    "encoding":                       "json",
+    "transactionDetails":             "full",
+    "rewards":                        false,
+    "maxSupportedTransactionVersion": 0,
+  }}
+  if err := cl.RpcConn.RPCCallForInfo(ctx, &res, "getBlock", params); err != nil{
+    return nil, err
+  }
+  if res == nil{
+    return nil, fmt.Errorf("block %d not available", slot)
+  }
+
+  var decodeErr error
+  decode := func(s string) []byte{
+    raw, err := base58.Decode(s)
+    if err != nil && decodeErr == nil{
+      decodeErr = fmt.Errorf("invalid base58 %q", s)
+    }
+    return raw
+  }
+  decodeAll := func(ss []string) [][]byte{
+    out := make([][]byte, 0, len(ss))
+    for _, s := range ss{
+      out = append(out, decode(s))
+    }
+    return out
+  }
+
+  block := &jito_pb.ConfirmedBlock{
+    Blockhash:         res.Blockhash,
+    PreviousBlockhash: res.PreviousBlockhash,
+    ParentSlot:        res.ParentSlot,
+    Transactions:      make([]*jito_pb.ConfirmedTransaction, 0, len(res.Transactions)),
+  }
+  if res.BlockTime != nil{
+    block.BlockTime = &jito_pb.UnixTimestamp{Timestamp: *res.BlockTime}
+  }
+  if res.BlockHeight != nil{
+    block.BlockHeight = &jito_pb.BlockHeight{BlockHeight: *res.BlockHeight}
+  }
+  for _, t := range res.Transactions{
+    msg := &t.Transaction.Message
+    message := &jito_pb.Message{
+      Header: &jito_pb.MessageHeader{
+        NumRequiredSignatures:       msg.Header.NumRequiredSignatures,
+        NumReadonlySignedAccounts:   msg.Header.NumReadonlySignedAccounts,
+        NumReadonlyUnsignedAccounts: msg.Header.NumReadonlyUnsignedAccounts,
+      },
+      AccountKeys:     decodeAll(msg.AccountKeys),
+      RecentBlockhash: decode(msg.RecentBlockhash),
+      Versioned:       len(t.Version) > 0 && string(t.Version) != `"legacy"`,
+    }
+    for _, inst := range msg.Instructions{
+      message.Instructions = append(message.Instructions, &jito_pb.CompiledInstruction{
+        ProgramIdIndex: inst.ProgramIDIndex,
+        Accounts:       inst.Accounts,
+        Data:           decode(inst.Data),
+      })
+    }
+    for _, lookup := range msg.AddressTableLookups{
+      message.AddressTableLookups = append(message.AddressTableLookups, &jito_pb.MessageAddressTableLookup{
+        AccountKey:      decode(lookup.AccountKey),
+        WritableIndexes: lookup.WritableIndexes,
+        ReadonlyIndexes: lookup.ReadonlyIndexes,
+      })
+    }
+    tx := &jito_pb.ConfirmedTransaction{
+      Transaction: &jito_pb.Transaction{Signatures: decodeAll(t.Transaction.Signatures), Message: message},
+    }
+    if m := t.Meta; m != nil{
+      tx.Meta = &jito_pb.TransactionStatusMeta{
+        Fee:                  m.Fee,
+        PreBalances:          m.PreBalances,
+        PostBalances:         m.PostBalances,
+        LogMessages:          m.LogMessages,
+        LogMessagesNone:      m.LogMessages == nil,
+        ComputeUnitsConsumed: m.ComputeUnitsConsumed,
+      }
+      if len(m.Err) > 0 && string(m.Err) != "null"{
+        tx.Meta.Err = &jito_pb.TransactionError{Err: m.Err}
+      }
+      if m.LoadedAddresses != nil{
+        tx.Meta.LoadedWritableAddresses = decodeAll(m.LoadedAddresses.Writable)
+        tx.Meta.LoadedReadonlyAddresses = decodeAll(m.LoadedAddresses.Readonly)
+      }
+    }
+    block.Transactions = append(block.Transactions, tx)
+  }
+  if decodeErr != nil{
+    return nil, fmt.Errorf("getBlock %d: %w", slot, decodeErr)
+  }
+  return block, nil
+}
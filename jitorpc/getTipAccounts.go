@@ -4,14 +4,20 @@ import(
   "encoding/json"
   "context"
   "math/rand"
+  "time"
   
   "github.com/scatkit/pumpdexer/programs/system"
   "github.com/scatkit/pumpdexer/solana"
   "github.com/scatkit/gojito/jitorpc/jsonrpc"
+  "github.com/scatkit/gojito/metrics"
 )
 
  
-func (cl *JitoClient) GetTipAccounts(ctx context.Context) ([]string, error){
+func (cl *JitoClient) GetTipAccounts(ctx context.Context) (tipAccounts []string, err error){
+  start := time.Now()
+  defer func(){
+    cl.metrics.TipAccountsFetched(metrics.OutcomeOf(err, metrics.OutcomeSuccess), time.Since(start))
+  }()
   
   payload := &jsonrpc.RPCPayload{
     Method: "getTipAccounts", 
@@ -29,7 +35,6 @@ func (cl *JitoClient) GetTipAccounts(ctx context.Context) ([]string, error){
     return nil, err
   }
   
-  var tipAccounts []string
   if err := json.Unmarshal(resp.Result, &tipAccounts); err != nil{
     return nil, fmt.Errorf("failed to unmarshal tip accpounts")
   }
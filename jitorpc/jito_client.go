@@ -2,7 +2,11 @@ package jitorpc
 import(
   //"io"
   "context"
+  "log/slog"
+  "github.com/scatkit/gojito/dedup"
   "github.com/scatkit/gojito/jitorpc/jsonrpc"
+  "github.com/scatkit/gojito/pkg"
+  "github.com/scatkit/gojito/metrics"
 )
 
 type JITORPC interface{
@@ -14,17 +18,55 @@ type JitoClient struct{
   jitoURL     string 
   jitoRPC     JITORPC    
   uuid        string
+  region      string
+  logger      *slog.Logger
+  metrics     metrics.Recorder
+  dedup       *dedup.Guard
+}
+
+type JitoClientOpts struct{
+  HTTPClient jsonrpc.HTTPClient
+  Logger     *slog.Logger // nil discards all records
+  Metrics    metrics.Recorder // nil discards all measurements
+  Dedup      *dedup.Guard     // nil sends every transaction; share it with the searcher client to catch cross-path repeats
 }
 
 func NewJito(endpoint, uuid string) *JitoClient{
-  jitoRPC := jsonrpc.NewClient(endpoint)
+  return NewJitoWithOpts(endpoint, uuid, nil)
+}
+
+// NewJitoWithOpts creates a Jito JSON-RPC client with a custom HTTP client and/or logger.
+func NewJitoWithOpts(endpoint, uuid string, opts *JitoClientOpts) *JitoClient{
+  if opts == nil{
+    opts = &JitoClientOpts{}
+  }
+  logger := opts.Logger
+  if logger == nil{
+    logger = pkg.NopLogger()
+  }
+  recorder := opts.Metrics
+  if recorder == nil{
+    recorder = metrics.Nop{}
+  }
+  
+  jitoRPC := jsonrpc.NewClientWithOpts(endpoint, &jsonrpc.RPCClientOpts{
+    HTTPClient: opts.HTTPClient,
+    Logger:     logger,
+  })
   return &JitoClient{
     jitoURL:  endpoint,
     jitoRPC:  jitoRPC,
     uuid:     uuid,
+    region:   pkg.RegionFromURL(endpoint),
+    logger:   logger,
+    metrics:  recorder,
+    dedup:    opts.Dedup,
   }
 }
 
+// Region returns the block engine region of the endpoint, empty for the global endpoint.
+func (cl *JitoClient) Region() string{ return cl.region }
+
 //func (cl *JitoClient) Close() error {
 //	if cl.jitoRPC == nil {
 //		return nil
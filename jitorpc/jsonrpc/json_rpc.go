@@ -8,8 +8,12 @@ import(
   "sync/atomic"
   "errors"
   "reflect"
+  "log/slog"
+  "time"
   
   "github.com/davecgh/go-spew/spew"
+  "github.com/scatkit/gojito/pkg"
+  "github.com/scatkit/gojito/tracing"
 )
 
 type RPCClient interface{
@@ -25,6 +29,13 @@ type HTTPClient interface{
 type rpcClient struct {
 	endpoint      string
 	httpClient    HTTPClient
+	region        string
+	logger        *slog.Logger
+}
+
+type RPCClientOpts struct {
+	HTTPClient HTTPClient
+	Logger     *slog.Logger // nil discards all records
 }
 
 type RPCPayload struct {
@@ -79,10 +90,25 @@ func (e *RPCError) Error() string {
 }
 
 func NewClient(endpoint string) RPCClient {
-	return &rpcClient{
+	return NewClientWithOpts(endpoint, nil)
+}
+
+func NewClientWithOpts(endpoint string, opts *RPCClientOpts) RPCClient {
+	client := &rpcClient{
 		endpoint:    endpoint,
-		httpClient:   &http.Client{},
+		httpClient:  tracing.HTTPClient(),
+		region:      pkg.RegionFromURL(endpoint),
+		logger:      pkg.NopLogger(),
 	}
+	if opts != nil {
+		if opts.HTTPClient != nil {
+			client.httpClient = opts.HTTPClient
+		}
+		if opts.Logger != nil {
+			client.logger = opts.Logger
+		}
+	}
+	return client
 }
 
 //func (client *rpcClient) Close() error{
@@ -181,7 +207,7 @@ func (client *rpcClient) MakeCallWithHeader(ctx context.Context, path string, RP
 	}
   
   rpcResponse.BundleID = httpResp.Header.Get("x-bundle-id")
-  fmt.Println("Bundle id",rpcResponse.BundleID)
+  client.logger.Debug("bundle id received", slog.String("method", RPCPayload.Method), slog.String("bundle_uuid", rpcResponse.BundleID))
 
 	return rpcResponse, nil
 }
@@ -202,12 +228,28 @@ func (client *rpcClient) doCallWithCallbackOnHTTPResponse(
 		}
 		return nil, fmt.Errorf("rpc call %v(): %w", RPCPayload.Method, err)
 	}
+	start := time.Now()
 	httpResponse, err := client.httpClient.Do(httpRequest)
 	if err != nil {
+		client.logger.Warn("rpc call failed",
+			slog.String("method", RPCPayload.Method),
+			slog.String("url", httpRequest.URL.String()),
+			slog.String("region", client.region),
+			slog.Duration("latency", time.Since(start)),
+			slog.Any("error", err),
+		)
 		return nil, fmt.Errorf("rpc call %v() on %v: %w", RPCPayload.Method, httpRequest.URL.String(), err)
 	}
 	defer httpResponse.Body.Close()
 
+	client.logger.Debug("rpc call",
+		slog.String("method", RPCPayload.Method),
+		slog.String("url", httpRequest.URL.String()),
+		slog.String("region", client.region),
+		slog.Int("status", httpResponse.StatusCode),
+		slog.Duration("latency", time.Since(start)),
+	)
+
 	return httpResponse, callback(httpRequest, httpResponse)
 }
 
@@ -0,0 +1,92 @@
+package jitorpc
+import(
+  "context"
+  "encoding/base64"
+  "encoding/json"
+  "fmt"
+  "log/slog"
+  "time"
+
+  "github.com/scatkit/pumpdexer/solana"
+  "github.com/scatkit/gojito/dedup"
+  "github.com/scatkit/gojito/jitorpc/jsonrpc"
+  "github.com/scatkit/gojito/metrics"
+  "github.com/scatkit/gojito/pb"
+  "github.com/scatkit/gojito/pkg"
+  "github.com/scatkit/gojito/tracing"
+)
+
+// SendBundle sends up to 5 signed transactions as a bundle and returns its bundle ID.
+func (cl *JitoClient) SendBundle(ctx context.Context, transactions []*solana.Transaction) (bundleID string, err error){
+  ctx, span := tracing.Start(ctx, "jito.send_bundle",
+    tracing.RegionKey.String(cl.region),
+    tracing.SignaturesKey.StringSlice(pkg.SignatureStrings(pkg.BatchExtractSigFromTx(transactions))),
+  )
+  defer func(){
+    span.SetAttributes(tracing.BundleUUIDKey.String(bundleID))
+    tracing.End(span, err)
+  }()
+
+  // keyed like the searcher client's bundles, so a guard shared by both catches a bundle sent through each
+  bundle := &jito_pb.Bundle{Packets: make([]*jito_pb.Packet, 0, len(transactions))}
+  for i, tx := range transactions{
+    packet, err := pkg.ConvertTransactionToProtobufPacket(tx)
+    if err != nil{
+      return "", fmt.Errorf("%d: error converting tx to jito_pb packet [%w]", i, err)
+    }
+    bundle.Packets = append(bundle.Packets, &packet)
+  }
+  return dedup.Do(ctx, cl.dedup, metrics.KindBundle, dedup.BundleKeys(bundle, transactions), func() (string, error){
+    return cl.sendBundle(ctx, bundle, transactions)
+  })
+}
+
+func (cl *JitoClient) sendBundle(ctx context.Context, bundle *jito_pb.Bundle, transactions []*solana.Transaction) (string, error){
+  encoded := make([]string, 0, len(bundle.Packets))
+  for _, packet := range bundle.Packets{
+    encoded = append(encoded, base64.StdEncoding.EncodeToString(packet.Data))
+  }
+
+  payload := &jsonrpc.RPCPayload{
+    JSONRPC: "2.0",
+    Method: "sendBundle",
+    Params: []interface{}{encoded, map[string]string{"encoding": "base64"}},
+  }
+
+  path := "/api/v1/bundles"
+  if cl.uuid != ""{
+    path = fmt.Sprintf("%s?uuid=%s", path, cl.uuid)
+  }
+
+  start := time.Now()
+  resp, err := cl.jitoRPC.MakeCall(ctx, path, payload)
+  if err == nil && resp.Error != nil{
+    err = resp.Error
+  }
+  cl.metrics.Submitted(metrics.KindBundle, metrics.OutcomeOf(err, metrics.OutcomeAccepted), time.Since(start))
+  signatures := pkg.SignatureStrings(pkg.BatchExtractSigFromTx(transactions))
+  if err != nil{
+    cl.logger.Warn("send bundle failed",
+      slog.String("method", payload.Method),
+      slog.String("region", cl.region),
+      slog.Any("signatures", signatures),
+      slog.Duration("latency", time.Since(start)),
+      slog.Any("error", err),
+    )
+    return "", err
+  }
+
+  var bundleID string
+  if err = json.Unmarshal(resp.Result, &bundleID); err != nil{
+    return "", err
+  }
+
+  cl.logger.Info("bundle sent",
+    slog.String("method", payload.Method),
+    slog.String("region", cl.region),
+    slog.String("bundle_uuid", bundleID),
+    slog.Any("signatures", signatures),
+    slog.Duration("latency", time.Since(start)),
+  )
+  return bundleID, nil
+}
@@ -5,11 +5,14 @@ import(
   "encoding/json"
   "encoding/base64"
   "strings"
+  "log/slog"
+  "time"
   
   "github.com/scatkit/pumpdexer/solana"
+  "github.com/scatkit/gojito/dedup"
   "github.com/scatkit/gojito/jitorpc/jsonrpc"
-  "github.com/davecgh/go-spew/spew"
-
+  "github.com/scatkit/gojito/metrics"
+  "github.com/scatkit/gojito/tracing"
 )
 
 //type TransactionResponse struct{
@@ -19,12 +22,40 @@ import(
 //	BundleID string
 //}
 
+// JitoTxResponse holds the signature of a transaction sent through Jito and the ID of the bundle it was wrapped into.
+type JitoTxResponse struct{
+  txSig    solana.Signature
+  bundleID string
+}
+
+func (r *JitoTxResponse) Signature() solana.Signature{ return r.txSig }
+
+// BundleID is only populated when the transaction was sent with bundleOnly=true.
+func (r *JitoTxResponse) BundleID() string{ return r.bundleID }
+
 func (cl *JitoClient) SendTransaction(ctx context.Context, signedTx *solana.Transaction, bundleOnly bool,
-) (txSig solana.Signature, err error){
+) (out *JitoTxResponse, err error){
+  ctx, span := tracing.Start(ctx, "jito.send_transaction", tracing.RegionKey.String(cl.region))
+  defer func(){
+    if out != nil{
+      span.SetAttributes(
+        tracing.SignaturesKey.StringSlice([]string{out.txSig.String()}),
+        tracing.BundleUUIDKey.String(out.bundleID),
+      )
+    }
+    tracing.End(span, err)
+  }()
+  
+  return dedup.Do(ctx, cl.dedup, metrics.KindTransaction, []string{dedup.SignatureKey(signedTx)}, func() (*JitoTxResponse, error){
+    return cl.sendTransaction(ctx, signedTx, bundleOnly)
+  })
+}
+
+func (cl *JitoClient) sendTransaction(ctx context.Context, signedTx *solana.Transaction, bundleOnly bool) (*JitoTxResponse, error){
   // TO-DO: this is Legacy
   encodedTx, err := signedTx.MarshalBinary()
   if err != nil{
-    return solana.Signature{}, err
+    return nil, err
   }
   
   base64Transaction := base64.StdEncoding.EncodeToString(encodedTx)
@@ -50,11 +81,28 @@ func (cl *JitoClient) SendTransaction(ctx context.Context, signedTx *solana.Tran
 		path = fmt.Sprintf("%s?%s", path, strings.Join(queryParams, "&"))
 	}
   
+  start := time.Now()
   resp, err := cl.jitoRPC.MakeCallWithHeader(ctx, path, payload)
-  spew.Dump(resp)
-  err = json.Unmarshal(resp.Result, &txSig)
-  BundleID := resp.BundleID 
-  fmt.Println(BundleID)
+  if err == nil && resp.Error != nil{
+    err = resp.Error
+  }
+  cl.metrics.Submitted(metrics.KindTransaction, metrics.OutcomeOf(err, metrics.OutcomeAccepted), time.Since(start))
+  if err != nil{
+    return nil, err
+  }
+  
+  out := &JitoTxResponse{bundleID: resp.BundleID}
+  if err = json.Unmarshal(resp.Result, &out.txSig); err != nil{
+    return nil, err
+  }
   
-  return txSig, err
+  cl.logger.Info("transaction sent",
+    slog.String("method", payload.Method),
+    slog.String("region", cl.region),
+    slog.Any("signatures", []string{out.txSig.String()}),
+    slog.String("bundle_uuid", out.bundleID),
+    slog.Bool("bundle_only", bundleOnly),
+    slog.Duration("latency", time.Since(start)),
+  )
+  return out, nil
 }
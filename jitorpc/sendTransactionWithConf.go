@@ -1,10 +1,13 @@
 package jitorpc
 import(
-  "fmt"
   "context"
   "time"
   "errors"
+  "log/slog"
+  "github.com/scatkit/gojito/metrics"
+  "github.com/scatkit/gojito/tracing"
   "github.com/scatkit/pumpdexer/solana"
+  "go.opentelemetry.io/otel/trace"
   //"github.com/scatkit/pumpdexer/rpc"
   //"github.com/davecgh/go-spew/spew"
   //"github.com/scatkit/gojito/pkg"
@@ -16,11 +19,18 @@ type ConfirmedTxAsBundleResponse struct{
 
 func (cl *JitoClient) SendTransactionWithConf(ctx context.Context, signedTx *solana.Transaction,
 ) (out *JitoTxResponse, err error){
+  ctx, span := tracing.Start(ctx, "jito.bundle", tracing.RegionKey.String(cl.region))
+  defer func(){ tracing.End(span, err) }()
+  
   // Should i send different ctx???
+  submittedAt := time.Now()
   bundleTxResp, err := cl.SendTransaction(ctx, signedTx, true)
   if err != nil{
     return nil, err
   }
+  cl.metrics.InFlight(1)
+  defer cl.metrics.InFlight(-1)
+  span.SetAttributes(tracing.BundleUUIDKey.String(bundleTxResp.bundleID))
   
   //bundleSignatures := pkg.BatchExtractSigFromTx(signedTx)
   
@@ -39,23 +49,31 @@ func (cl *JitoClient) SendTransactionWithConf(ctx context.Context, signedTx *sol
         return bundleTxResp, err
       }
       
-      for i, value := range bundleStatuses.Value{
+      for _, value := range bundleStatuses.Value{
         if value.BundleId == bundleTxResp.bundleID{
+          logger := cl.logger.With(
+            slog.String("bundle_uuid", bundleTxResp.bundleID),
+            slog.Any("signatures", []string{bundleTxResp.txSig.String()}),
+            slog.String("region", cl.region),
+            slog.Int("attempt", attempt),
+            slog.String("status", value.Status),
+          )
           switch value.Status{
           case "Invalid":
-            fmt.Printf("bundle %d is invalid: %s\n",i,bundleTxResp.bundleID)
+            logger.Debug("bundle is invalid")
           case "Pending":
-            fmt.Printf("bundle %d is pending: %s\n", i, bundleTxResp.bundleID)
+            logger.Debug("bundle is pending")
           case "Failed":
-            fmt.Printf("bundle %d failed to land: %s\n", i, bundleTxResp.bundleID)
+            logger.Warn("bundle failed to land")
           case "Landed":
-            fmt.Printf("bundle %d has landed: %s\n", i, bundleTxResp.bundleID)
-            solScan := fmt.Sprintf("https://solscan.io/tx/%s", bundleTxResp.txSig)
-            fmt.Println(solScan)
+            cl.metrics.BundleResult(metrics.OutcomeLanded, "")
+            cl.metrics.Landed(time.Since(submittedAt))
+            span.AddEvent("landed", trace.WithAttributes(tracing.SlotKey.Int64(int64(value.LandedSlot))))
+            logger.Info("bundle has landed", slog.Uint64("landed_slot", value.LandedSlot))
             return bundleTxResp, nil
 
           default:
-            fmt.Printf("bundle %d unknown error: %s\n", i, bundleTxResp.bundleID)
+            logger.Warn("bundle status unknown")
           }
         }
       }
@@ -0,0 +1,120 @@
+package jitotest
+import(
+  "context"
+  "crypto/ed25519"
+  "crypto/rand"
+  "encoding/hex"
+  "fmt"
+  "strings"
+  "time"
+
+  "github.com/mr-tron/base58"
+  "github.com/scatkit/gojito/pb"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/metadata"
+  "google.golang.org/grpc/status"
+  "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (be *BlockEngine) GenerateAuthChallenge(_ context.Context, req *jito_pb.GenerateAuthChallengeRequest) (*jito_pb.GenerateAuthChallengeResponse, error){
+  if len(req.GetPubkey()) != ed25519.PublicKeySize{
+    return nil, status.Error(codes.InvalidArgument, "pubkey must be 32 bytes")
+  }
+  challenge := randomToken(9)
+
+  be.mu.Lock()
+  be.challenges[base58.Encode(req.Pubkey)] = challenge
+  be.mu.Unlock()
+  return &jito_pb.GenerateAuthChallengeResponse{Challenge: challenge}, nil
+}
+
+// GenerateAuthTokens verifies sign("<pubkey>-<challenge>") like the real auth service.
+func (be *BlockEngine) GenerateAuthTokens(_ context.Context, req *jito_pb.GenerateAuthTokensRequest) (*jito_pb.GenerateAuthTokensResponse, error){
+  pubkey := base58.Encode(req.GetClientPubkey())
+
+  be.mu.Lock()
+  challenge, ok := be.challenges[pubkey]
+  delete(be.challenges, pubkey)
+  be.mu.Unlock()
+
+  if !ok || req.GetChallenge() != fmt.Sprintf("%s-%s", pubkey, challenge){
+    return nil, status.Error(codes.PermissionDenied, "unknown challenge")
+  }
+  if !ed25519.Verify(req.ClientPubkey, []byte(req.Challenge), req.GetSignedChallenge()){
+    return nil, status.Error(codes.PermissionDenied, "invalid challenge signature")
+  }
+
+  return &jito_pb.GenerateAuthTokensResponse{
+    AccessToken:  be.issue(be.access, be.AccessTTL),
+    RefreshToken: be.issue(be.refresh, be.RefreshTTL),
+  }, nil
+}
+
+func (be *BlockEngine) RefreshAccessToken(_ context.Context, req *jito_pb.RefreshAccessTokenRequest) (*jito_pb.RefreshAccessTokenResponse, error){
+  if !be.valid(be.refresh, req.GetRefreshToken()){
+    return nil, status.Error(codes.Unauthenticated, "refresh token expired or unknown")
+  }
+  return &jito_pb.RefreshAccessTokenResponse{AccessToken: be.issue(be.access, be.AccessTTL)}, nil
+}
+
+// ExpireTokens invalidates every issued access token, forcing clients to refresh.
+func (be *BlockEngine) ExpireTokens(){
+  be.mu.Lock()
+  defer be.mu.Unlock()
+  for token := range be.access{
+    be.access[token] = time.Now()
+  }
+}
+
+func (be *BlockEngine) issue(store map[string]time.Time, ttl time.Duration) *jito_pb.Token{
+  value := randomToken(32)
+  expiresAt := time.Now().Add(ttl)
+
+  be.mu.Lock()
+  store[value] = expiresAt
+  be.mu.Unlock()
+  return &jito_pb.Token{Value: value, ExpiresAtUtc: timestamppb.New(expiresAt)}
+}
+
+func (be *BlockEngine) valid(store map[string]time.Time, token string) bool{
+  be.mu.Lock()
+  defer be.mu.Unlock()
+  expiresAt, ok := store[token]
+  return ok && time.Now().Before(expiresAt)
+}
+
+// authorize checks the bearer token of searcher calls when RequireAuth is set.
+func (be *BlockEngine) authorize(ctx context.Context, method string) error{
+  if !be.RequireAuth || strings.HasPrefix(method, "/auth."){
+    return nil
+  }
+  md, _ := metadata.FromIncomingContext(ctx)
+  values := md.Get("authorization")
+  if len(values) == 0 || !be.valid(be.access, strings.TrimPrefix(values[0], "Bearer ")){
+    return status.Error(codes.Unauthenticated, "missing or expired access token")
+  }
+  return nil
+}
+
+func (be *BlockEngine) unaryAuthInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error){
+  be.delay(ctx)
+  if err := be.authorize(ctx, info.FullMethod); err != nil{
+    return nil, err
+  }
+  return handler(ctx, req)
+}
+
+func (be *BlockEngine) streamAuthInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error{
+  if err := be.authorize(ss.Context(), info.FullMethod); err != nil{
+    return err
+  }
+  return handler(srv, ss)
+}
+
+func randomToken(n int) string{
+  b := make([]byte, n)
+  rand.Read(b)
+  return hex.EncodeToString(b)
+}
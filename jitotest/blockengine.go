@@ -0,0 +1,476 @@
+// Package jitotest provides an in-process, scriptable fake of a Jito block engine for offline testing.
+// The gRPC services (auth + searcher) are served over bufconn and the JSON-RPC API over httptest.
+package jitotest
+import(
+  "context"
+  "crypto/sha256"
+  "crypto/tls"
+  "encoding/hex"
+  "errors"
+  "fmt"
+  "net"
+  "sync"
+  "time"
+
+  "github.com/mr-tron/base58"
+  "github.com/scatkit/gojito/pb"
+  "github.com/scatkit/gojito/server"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/credentials"
+  "google.golang.org/grpc/status"
+  "google.golang.org/grpc/test/bufconn"
+)
+
+const bufSize = 1 << 20
+
+// DefaultTipAccounts are the mainnet tip accounts, returned unless overridden with WithTipAccounts.
+var DefaultTipAccounts = server.DefaultTipAccounts
+
+// ResultScript returns the sequence of results the block engine streams on SubscribeBundleResults for a bundle.
+// BundleId and zero Accepted/Processed slots are filled in by the server.
+type ResultScript func(uuid string, bundle *jito_pb.Bundle) []*jito_pb.BundleResult
+
+// RecordedBundle is a bundle received by the fake, either via gRPC SendBundle or JSON-RPC sendBundle/sendTransaction.
+type RecordedBundle struct{
+  UUID       string
+  Signatures []string // base58
+  Packets    [][]byte // raw wire transactions
+  ReceivedAt time.Time
+}
+
+// BlockEngine is a fake block engine implementing the auth and searcher gRPC services.
+type BlockEngine struct{
+  jito_pb.UnimplementedAuthServiceServer
+  jito_pb.UnimplementedSearcherServiceServer
+
+  Region      string
+  Regions     []string
+  TipAccounts []string // see SetTipAccounts once started
+  NextLeader  *jito_pb.NextScheduledLeaderResponse
+  Leaders     map[string]*jito_pb.SlotList // validator identity => leader slots for the current epoch
+  Slot        uint64 // reported in Accepted/Processed results and JSON-RPC contexts
+
+  AccessTTL   time.Duration
+  RefreshTTL  time.Duration
+  RequireAuth bool // rejects searcher calls without a valid access token
+  Script      ResultScript
+  ResultDelay time.Duration // delay between scripted results
+  Latency     time.Duration // injected before answering unary gRPC calls and JSON-RPC requests, see SetLatency
+
+  mu          sync.Mutex
+  challenges  map[string]string // base58 pubkey => challenge
+  access      map[string]time.Time
+  refresh     map[string]time.Time
+  bundles     []RecordedBundle
+  statuses    map[string]*bundleStatus
+  subscribers map[*subscriber]struct{}
+
+  stop        chan struct{} // closed by Close, unblocks Emit
+  closeOnce   sync.Once
+  listener    *bufconn.Listener
+  server      *grpc.Server
+  tlsConfig   *tls.Config
+}
+
+// subscriber is a SubscribeBundleResults stream.
+type subscriber struct{
+  results chan *jito_pb.BundleResult
+  done    chan struct{} // closed once the stream ended
+}
+
+type bundleStatus struct{
+  status     string // Invalid, Pending, Failed or Landed
+  landedSlot uint64
+  finalized  bool
+}
+
+type Option func(*BlockEngine)
+
+func WithRegion(region string, regions ...string) Option{
+  return func(be *BlockEngine){
+    be.Region = region
+    be.Regions = regions
+  }
+}
+
+func WithTipAccounts(accounts ...string) Option{
+  return func(be *BlockEngine){ be.TipAccounts = accounts }
+}
+
+func WithLeaders(next *jito_pb.NextScheduledLeaderResponse, leaders map[string]*jito_pb.SlotList) Option{
+  return func(be *BlockEngine){
+    be.NextLeader = next
+    be.Leaders = leaders
+  }
+}
+
+// WithTokenExpiry sets the TTL of issued access and refresh tokens.
+func WithTokenExpiry(access, refresh time.Duration) Option{
+  return func(be *BlockEngine){
+    be.AccessTTL = access
+    be.RefreshTTL = refresh
+  }
+}
+
+func WithRequireAuth() Option{
+  return func(be *BlockEngine){ be.RequireAuth = true }
+}
+
+// WithLatency delays every answer, e.g. to simulate a distant region.
+func WithLatency(latency time.Duration) Option{
+  return func(be *BlockEngine){ be.Latency = latency }
+}
+
+func WithScript(script ResultScript, delay time.Duration) Option{
+  return func(be *BlockEngine){
+    be.Script = script
+    be.ResultDelay = delay
+  }
+}
+
+// NewBlockEngine creates a fake block engine. Call Start before dialing it.
+func NewBlockEngine(opts ...Option) *BlockEngine{
+  be := &BlockEngine{
+    Region:      "ny",
+    Regions:     []string{"amsterdam", "frankfurt", "ny", "tokyo", "slc"},
+    TipAccounts: DefaultTipAccounts,
+    Slot:        1000,
+    AccessTTL:   30 * time.Minute,
+    RefreshTTL:  24 * time.Hour,
+    Script:      AcceptAndLand,
+    challenges:  make(map[string]string),
+    access:      make(map[string]time.Time),
+    refresh:     make(map[string]time.Time),
+    statuses:    make(map[string]*bundleStatus),
+    subscribers: make(map[*subscriber]struct{}),
+    stop:        make(chan struct{}),
+  }
+  for _, opt := range opts{
+    opt(be)
+  }
+  if be.NextLeader == nil{
+    be.NextLeader = &jito_pb.NextScheduledLeaderResponse{
+      CurrentSlot:        be.Slot,
+      NextLeaderSlot:     be.Slot + 4,
+      NextLeaderIdentity: "J1to1yufRnoWn81KYg1XkTWzmKjnYSnmE2VY8DGUJ9Qv",
+      NextLeaderRegion:   be.Region,
+    }
+  }
+  return be
+}
+
+// Start serves the auth and searcher services over an in-memory listener with a self-signed TLS certificate.
+func (be *BlockEngine) Start() error{
+  cert, tlsConfig, err := server.SelfSignedTLS("bufnet")
+  if err != nil{
+    return fmt.Errorf("failed to generate TLS certificate: %w", err)
+  }
+  be.tlsConfig = tlsConfig
+  be.listener = bufconn.Listen(bufSize)
+  be.server = grpc.NewServer(
+    grpc.Creds(credentials.NewServerTLSFromCert(&cert)),
+    grpc.UnaryInterceptor(be.unaryAuthInterceptor),
+    grpc.StreamInterceptor(be.streamAuthInterceptor),
+  )
+  jito_pb.RegisterAuthServiceServer(be.server, be)
+  jito_pb.RegisterSearcherServiceServer(be.server, be)
+
+  go be.server.Serve(be.listener)
+  return nil
+}
+
+func (be *BlockEngine) Close(){
+  be.closeOnce.Do(func(){ close(be.stop) })
+  if be.server != nil{
+    be.server.Stop()
+  }
+}
+
+// Target is the dial target to pass to the searcher client constructors together with DialOptions.
+func (be *BlockEngine) Target() string{
+  return "passthrough:///bufnet"
+}
+
+// DialOptions route connections to the in-memory listener.
+func (be *BlockEngine) DialOptions() []grpc.DialOption{
+  return []grpc.DialOption{
+    grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error){
+      return be.listener.DialContext(ctx)
+    }),
+  }
+}
+
+// TLSConfig trusts the fake's self-signed certificate.
+func (be *BlockEngine) TLSConfig() *tls.Config{
+  return be.tlsConfig.Clone()
+}
+
+// SetLatency changes the latency injected into answers.
+func (be *BlockEngine) SetLatency(latency time.Duration){
+  be.mu.Lock()
+  defer be.mu.Unlock()
+  be.Latency = latency
+}
+
+// delay waits for the injected latency, or until ctx is done.
+func (be *BlockEngine) delay(ctx context.Context){
+  be.mu.Lock()
+  latency := be.Latency
+  be.mu.Unlock()
+  if latency <= 0{
+    return
+  }
+  select{
+  case <-ctx.Done():
+  case <-time.After(latency):
+  }
+}
+
+// Bundles returns every bundle received so far, in arrival order.
+func (be *BlockEngine) Bundles() []RecordedBundle{
+  be.mu.Lock()
+  defer be.mu.Unlock()
+  return append([]RecordedBundle(nil), be.bundles...)
+}
+
+// Emit pushes a result to every SubscribeBundleResults stream and updates the bundle's JSON-RPC status.
+// It blocks until every stream took the result or ended, or the fake was closed, so that tests are deterministic.
+func (be *BlockEngine) Emit(result *jito_pb.BundleResult){
+  be.mu.Lock()
+  be.applyResultLocked(result)
+  subs := make([]*subscriber, 0, len(be.subscribers))
+  for sub := range be.subscribers{
+    subs = append(subs, sub)
+  }
+  be.mu.Unlock()
+
+  for _, sub := range subs{
+    select{
+    case sub.results <- result:
+    case <-sub.done:
+    case <-be.stop:
+    }
+  }
+}
+
+// Subscribers returns the number of open SubscribeBundleResults streams. Results emitted before a client's stream
+// is open don't reach it, so tests wait for its subscription first.
+func (be *BlockEngine) Subscribers() int{
+  be.mu.Lock()
+  defer be.mu.Unlock()
+  return len(be.subscribers)
+}
+
+// SetTipAccounts changes the tip accounts returned by GetTipAccounts and getTipAccounts.
+func (be *BlockEngine) SetTipAccounts(accounts ...string){
+  be.mu.Lock()
+  defer be.mu.Unlock()
+  be.TipAccounts = accounts
+}
+
+func (be *BlockEngine) tipAccounts() []string{
+  be.mu.Lock()
+  defer be.mu.Unlock()
+  return be.TipAccounts
+}
+
+// SetBundleStatus overrides the status reported by getInflightBundleStatuses for a bundle.
+func (be *BlockEngine) SetBundleStatus(uuid, status string, landedSlot uint64){
+  be.mu.Lock()
+  defer be.mu.Unlock()
+  be.statuses[uuid] = &bundleStatus{status: status, landedSlot: landedSlot}
+}
+
+func (be *BlockEngine) applyResultLocked(result *jito_pb.BundleResult){
+  st, ok := be.statuses[result.BundleId]
+  if !ok{
+    return
+  }
+  switch r := result.Result.(type){
+  case *jito_pb.BundleResult_Processed:
+    st.status, st.landedSlot = "Landed", r.Processed.Slot
+  case *jito_pb.BundleResult_Finalized:
+    st.status, st.finalized = "Landed", true
+  case *jito_pb.BundleResult_Rejected, *jito_pb.BundleResult_Dropped:
+    st.status = "Failed"
+  }
+}
+
+// record stores a bundle and schedules its scripted results.
+func (be *BlockEngine) record(packets [][]byte, bundle *jito_pb.Bundle) (string, error){
+  if len(packets) == 0 || len(packets) > 5{
+    return "", fmt.Errorf("bundle must contain between 1 and 5 transactions, got %d", len(packets))
+  }
+  sigs := make([]string, 0, len(packets))
+  hash := sha256.New()
+  for _, data := range packets{
+    sig, err := firstSignature(data)
+    if err != nil{
+      return "", err
+    }
+    hash.Write(sig)
+    sigs = append(sigs, base58.Encode(sig))
+  }
+  uuid := hex.EncodeToString(hash.Sum(nil))
+
+  be.mu.Lock()
+  be.bundles = append(be.bundles, RecordedBundle{UUID: uuid, Signatures: sigs, Packets: packets, ReceivedAt: time.Now()})
+  be.statuses[uuid] = &bundleStatus{status: "Pending"}
+  script, delay := be.Script, be.ResultDelay
+  be.mu.Unlock()
+
+  if script != nil{
+    results := script(uuid, bundle)
+    go func(){
+      for _, result := range results{
+        if result.BundleId == ""{
+          result.BundleId = uuid
+        }
+        be.fillSlot(result)
+        time.Sleep(delay)
+        be.Emit(result)
+      }
+    }()
+  }
+  return uuid, nil
+}
+
+// fillSlot stamps the current slot on Accepted/Processed results that were scripted without one.
+func (be *BlockEngine) fillSlot(result *jito_pb.BundleResult){
+  be.mu.Lock()
+  slot := be.Slot
+  be.mu.Unlock()
+  switch r := result.Result.(type){
+  case *jito_pb.BundleResult_Accepted:
+    if r.Accepted.Slot == 0{
+      r.Accepted.Slot = slot
+    }
+  case *jito_pb.BundleResult_Processed:
+    if r.Processed.Slot == 0{
+      r.Processed.Slot = slot
+    }
+  }
+}
+
+// firstSignature returns the first signature of a serialized transaction.
+func firstSignature(data []byte) ([]byte, error){
+  // compact-u16 signature count
+  var count, shift, n int
+  for n < len(data) && n < 3{
+    b := data[n]
+    count |= int(b&0x7f) << shift
+    n++
+    if b&0x80 == 0{
+      break
+    }
+    shift += 7
+  }
+  if count == 0 || len(data) < n+64{
+    return nil, errors.New("transaction has no signatures")
+  }
+  return data[n : n+64], nil
+}
+
+// AcceptAndLand is the default script: the bundle is accepted, processed and finalized.
+func AcceptAndLand(_ string, _ *jito_pb.Bundle) []*jito_pb.BundleResult{
+  return []*jito_pb.BundleResult{
+    {Result: &jito_pb.BundleResult_Accepted{Accepted: &jito_pb.Accepted{ValidatorIdentity: "J1to1yufRnoWn81KYg1XkTWzmKjnYSnmE2VY8DGUJ9Qv"}}},
+    {Result: &jito_pb.BundleResult_Processed{Processed: &jito_pb.Processed{ValidatorIdentity: "J1to1yufRnoWn81KYg1XkTWzmKjnYSnmE2VY8DGUJ9Qv"}}},
+    {Result: &jito_pb.BundleResult_Finalized{Finalized: &jito_pb.Finalized{}}},
+  }
+}
+
+// Reject scripts a bundle to be rejected for losing the state auction.
+func Reject(msg string) ResultScript{
+  return func(_ string, _ *jito_pb.Bundle) []*jito_pb.BundleResult{
+    return []*jito_pb.BundleResult{{
+      Result: &jito_pb.BundleResult_Rejected{Rejected: &jito_pb.Rejected{
+        Reason: &jito_pb.Rejected_StateAuctionBidRejected{StateAuctionBidRejected: &jito_pb.StateAuctionBidRejected{Msg: &msg}},
+      }},
+    }}
+  }
+}
+
+// Drop scripts a bundle to be accepted and then dropped.
+func Drop(reason jito_pb.DroppedReason) ResultScript{
+  return func(_ string, _ *jito_pb.Bundle) []*jito_pb.BundleResult{
+    return []*jito_pb.BundleResult{
+      {Result: &jito_pb.BundleResult_Accepted{Accepted: &jito_pb.Accepted{}}},
+      {Result: &jito_pb.BundleResult_Dropped{Dropped: &jito_pb.Dropped{Reason: reason}}},
+    }
+  }
+}
+
+// Silent never emits results, simulating a bundle lost in flight.
+func Silent(_ string, _ *jito_pb.Bundle) []*jito_pb.BundleResult{ return nil }
+
+// Searcher service
+
+func (be *BlockEngine) SendBundle(_ context.Context, req *jito_pb.SendBundleRequest) (*jito_pb.SendBundleResponse, error){
+  if req.GetBundle() == nil{
+    return nil, status.Error(codes.InvalidArgument, "missing bundle")
+  }
+  packets := make([][]byte, 0, len(req.Bundle.Packets))
+  for _, p := range req.Bundle.Packets{
+    packets = append(packets, p.GetData())
+  }
+  uuid, err := be.record(packets, req.Bundle)
+  if err != nil{
+    return nil, status.Error(codes.InvalidArgument, err.Error())
+  }
+  return &jito_pb.SendBundleResponse{Uuid: uuid}, nil
+}
+
+func (be *BlockEngine) SubscribeBundleResults(_ *jito_pb.SubscribeBundleResultsRequest, stream jito_pb.SearcherService_SubscribeBundleResultsServer) error{
+  sub := &subscriber{results: make(chan *jito_pb.BundleResult, 64), done: make(chan struct{})}
+  be.mu.Lock()
+  be.subscribers[sub] = struct{}{}
+  be.mu.Unlock()
+  defer func(){
+    close(sub.done)
+    be.mu.Lock()
+    delete(be.subscribers, sub)
+    be.mu.Unlock()
+  }()
+
+  for{
+    select{
+    case <-stream.Context().Done():
+      return nil
+    case result := <-sub.results:
+      if err := stream.Send(result); err != nil{
+        return err
+      }
+    }
+  }
+}
+
+func (be *BlockEngine) GetNextScheduledLeader(context.Context, *jito_pb.NextScheduledLeaderRequest) (*jito_pb.NextScheduledLeaderResponse, error){
+  return be.NextLeader, nil
+}
+
+func (be *BlockEngine) GetConnectedLeaders(context.Context, *jito_pb.ConnectedLeadersRequest) (*jito_pb.ConnectedLeadersResponse, error){
+  return &jito_pb.ConnectedLeadersResponse{ConnectedValidators: be.Leaders}, nil
+}
+
+func (be *BlockEngine) GetConnectedLeadersRegioned(_ context.Context, req *jito_pb.ConnectedLeadersRegionedRequest) (*jito_pb.ConnectedLeadersRegionedResponse, error){
+  regions := req.GetRegions()
+  if len(regions) == 0{
+    regions = []string{be.Region}
+  }
+  out := &jito_pb.ConnectedLeadersRegionedResponse{ConnectedValidators: make(map[string]*jito_pb.ConnectedLeadersResponse)}
+  for _, region := range regions{
+    out.ConnectedValidators[region] = &jito_pb.ConnectedLeadersResponse{ConnectedValidators: be.Leaders}
+  }
+  return out, nil
+}
+
+func (be *BlockEngine) GetTipAccounts(context.Context, *jito_pb.GetTipAccountsRequest) (*jito_pb.GetTipAccountsResponse, error){
+  return &jito_pb.GetTipAccountsResponse{Accounts: be.tipAccounts()}, nil
+}
+
+func (be *BlockEngine) GetRegions(context.Context, *jito_pb.GetRegionsRequest) (*jito_pb.GetRegionsResponse, error){
+  return &jito_pb.GetRegionsResponse{CurrentRegion: be.Region, AvailableRegions: be.Regions}, nil
+}
@@ -0,0 +1,260 @@
+package jitotest
+import(
+  "encoding/base64"
+  "encoding/json"
+  "fmt"
+  "net/http"
+  "net/http/httptest"
+
+  "github.com/mr-tron/base58"
+)
+
+type rpcRequest struct{
+  JSONRPC string            `json:"jsonrpc"`
+  Method  string            `json:"method"`
+  Params  []json.RawMessage `json:"params"`
+  ID      any               `json:"id"`
+}
+
+type rpcError struct{
+  Code    int    `json:"code"`
+  Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string{ return e.Message }
+
+type rpcContext struct{
+  Slot uint64 `json:"slot"`
+}
+
+type encodingConfig struct{
+  Encoding string `json:"encoding"`
+}
+
+// HTTPHandler serves the block engine JSON-RPC API (/api/v1/bundles and /api/v1/transactions)
+// backed by the same state as the gRPC services.
+func (be *BlockEngine) HTTPHandler() http.Handler{
+  mux := http.NewServeMux()
+  mux.HandleFunc("/api/v1/bundles", be.serveJSONRPC)
+  mux.HandleFunc("/api/v1/transactions", be.serveJSONRPC)
+  return mux
+}
+
+// NewHTTPServer starts an httptest server for HTTPHandler. Pass its URL to jitorpc.NewJito.
+func (be *BlockEngine) NewHTTPServer() *httptest.Server{
+  return httptest.NewServer(be.HTTPHandler())
+}
+
+func (be *BlockEngine) serveJSONRPC(w http.ResponseWriter, r *http.Request){
+  be.delay(r.Context())
+  var req rpcRequest
+  if err := json.NewDecoder(r.Body).Decode(&req); err != nil{
+    writeRPC(w, nil, nil, &rpcError{Code: -32700, Message: "parse error"})
+    return
+  }
+
+  var (
+    result any
+    err    error
+  )
+  switch req.Method{
+  case "sendBundle":
+    result, err = be.rpcSendBundle(req.Params)
+  case "sendTransaction":
+    var uuid string
+    result, uuid, err = be.rpcSendTransaction(req.Params, r.URL.Query().Get("bundleOnly") == "true")
+    if uuid != ""{
+      w.Header().Set("x-bundle-id", uuid)
+    }
+  case "getTipAccounts":
+    result = be.tipAccounts()
+  case "getBundleStatuses":
+    result, err = be.rpcBundleStatuses(req.Params)
+  case "getInflightBundleStatuses":
+    result, err = be.rpcInflightBundleStatuses(req.Params)
+  default:
+    writeRPC(w, req.ID, nil, &rpcError{Code: -32601, Message: fmt.Sprintf("method %q not found", req.Method)})
+    return
+  }
+  if err != nil{
+    writeRPC(w, req.ID, nil, &rpcError{Code: -32602, Message: err.Error()})
+    return
+  }
+  writeRPC(w, req.ID, result, nil)
+}
+
+func writeRPC(w http.ResponseWriter, id, result any, rpcErr *rpcError){
+  resp := map[string]any{"jsonrpc": "2.0", "id": id}
+  if rpcErr != nil{
+    resp["error"] = rpcErr
+  } else{
+    resp["result"] = result
+  }
+  w.Header().Set("Content-Type", "application/json")
+  json.NewEncoder(w).Encode(resp)
+}
+
+// decodeTransactions decodes base58 (default) or base64 encoded wire transactions.
+func decodeTransactions(encoded []string, config json.RawMessage) ([][]byte, error){
+  var conf encodingConfig
+  if len(config) > 0{
+    if err := json.Unmarshal(config, &conf); err != nil{
+      return nil, err
+    }
+  }
+  out := make([][]byte, 0, len(encoded))
+  for _, tx := range encoded{
+    var (
+      data []byte
+      err  error
+    )
+    switch conf.Encoding{
+    case "base64":
+      data, err = base64.StdEncoding.DecodeString(tx)
+    case "", "base58":
+      data, err = base58.Decode(tx)
+    default:
+      return nil, fmt.Errorf("unsupported encoding %q", conf.Encoding)
+    }
+    if err != nil{
+      return nil, fmt.Errorf("failed to decode transaction: %w", err)
+    }
+    out = append(out, data)
+  }
+  return out, nil
+}
+
+func (be *BlockEngine) rpcSendBundle(params []json.RawMessage) (string, error){
+  if len(params) == 0{
+    return "", fmt.Errorf("missing transactions")
+  }
+  var encoded []string
+  if err := json.Unmarshal(params[0], &encoded); err != nil{
+    return "", err
+  }
+  var config json.RawMessage
+  if len(params) > 1{
+    config = params[1]
+  }
+  packets, err := decodeTransactions(encoded, config)
+  if err != nil{
+    return "", err
+  }
+  return be.record(packets, nil)
+}
+
+func (be *BlockEngine) rpcSendTransaction(params []json.RawMessage, bundleOnly bool) (sig string, uuid string, err error){
+  if len(params) == 0{
+    return "", "", fmt.Errorf("missing transaction")
+  }
+  var encoded string
+  if err = json.Unmarshal(params[0], &encoded); err != nil{
+    return "", "", err
+  }
+  var config json.RawMessage
+  if len(params) > 1{
+    config = params[1]
+  }
+  packets, err := decodeTransactions([]string{encoded}, config)
+  if err != nil{
+    return "", "", err
+  }
+  first, err := firstSignature(packets[0])
+  if err != nil{
+    return "", "", err
+  }
+  if bundleOnly{
+    if uuid, err = be.record(packets, nil); err != nil{
+      return "", "", err
+    }
+  }
+  return base58.Encode(first), uuid, nil
+}
+
+func bundleIDs(params []json.RawMessage) ([]string, error){
+  if len(params) == 0{
+    return nil, fmt.Errorf("missing bundle ids")
+  }
+  var ids []string
+  if err := json.Unmarshal(params[0], &ids); err != nil{
+    return nil, err
+  }
+  if len(ids) > 5{
+    return nil, fmt.Errorf("at most 5 bundle ids can be queried at once")
+  }
+  return ids, nil
+}
+
+func (be *BlockEngine) rpcBundleStatuses(params []json.RawMessage) (any, error){
+  ids, err := bundleIDs(params)
+  if err != nil{
+    return nil, err
+  }
+
+  type bundleStatusValue struct{
+    BundleID           string            `json:"bundle_id"`
+    Transactions       []string          `json:"transactions"`
+    Slot               uint64            `json:"slot"`
+    ConfirmationStatus string            `json:"confirmation_status"`
+    Err                map[string]any    `json:"err"`
+  }
+  be.mu.Lock()
+  defer be.mu.Unlock()
+  values := make([]*bundleStatusValue, 0, len(ids))
+  for _, id := range ids{
+    st, ok := be.statuses[id]
+    if !ok || st.status != "Landed"{
+      values = append(values, nil)
+      continue
+    }
+    confirmation := "confirmed"
+    if st.finalized{
+      confirmation = "finalized"
+    }
+    values = append(values, &bundleStatusValue{
+      BundleID:           id,
+      Transactions:       be.signaturesLocked(id),
+      Slot:               st.landedSlot,
+      ConfirmationStatus: confirmation,
+      Err:                map[string]any{"Ok": nil},
+    })
+  }
+  return map[string]any{"context": rpcContext{Slot: be.Slot}, "value": values}, nil
+}
+
+func (be *BlockEngine) rpcInflightBundleStatuses(params []json.RawMessage) (any, error){
+  ids, err := bundleIDs(params)
+  if err != nil{
+    return nil, err
+  }
+
+  type inflightValue struct{
+    BundleID   string  `json:"bundle_id"`
+    Status     string  `json:"status"`
+    LandedSlot *uint64 `json:"landed_slot"`
+  }
+  be.mu.Lock()
+  defer be.mu.Unlock()
+  values := make([]inflightValue, 0, len(ids))
+  for _, id := range ids{
+    value := inflightValue{BundleID: id, Status: "Invalid"}
+    if st, ok := be.statuses[id]; ok{
+      value.Status = st.status
+      if st.status == "Landed"{
+        slot := st.landedSlot
+        value.LandedSlot = &slot
+      }
+    }
+    values = append(values, value)
+  }
+  return map[string]any{"context": rpcContext{Slot: be.Slot}, "value": values}, nil
+}
+
+func (be *BlockEngine) signaturesLocked(uuid string) []string{
+  for _, b := range be.bundles{
+    if b.UUID == uuid{
+      return b.Signatures
+    }
+  }
+  return nil
+}
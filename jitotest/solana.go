@@ -0,0 +1,560 @@
+package jitotest
+import(
+  "crypto/sha256"
+  "encoding/binary"
+  "encoding/json"
+  "errors"
+  "fmt"
+  "net/http"
+  "net/http/httptest"
+  "sync"
+  "time"
+
+  "github.com/mr-tron/base58"
+  "github.com/scatkit/pumpdexer/solana"
+  "github.com/scatkit/gojito/pkg"
+)
+
+// MaxBlockhashAge mirrors MAX_PROCESSING_AGE: a blockhash expires 150 blocks after it was issued.
+const MaxBlockhashAge = 150
+
+// JSON-RPC error codes of getBlock, see solana rpc_custom_error.rs.
+const(
+  ErrCodeBlockNotAvailable = -32004
+  ErrCodeSlotSkipped       = -32007
+)
+
+// finalizationDepth is the number of slots after which a confirmed signature is reported finalized.
+const finalizationDepth = 32
+
+// initialLamports is the balance of every account before a block transaction whose balances weren't scripted.
+const initialLamports = 1_000_000_000
+
+// SignatureStatus is the scripted status of a transaction signature.
+type SignatureStatus struct{
+  Slot               uint64
+  ConfirmationStatus string // processed, confirmed or finalized
+  Err                any
+}
+
+// BlockTransaction is a transaction included in a block served by getBlock, see Include.
+type BlockTransaction struct{
+  Transaction  *solana.Transaction
+  Err          any      // the transaction failed with this error
+  Fee          uint64   // defaults to 5000 lamports per signature
+  PreBalances  []uint64 // per account key; when nil, derived from the fee and the System Program transfers
+  PostBalances []uint64
+}
+
+// MethodHandler overrides the response of a JSON-RPC method. Returning an error produces a JSON-RPC error.
+type MethodHandler func(params []json.RawMessage) (any, error)
+
+// SolanaRPC is a fake Solana JSON-RPC node with scriptable signature statuses and slot progression.
+// Signatures added with Land move from processed to confirmed to finalized as slots advance.
+type SolanaRPC struct{
+  UnitsConsumed uint64 // reported by simulateTransaction/simulateBundle
+  SimulationErr any    // when set, every simulation fails with this error
+
+  mu          sync.Mutex
+  slot        uint64
+  blockHeight uint64
+  blockhashes map[solana.Hash]uint64 // blockhash => last valid block height
+  statuses    map[string]*SignatureStatus
+  blocks      map[uint64][]*BlockTransaction
+  skipped     map[uint64]bool
+  overrides   map[string]MethodHandler
+  calls       map[string]int
+
+  server *httptest.Server
+  stop   chan struct{}
+}
+
+func NewSolanaRPC() *SolanaRPC{
+  return &SolanaRPC{
+    UnitsConsumed: 1500,
+    slot:          1000,
+    blockHeight:   900,
+    blockhashes:   make(map[solana.Hash]uint64),
+    statuses:      make(map[string]*SignatureStatus),
+    blocks:        make(map[uint64][]*BlockTransaction),
+    skipped:       make(map[uint64]bool),
+    overrides:     make(map[string]MethodHandler),
+    calls:         make(map[string]int),
+    stop:          make(chan struct{}),
+  }
+}
+
+// Start serves the node over httptest and returns its URL, suitable for rpc.New.
+func (s *SolanaRPC) Start() string{
+  s.server = httptest.NewServer(http.HandlerFunc(s.serveJSONRPC))
+  return s.server.URL
+}
+
+func (s *SolanaRPC) Close(){
+  close(s.stop)
+  if s.server != nil{
+    s.server.Close()
+  }
+}
+
+// Handle overrides a method's response, e.g. to inject RPC errors.
+func (s *SolanaRPC) Handle(method string, handler MethodHandler){
+  s.mu.Lock()
+  defer s.mu.Unlock()
+  s.overrides[method] = handler
+}
+
+// Calls returns how many times a method was called.
+func (s *SolanaRPC) Calls(method string) int{
+  s.mu.Lock()
+  defer s.mu.Unlock()
+  return s.calls[method]
+}
+
+func (s *SolanaRPC) Slot() uint64{
+  s.mu.Lock()
+  defer s.mu.Unlock()
+  return s.slot
+}
+
+// Advance moves the slot and block height forward by n, promoting signature commitments on the way.
+func (s *SolanaRPC) Advance(n uint64){
+  s.mu.Lock()
+  defer s.mu.Unlock()
+  s.slot += n
+  s.blockHeight += n
+  for _, st := range s.statuses{
+    if st.ConfirmationStatus == "processed" && s.slot > st.Slot{
+      st.ConfirmationStatus = "confirmed"
+    }
+    if st.ConfirmationStatus == "confirmed" && s.slot >= st.Slot+finalizationDepth{
+      st.ConfirmationStatus = "finalized"
+    }
+  }
+}
+
+// AutoAdvance advances one slot every interval until Close is called.
+func (s *SolanaRPC) AutoAdvance(interval time.Duration){
+  go func(){
+    ticker := time.NewTicker(interval)
+    defer ticker.Stop()
+    for{
+      select{
+      case <-s.stop:
+        return
+      case <-ticker.C:
+        s.Advance(1)
+      }
+    }
+  }()
+}
+
+// Land marks signatures as processed in the current slot.
+func (s *SolanaRPC) Land(signatures ...string){
+  s.mu.Lock()
+  defer s.mu.Unlock()
+  for _, sig := range signatures{
+    s.statuses[sig] = &SignatureStatus{Slot: s.slot, ConfirmationStatus: "processed"}
+  }
+}
+
+// Include appends transactions to the block of the current slot and marks their signatures as processed.
+// The block is served by getBlock once the slot is confirmed, i.e. after the next Advance.
+func (s *SolanaRPC) Include(txs ...*BlockTransaction){
+  s.mu.Lock()
+  defer s.mu.Unlock()
+  for _, tx := range txs{
+    s.blocks[s.slot] = append(s.blocks[s.slot], tx)
+    s.statuses[tx.Transaction.Signatures[0].String()] = &SignatureStatus{Slot: s.slot, ConfirmationStatus: "processed", Err: tx.Err}
+  }
+}
+
+// SkipSlots makes getBlock report the slots as skipped, i.e. no block was produced in them.
+func (s *SolanaRPC) SkipSlots(slots ...uint64){
+  s.mu.Lock()
+  defer s.mu.Unlock()
+  for _, slot := range slots{
+    s.skipped[slot] = true
+  }
+}
+
+// SetSignatureStatus scripts the status of a signature; nil makes it unknown again.
+func (s *SolanaRPC) SetSignatureStatus(signature string, status *SignatureStatus){
+  s.mu.Lock()
+  defer s.mu.Unlock()
+  if status == nil{
+    delete(s.statuses, signature)
+    return
+  }
+  s.statuses[signature] = status
+}
+
+// ExpireBlockhashes advances the block height past the validity window of every issued blockhash.
+func (s *SolanaRPC) ExpireBlockhashes(){
+  s.Advance(MaxBlockhashAge + 1)
+}
+
+func (s *SolanaRPC) serveJSONRPC(w http.ResponseWriter, r *http.Request){
+  var req rpcRequest
+  if err := json.NewDecoder(r.Body).Decode(&req); err != nil{
+    writeRPC(w, nil, nil, &rpcError{Code: -32700, Message: "parse error"})
+    return
+  }
+
+  s.mu.Lock()
+  s.calls[req.Method]++
+  override := s.overrides[req.Method]
+  s.mu.Unlock()
+
+  var (
+    result any
+    err    error
+  )
+  if override != nil{
+    result, err = override(req.Params)
+  } else{
+    switch req.Method{
+    case "getSlot":
+      result = s.Slot()
+    case "getBlockHeight":
+      s.mu.Lock()
+      result = s.blockHeight
+      s.mu.Unlock()
+    case "getLatestBlockhash":
+      result = s.latestBlockhash()
+    case "isBlockhashValid":
+      result, err = s.isBlockhashValid(req.Params)
+    case "getSignatureStatuses":
+      result, err = s.signatureStatuses(req.Params)
+    case "getBlock":
+      result, err = s.block(req.Params)
+    case "simulateTransaction":
+      result, err = s.simulateTransaction(req.Params)
+    case "simulateBundle":
+      result, err = s.simulateBundle(req.Params)
+    default:
+      writeRPC(w, req.ID, nil, &rpcError{Code: -32601, Message: "Method not found"})
+      return
+    }
+  }
+  var rpcErr *rpcError
+  if errors.As(err, &rpcErr){
+    writeRPC(w, req.ID, nil, rpcErr)
+    return
+  }
+  if err != nil{
+    writeRPC(w, req.ID, nil, &rpcError{Code: -32602, Message: err.Error()})
+    return
+  }
+  writeRPC(w, req.ID, result, nil)
+}
+
+// withContext wraps a value the way RpcResponse<T> is serialized.
+func (s *SolanaRPC) withContext(value any) map[string]any{
+  return map[string]any{"context": rpcContext{Slot: s.Slot()}, "value": value}
+}
+
+func (s *SolanaRPC) latestBlockhash() map[string]any{
+  s.mu.Lock()
+  var seed [8]byte
+  binary.LittleEndian.PutUint64(seed[:], s.blockHeight)
+  hash := solana.Hash(sha256.Sum256(seed[:]))
+  lastValid := s.blockHeight + MaxBlockhashAge
+  s.blockhashes[hash] = lastValid
+  s.mu.Unlock()
+
+  return s.withContext(map[string]any{"blockhash": hash.String(), "lastValidBlockHeight": lastValid})
+}
+
+func (s *SolanaRPC) blockhashValid(hash solana.Hash) bool{
+  s.mu.Lock()
+  defer s.mu.Unlock()
+  lastValid, ok := s.blockhashes[hash]
+  return ok && s.blockHeight <= lastValid
+}
+
+func (s *SolanaRPC) isBlockhashValid(params []json.RawMessage) (any, error){
+  if len(params) == 0{
+    return nil, fmt.Errorf("missing blockhash")
+  }
+  var encoded string
+  if err := json.Unmarshal(params[0], &encoded); err != nil{
+    return nil, err
+  }
+  hash, err := solana.HashFromBase58(encoded)
+  if err != nil{
+    return nil, err
+  }
+  return s.withContext(s.blockhashValid(hash)), nil
+}
+
+func (s *SolanaRPC) signatureStatuses(params []json.RawMessage) (any, error){
+  if len(params) == 0{
+    return nil, fmt.Errorf("missing signatures")
+  }
+  sigs, err := decodeSignatures(params[0])
+  if err != nil{
+    return nil, err
+  }
+
+  type statusValue struct{
+    Slot               uint64  `json:"slot"`
+    Confirmations      *uint64 `json:"confirmations"`
+    Err                any     `json:"err"`
+    ConfirmationStatus string  `json:"confirmationStatus"`
+  }
+  s.mu.Lock()
+  values := make([]*statusValue, 0, len(sigs))
+  for _, sig := range sigs{
+    st, ok := s.statuses[sig]
+    if !ok{
+      values = append(values, nil)
+      continue
+    }
+    value := &statusValue{Slot: st.Slot, Err: st.Err, ConfirmationStatus: st.ConfirmationStatus}
+    if st.ConfirmationStatus != "finalized"{
+      confirmations := s.slot - st.Slot
+      value.Confirmations = &confirmations
+    }
+    values = append(values, value)
+  }
+  s.mu.Unlock()
+  return s.withContext(values), nil
+}
+
+// block serves a block in the json encoding with full transaction details. Slots that aren't confirmed yet are
+// reported as not available, skipped slots as such, other past slots without transactions as empty blocks.
+func (s *SolanaRPC) block(params []json.RawMessage) (any, error){
+  if len(params) == 0{
+    return nil, fmt.Errorf("missing slot")
+  }
+  var slot uint64
+  if err := json.Unmarshal(params[0], &slot); err != nil{
+    return nil, err
+  }
+  s.mu.Lock()
+  current, txs, skipped := s.slot, s.blocks[slot], s.skipped[slot]
+  heightOffset := s.slot - s.blockHeight
+  s.mu.Unlock()
+  if skipped && slot < current{
+    return nil, &rpcError{Code: ErrCodeSlotSkipped, Message: fmt.Sprintf("Slot %d was skipped, or missing due to ledger jump to recent snapshot", slot)}
+  }
+  if slot >= current{
+    return nil, &rpcError{Code: ErrCodeBlockNotAvailable, Message: fmt.Sprintf("Block not available for slot %d", slot)}
+  }
+
+  var seed [8]byte
+  binary.LittleEndian.PutUint64(seed[:], slot)
+  hash := solana.Hash(sha256.Sum256(append([]byte("block"), seed[:]...)))
+  transactions := make([]map[string]any, 0, len(txs))
+  for _, tx := range txs{
+    transactions = append(transactions, blockTransactionJSON(tx))
+  }
+  var parentSlot, blockHeight uint64
+  if slot > 0{
+    parentSlot = slot - 1
+  }
+  if slot > heightOffset{
+    blockHeight = slot - heightOffset
+  }
+  return map[string]any{
+    "blockhash":         hash.String(),
+    "previousBlockhash": solana.Hash{}.String(),
+    "parentSlot":        parentSlot,
+    "blockHeight":       blockHeight,
+    "blockTime":         nil,
+    "transactions":      transactions,
+  }, nil
+}
+
+func blockTransactionJSON(tx *BlockTransaction) map[string]any{
+  msg := tx.Transaction.Message
+  signatures := make([]string, 0, len(tx.Transaction.Signatures))
+  for _, sig := range tx.Transaction.Signatures{
+    signatures = append(signatures, sig.String())
+  }
+  keys := make([]string, 0, len(msg.AccountKeys))
+  for _, key := range msg.AccountKeys{
+    keys = append(keys, key.String())
+  }
+  instructions := make([]map[string]any, 0, len(msg.Instructions))
+  for _, inst := range msg.Instructions{
+    instructions = append(instructions, map[string]any{
+      "programIdIndex": inst.ProgramIDIndex,
+      "accounts":       inst.Accounts,
+      "data":           base58.Encode(inst.Data),
+      "stackHeight":    nil,
+    })
+  }
+
+  fee := tx.Fee
+  if fee == 0{
+    fee = 5000 * uint64(len(tx.Transaction.Signatures))
+  }
+  status := map[string]any{"Ok": nil}
+  if tx.Err != nil{
+    status = map[string]any{"Err": tx.Err}
+  }
+  pre, post := tx.PreBalances, tx.PostBalances
+  if pre == nil{
+    pre, post = derivedBalances(tx.Transaction, fee, tx.Err == nil)
+  }
+  return map[string]any{
+    "transaction": map[string]any{
+      "signatures": signatures,
+      "message": map[string]any{
+        "accountKeys":     keys,
+        "header":          msg.Header,
+        "recentBlockhash": msg.RecentBlockhash.String(),
+        "instructions":    instructions,
+      },
+    },
+    "meta": map[string]any{
+      "err":                  tx.Err,
+      "status":               status,
+      "fee":                  fee,
+      "preBalances":          pre,
+      "postBalances":         post,
+      "innerInstructions":    []any{},
+      "logMessages":          []string{},
+      "preTokenBalances":     []any{},
+      "postTokenBalances":    []any{},
+      "loadedAddresses":      map[string]any{"writable": []string{}, "readonly": []string{}},
+      "computeUnitsConsumed": 1500,
+    },
+    "version": "legacy",
+  }
+}
+
+// derivedBalances starts every account at initialLamports, charges the fee to the payer and, if the transaction
+// succeeded, applies its System Program transfers.
+func derivedBalances(tx *solana.Transaction, fee uint64, succeeded bool) (pre, post []uint64){
+  keys := tx.Message.AccountKeys
+  pre, post = make([]uint64, len(keys)), make([]uint64, len(keys))
+  for i := range keys{
+    pre[i], post[i] = initialLamports, initialLamports
+  }
+  if len(keys) > 0{
+    post[0] -= fee
+  }
+  if !succeeded{
+    return pre, post
+  }
+  for _, inst := range tx.Message.Instructions{
+    if int(inst.ProgramIDIndex) >= len(keys) || !keys[inst.ProgramIDIndex].Equals(pkg.SystemProgramID){
+      continue
+    }
+    // Transfer: u32 instruction index 2, u64 lamports; accounts: [from, to]
+    if len(inst.Data) != 12 || binary.LittleEndian.Uint32(inst.Data[:4]) != 2 || len(inst.Accounts) != 2{
+      continue
+    }
+    from, to := int(inst.Accounts[0]), int(inst.Accounts[1])
+    if from >= len(keys) || to >= len(keys){
+      continue
+    }
+    lamports := binary.LittleEndian.Uint64(inst.Data[4:])
+    post[from] -= lamports
+    post[to] += lamports
+  }
+  return pre, post
+}
+
+// decodeSignatures accepts base58 strings as well as raw 64 byte arrays,
+// which is how solana.Signature values are serialized by the pumpdexer RPC client.
+func decodeSignatures(raw json.RawMessage) ([]string, error){
+  var items []json.RawMessage
+  if err := json.Unmarshal(raw, &items); err != nil{
+    return nil, err
+  }
+  sigs := make([]string, 0, len(items))
+  for _, item := range items{
+    var sig string
+    if err := json.Unmarshal(item, &sig); err == nil{
+      sigs = append(sigs, sig)
+      continue
+    }
+    var bytes []byte
+    var ints []int
+    if err := json.Unmarshal(item, &ints); err != nil || len(ints) != 64{
+      return nil, fmt.Errorf("invalid signature %s", item)
+    }
+    for _, b := range ints{
+      bytes = append(bytes, byte(b))
+    }
+    sigs = append(sigs, base58.Encode(bytes))
+  }
+  return sigs, nil
+}
+
+// simulate checks the transaction's blockhash and returns its simulation error, if any.
+func (s *SolanaRPC) simulate(encoded string) (*solana.Transaction, any, error){
+  var tx solana.Transaction
+  if err := tx.UnmarshalBase64(encoded); err != nil{
+    return nil, nil, fmt.Errorf("failed to decode transaction: %w", err)
+  }
+  if s.SimulationErr != nil{
+    return &tx, s.SimulationErr, nil
+  }
+  if !s.blockhashValid(tx.Message.RecentBlockhash){
+    return &tx, "BlockhashNotFound", nil
+  }
+  return &tx, nil, nil
+}
+
+func (s *SolanaRPC) simulateTransaction(params []json.RawMessage) (any, error){
+  if len(params) == 0{
+    return nil, fmt.Errorf("missing transaction")
+  }
+  var encoded string
+  if err := json.Unmarshal(params[0], &encoded); err != nil{
+    return nil, err
+  }
+  _, simErr, err := s.simulate(encoded)
+  if err != nil{
+    return nil, err
+  }
+  return s.withContext(map[string]any{
+    "err":           simErr,
+    "logs":          []string{},
+    "accounts":      nil,
+    "unitsConsumed": s.UnitsConsumed,
+  }), nil
+}
+
+func (s *SolanaRPC) simulateBundle(params []json.RawMessage) (any, error){
+  if len(params) == 0{
+    return nil, fmt.Errorf("missing bundle")
+  }
+  var bundle struct{
+    EncodedTransactions []string `json:"encodedTransactions"`
+  }
+  if err := json.Unmarshal(params[0], &bundle); err != nil{
+    return nil, err
+  }
+
+  var summary any = "succeeded"
+  results := make([]map[string]any, 0, len(bundle.EncodedTransactions))
+  for _, encoded := range bundle.EncodedTransactions{
+    tx, simErr, err := s.simulate(encoded)
+    if err != nil{
+      return nil, err
+    }
+    if simErr != nil{
+      // the bundle stops executing at the first failing transaction
+      summary = map[string]any{"failed": map[string]any{
+        "error":        map[string]any{"TransactionFailure": []any{[]any{}, fmt.Sprint(simErr)}},
+        "tx_signature": tx.Signatures[0].String(),
+      }}
+      break
+    }
+    results = append(results, map[string]any{
+      "err":                   nil,
+      "logs":                  []string{},
+      "preExecutionAccounts":  nil,
+      "postExecutionAccounts": nil,
+      "unitsConsumed":         s.UnitsConsumed,
+      "returnData":            nil,
+    })
+  }
+  return s.withContext(map[string]any{"summary": summary, "transactionResults": results}), nil
+}
@@ -0,0 +1,372 @@
+// Package journal persists the bundles a searcher submits so that a crash between SendBundle and its result
+// doesn't lose track of signatures that may still land. Every bundle is written to an append-only file before
+// it's sent, followed by one record per lifecycle transition; Recover resolves the entries left open by a crash.
+package journal
+import(
+  "bufio"
+  "bytes"
+  "encoding/base64"
+  "encoding/json"
+  "errors"
+  "fmt"
+  "io"
+  "os"
+  "path/filepath"
+  "sort"
+  "sync"
+  "time"
+
+  "github.com/scatkit/pumpdexer/solana"
+  "github.com/scatkit/gojito/pkg"
+)
+
+// State is the lifecycle state of a journaled bundle.
+type State string
+
+const (
+  StatePending  State = "pending"  // written ahead of SendBundle, or SendBundle failed and the bundle may still land
+  StateSent     State = "sent"     // the block engine returned a UUID
+  StateAccepted State = "accepted" // forwarded to a validator
+  StateLanded   State = "landed"
+  StateFailed   State = "failed"   // landed, but a transaction failed
+  StateRejected State = "rejected"
+  StateDropped  State = "dropped"
+  StateExpired  State = "expired"  // the blockhash expired before the bundle landed
+)
+
+// Final reports whether the bundle can no longer change state.
+func (s State) Final() bool{
+  switch s{
+  case StateLanded, StateFailed, StateRejected, StateDropped, StateExpired:
+    return true
+  }
+  return false
+}
+
+// record is a line of the journal file. The first record of an entry carries the bundle itself,
+// later ones only the transition.
+type record struct{
+  ID    string    `json:"id"`
+  Time  time.Time `json:"time"`
+  State State     `json:"state"`
+
+  Transactions         []string `json:"transactions,omitempty"` // base64 wire transactions
+  Signatures           []string `json:"signatures,omitempty"`
+  TipLamports          uint64   `json:"tip_lamports,omitempty"`
+  Blockhash            string   `json:"blockhash,omitempty"`
+  LastValidBlockHeight uint64   `json:"last_valid_block_height,omitempty"`
+
+  UUID   string `json:"uuid,omitempty"`
+  Slot   uint64 `json:"slot,omitempty"`
+  Reason string `json:"reason,omitempty"`
+}
+
+// Transition is a state change of an entry.
+type Transition struct{
+  State  State
+  Time   time.Time
+  Reason string
+}
+
+// Entry is the current view of a journaled bundle.
+type Entry struct{
+  ID                   string   // the bundle's first signature
+  Transactions         []string // base64 wire transactions, to resend or inspect the bundle
+  Signatures           []string
+  TipLamports          uint64 // paid to the tip accounts known when the bundle was journaled
+  Blockhash            string
+  LastValidBlockHeight uint64 // 0 if unknown, in which case the blockhash is checked with isBlockhashValid
+  UUID                 string
+  State                State
+  Slot                 uint64
+  Reason               string
+  CreatedAt            time.Time
+  UpdatedAt            time.Time
+  History              []Transition
+}
+
+// Update is a transition recorded with Journal.Update. Empty fields keep their current value.
+type Update struct{
+  State  State
+  UUID   string
+  Slot   uint64
+  Reason string
+}
+
+var ErrUnknownEntry = errors.New("unknown journal entry")
+
+// Journal is an append-only file of bundle submissions. It's safe for concurrent use.
+type Journal struct{
+  mu      sync.Mutex
+  path    string
+  f       *os.File
+  entries map[string]*Entry
+  now     func() time.Time
+}
+
+// Open opens the journal at path, creating it if needed, and replays its records. A torn last record,
+// left by a crash in the middle of a write, is discarded.
+func Open(path string) (*Journal, error){
+  f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+  if err != nil{
+    return nil, err
+  }
+  j := &Journal{path: path, f: f, entries: make(map[string]*Entry), now: time.Now}
+  if err = j.replay(); err != nil{
+    f.Close()
+    return nil, fmt.Errorf("journal %s: %w", path, err)
+  }
+  return j, nil
+}
+
+func (j *Journal) replay() error{
+  data, err := io.ReadAll(j.f)
+  if err != nil{
+    return err
+  }
+  var offset int64
+  scanner := bufio.NewScanner(bytes.NewReader(data))
+  scanner.Buffer(make([]byte, 0, 64*1024), 16<<20)
+  for line := 1; scanner.Scan(); line++{
+    raw := scanner.Bytes()
+    end := offset + int64(len(raw)) + 1
+    if len(bytes.TrimSpace(raw)) > 0{
+      var rec record
+      if err := json.Unmarshal(raw, &rec); err != nil{
+        if end >= int64(len(data)){
+          break // torn write
+        }
+        return fmt.Errorf("line %d: %w", line, err)
+      }
+      j.apply(&rec)
+    }
+    offset = end
+  }
+  if err = scanner.Err(); err != nil{
+    return err
+  }
+  switch{
+  case offset > int64(len(data)):
+    // the last record is complete but lacks its newline
+    offset = int64(len(data))
+    if _, err = j.f.WriteAt([]byte{'\n'}, offset); err != nil{
+      return err
+    }
+    offset++
+  case offset < int64(len(data)):
+    if err = j.f.Truncate(offset); err != nil{
+      return err
+    }
+  }
+  _, err = j.f.Seek(offset, io.SeekStart)
+  return err
+}
+
+// apply folds a record into the entries.
+func (j *Journal) apply(rec *record){
+  e, ok := j.entries[rec.ID]
+  if !ok || len(rec.Transactions) > 0{
+    if !ok{
+      e = &Entry{ID: rec.ID, CreatedAt: rec.Time}
+      j.entries[rec.ID] = e
+    }
+    if len(rec.Transactions) > 0{
+      e.Transactions, e.Signatures, e.TipLamports = rec.Transactions, rec.Signatures, rec.TipLamports
+      e.Blockhash, e.LastValidBlockHeight = rec.Blockhash, rec.LastValidBlockHeight
+    }
+  }
+  if rec.UUID != ""{
+    e.UUID = rec.UUID
+  }
+  if rec.Slot != 0{
+    e.Slot = rec.Slot
+  }
+  e.Reason = rec.Reason
+  e.State = rec.State
+  e.UpdatedAt = rec.Time
+  e.History = append(e.History, Transition{State: rec.State, Time: rec.Time, Reason: rec.Reason})
+}
+
+// write appends rec and syncs the file before applying it.
+func (j *Journal) write(rec *record) error{
+  if j.f == nil{
+    return os.ErrClosed
+  }
+  line, err := json.Marshal(rec)
+  if err != nil{
+    return err
+  }
+  if _, err = j.f.Write(append(line, '\n')); err != nil{
+    return err
+  }
+  if err = j.f.Sync(); err != nil{
+    return err
+  }
+  j.apply(rec)
+  return nil
+}
+
+// Begin records a bundle as pending. It must be called before the bundle is sent: once Begin returns,
+// the bundle's signatures survive a crash. lastValidBlockHeight is optional (0 if unknown).
+// Beginning a bundle that's already journaled records it as pending again, e.g. for a resend.
+func (j *Journal) Begin(txns []*solana.Transaction, tipLamports, lastValidBlockHeight uint64) (*Entry, error){
+  if len(txns) == 0{
+    return nil, errors.New("empty bundle")
+  }
+  sigs := pkg.SignatureStrings(pkg.BatchExtractSigFromTx(txns))
+  if len(sigs) == 0{
+    return nil, errors.New("bundle is not signed")
+  }
+  rec := &record{
+    ID:                   sigs[0],
+    State:                StatePending,
+    Signatures:           sigs,
+    TipLamports:          tipLamports,
+    Blockhash:            txns[0].Message.RecentBlockhash.String(),
+    LastValidBlockHeight: lastValidBlockHeight,
+  }
+  for i, tx := range txns{
+    data, err := tx.MarshalBinary()
+    if err != nil{
+      return nil, fmt.Errorf("%d: failed to encode transaction: %w", i, err)
+    }
+    rec.Transactions = append(rec.Transactions, base64.StdEncoding.EncodeToString(data))
+  }
+
+  j.mu.Lock()
+  defer j.mu.Unlock()
+  rec.Time = j.now()
+  if err := j.write(rec); err != nil{
+    return nil, err
+  }
+  return j.entries[rec.ID].clone(), nil
+}
+
+// Update records a transition of the entry id. Transitions out of a final state are ignored.
+func (j *Journal) Update(id string, u Update) error{
+  j.mu.Lock()
+  defer j.mu.Unlock()
+  e, ok := j.entries[id]
+  if !ok{
+    return fmt.Errorf("%w: %s", ErrUnknownEntry, id)
+  }
+  if e.State.Final() && u.State != e.State{
+    return nil
+  }
+  if u.State == ""{
+    u.State = e.State
+  }
+  return j.write(&record{ID: id, Time: j.now(), State: u.State, UUID: u.UUID, Slot: u.Slot, Reason: u.Reason})
+}
+
+// Entry returns a copy of the entry id.
+func (j *Journal) Entry(id string) (*Entry, bool){
+  j.mu.Lock()
+  defer j.mu.Unlock()
+  e, ok := j.entries[id]
+  if !ok{
+    return nil, false
+  }
+  return e.clone(), true
+}
+
+// Entries returns copies of all entries, oldest first.
+func (j *Journal) Entries() []*Entry{
+  return j.filter(func(*Entry) bool{ return true })
+}
+
+// Unresolved returns copies of the entries that aren't in a final state, oldest first.
+func (j *Journal) Unresolved() []*Entry{
+  return j.filter(func(e *Entry) bool{ return !e.State.Final() })
+}
+
+func (j *Journal) filter(keep func(*Entry) bool) []*Entry{
+  j.mu.Lock()
+  defer j.mu.Unlock()
+  out := make([]*Entry, 0, len(j.entries))
+  for _, e := range j.entries{
+    if keep(e){
+      out = append(out, e.clone())
+    }
+  }
+  sort.Slice(out, func(a, b int) bool{ return out[a].CreatedAt.Before(out[b].CreatedAt) })
+  return out
+}
+
+// Compact rewrites the journal without the entries resolved before cutoff. The file is replaced atomically.
+func (j *Journal) Compact(cutoff time.Time) error{
+  j.mu.Lock()
+  defer j.mu.Unlock()
+  if j.f == nil{
+    return os.ErrClosed
+  }
+
+  tmp, err := os.CreateTemp(filepath.Dir(j.path), filepath.Base(j.path)+".compact-*")
+  if err != nil{
+    return err
+  }
+  defer os.Remove(tmp.Name())
+
+  kept := make(map[string]*Entry, len(j.entries))
+  w := bufio.NewWriter(tmp)
+  enc := json.NewEncoder(w)
+  for id, e := range j.entries{
+    if e.State.Final() && e.UpdatedAt.Before(cutoff){
+      continue
+    }
+    kept[id] = e
+    for i, t := range e.History{
+      rec := &record{ID: id, Time: t.Time, State: t.State, Reason: t.Reason}
+      if i == 0{
+        rec.Transactions, rec.Signatures, rec.TipLamports = e.Transactions, e.Signatures, e.TipLamports
+        rec.Blockhash, rec.LastValidBlockHeight = e.Blockhash, e.LastValidBlockHeight
+      }
+      if i == len(e.History)-1{
+        rec.UUID, rec.Slot = e.UUID, e.Slot
+      }
+      if err = enc.Encode(rec); err != nil{
+        tmp.Close()
+        return err
+      }
+    }
+  }
+  if err = w.Flush(); err == nil{
+    err = tmp.Sync()
+  }
+  if cerr := tmp.Close(); err == nil{
+    err = cerr
+  }
+  if err != nil{
+    return err
+  }
+  if err = os.Rename(tmp.Name(), j.path); err != nil{
+    return err
+  }
+
+  f, err := os.OpenFile(j.path, os.O_RDWR|os.O_APPEND, 0o600)
+  if err != nil{
+    return err
+  }
+  j.f.Close()
+  j.f, j.entries = f, kept
+  return nil
+}
+
+func (j *Journal) Close() error{
+  j.mu.Lock()
+  defer j.mu.Unlock()
+  if j.f == nil{
+    return nil
+  }
+  err := j.f.Close()
+  j.f = nil
+  return err
+}
+
+func (e *Entry) clone() *Entry{
+  c := *e
+  c.Transactions = append([]string(nil), e.Transactions...)
+  c.Signatures = append([]string(nil), e.Signatures...)
+  c.History = append([]Transition(nil), e.History...)
+  return &c
+}
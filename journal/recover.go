@@ -0,0 +1,164 @@
+package journal
+import(
+  "context"
+  "fmt"
+  "log/slog"
+  "time"
+
+  "github.com/scatkit/pumpdexer/rpc"
+  "github.com/scatkit/pumpdexer/solana"
+  "github.com/scatkit/gojito/jitorpc"
+  "github.com/scatkit/gojito/pkg"
+)
+
+// maxInflightBundleIDs is the most bundle IDs getInflightBundleStatuses accepts per call.
+const maxInflightBundleIDs = 5
+
+type RecoverOpts struct{
+  // RPC is the Solana RPC node queried for signature statuses and the block height. Required.
+  RPC *rpc.Client
+  // Jito, if set, is queried with getInflightBundleStatuses for the entries that got a UUID.
+  Jito         *jitorpc.JitoClient
+  PollInterval time.Duration // defaults to 2s
+  Logger       *slog.Logger  // nil discards all records
+}
+
+// Recover resolves the unresolved entries of j, typically on startup after a crash. Entries are polled until
+// their signatures land, the block engine reports a terminal status or their blockhash expires, whichever comes
+// first; an entry whose signatures haven't landed by the time its blockhash expires can no longer land and is
+// safe to send again. Recover returns the entries it resolved, and ctx's error if it was cancelled first.
+func Recover(ctx context.Context, j *Journal, opts RecoverOpts) ([]*Entry, error){
+  if opts.RPC == nil{
+    return nil, fmt.Errorf("recover requires a Solana RPC client")
+  }
+  if opts.PollInterval <= 0{
+    opts.PollInterval = 2 * time.Second
+  }
+  if opts.Logger == nil{
+    opts.Logger = pkg.NopLogger()
+  }
+
+  var resolved []*Entry
+  ticker := time.NewTicker(opts.PollInterval)
+  defer ticker.Stop()
+  for{
+    pending := j.Unresolved()
+    if len(pending) == 0{
+      return resolved, nil
+    }
+    opts.Logger.Debug("recovering journal entries", slog.Int("unresolved", len(pending)))
+    done, err := recoverOnce(ctx, j, pending, &opts)
+    resolved = append(resolved, done...)
+    if err != nil{
+      opts.Logger.Warn("journal recovery poll failed", slog.Any("error", err))
+    }
+    select{
+    case <-ctx.Done():
+      return resolved, ctx.Err()
+    case <-ticker.C:
+    }
+  }
+}
+
+// recoverOnce polls the statuses of pending once and records the entries it resolves.
+func recoverOnce(ctx context.Context, j *Journal, pending []*Entry, opts *RecoverOpts) ([]*Entry, error){
+  var resolved []*Entry
+  resolve := func(e *Entry, u Update) error{
+    if err := j.Update(e.ID, u); err != nil{
+      return err
+    }
+    opts.Logger.Info("journal entry resolved",
+      slog.String("id", e.ID),
+      slog.String("bundle_uuid", e.UUID),
+      slog.String("state", string(u.State)),
+      slog.String("reason", u.Reason),
+    )
+    if updated, ok := j.Entry(e.ID); ok{
+      resolved = append(resolved, updated)
+    }
+    return nil
+  }
+
+  // read once for all entries, before their signatures
+  height, heightErr := blockHeight(ctx, opts.RPC)
+  polledHeight := func(context.Context) (uint64, error){ return height, heightErr }
+
+  bundleStatuses := make(map[string]string)
+  bundleSlots := make(map[string]uint64)
+  if opts.Jito != nil{
+    var ids []string
+    for _, e := range pending{
+      if e.UUID != ""{
+        ids = append(ids, e.UUID)
+      }
+    }
+    for start := 0; start < len(ids); start += maxInflightBundleIDs{
+      end := min(start+maxInflightBundleIDs, len(ids))
+      resp, err := opts.Jito.GetInflightBundleStatuses(ctx, ids[start:end])
+      if err != nil{
+        opts.Logger.Warn("failed to get inflight bundle statuses", slog.Any("error", err))
+        break
+      }
+      for _, v := range resp.Value{
+        bundleStatuses[v.BundleId], bundleSlots[v.BundleId] = v.Status, v.LandedSlot
+      }
+    }
+  }
+
+  for _, e := range pending{
+    sigs, err := pkg.DecodeSignatures(e.Signatures)
+    if err != nil{
+      return resolved, fmt.Errorf("entry %s: %w", e.ID, err)
+    }
+    expired, expErr := blockhashExpired(ctx, opts.RPC, e, polledHeight)
+    // the crash may have outlasted the node's recent status cache: a landed bundle missing from it would be
+    // taken for expired and sent again
+    statuses, err := opts.RPC.GetSignatureStatuses(ctx, true, sigs...)
+    if err != nil{
+      return resolved, fmt.Errorf("entry %s: %w", e.ID, err)
+    }
+
+    landed, slot, txErr := pkg.FoldSignatureStatuses(statuses.Value)
+    switch{
+    case txErr != nil:
+      err = resolve(e, Update{State: StateFailed, Slot: slot, Reason: fmt.Sprint(txErr)})
+    case landed == len(sigs):
+      err = resolve(e, Update{State: StateLanded, Slot: slot})
+    case landed > 0:
+      continue // bundles land atomically: the remaining statuses will show up
+    case bundleStatuses[e.UUID] == "Landed":
+      err = resolve(e, Update{State: StateLanded, Slot: bundleSlots[e.UUID]})
+    case bundleStatuses[e.UUID] == "Failed" || bundleStatuses[e.UUID] == "Invalid":
+      err = resolve(e, Update{State: StateRejected, Reason: "inflight bundle status " + bundleStatuses[e.UUID]})
+    default:
+      if expErr != nil{
+        opts.Logger.Warn("failed to check blockhash expiry", slog.String("id", e.ID), slog.Any("error", expErr))
+        continue
+      }
+      if expired{
+        err = resolve(e, Update{State: StateExpired, Reason: "blockhash " + e.Blockhash + " expired"})
+      }
+    }
+    if err != nil{
+      return resolved, err
+    }
+  }
+  return resolved, nil
+}
+
+// blockhashExpired checks the expiry of the entry's blockhash against its journaled last valid block height.
+func blockhashExpired(ctx context.Context, client *rpc.Client, e *Entry, height func(context.Context) (uint64, error)) (bool, error){
+  hash, err := solana.HashFromBase58(e.Blockhash)
+  if err != nil{
+    return false, fmt.Errorf("entry %s: %w", e.ID, err)
+  }
+  expired, _, err := pkg.BlockhashExpired(ctx, client, hash, e.LastValidBlockHeight, height)
+  return expired, err
+}
+
+func blockHeight(ctx context.Context, client *rpc.Client) (uint64, error){
+  var height uint64
+  params := []interface{}{map[string]any{"commitment": rpc.CommitmentProcessed}}
+  err := client.RPCCallForInfo(ctx, &height, "getBlockHeight", params)
+  return height, err
+}
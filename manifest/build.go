@@ -0,0 +1,359 @@
+package manifest
+import(
+  "bytes"
+  "crypto/ed25519"
+  "crypto/sha256"
+  "encoding/binary"
+  "encoding/json"
+  "errors"
+  "fmt"
+  "os"
+  "path/filepath"
+  "slices"
+  "strings"
+
+  "github.com/scatkit/gojito/clients/searcher_client"
+  "github.com/scatkit/gojito/pb"
+  "github.com/scatkit/pumpdexer/programs/system"
+  "github.com/scatkit/pumpdexer/solana"
+)
+
+var (
+  TokenProgramID     = solana.MustPubkeyFromBase58("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+  Token2022ProgramID = solana.MustPubkeyFromBase58("TokenzQdBNbLqP5VEhdkAS6EPFLC1PHnBqCXEpPxuEb")
+  MemoProgramID      = solana.MustPubkeyFromBase58("MemoSq4gqABAXKb96qnH8TysNcWxMyWCqXgDLGmfcHr")
+)
+
+// SPL Token instruction discriminators.
+const (
+  tokenTransfer        = 3
+  tokenTransferChecked = 12
+)
+
+type BuildOpts struct{
+  // Blockhash is used when the manifest doesn't pin one. Required if the manifest has instruction specs.
+  Blockhash solana.Hash
+  // TipAccounts are the block engine's tip accounts the random and first strategies pick from.
+  TipAccounts []string
+  // Signers add to or override the manifest's keypair files.
+  Signers map[string]solana.PrivateKey
+}
+
+// instruction is a generic instruction built from a spec.
+type instruction struct{
+  programID solana.PublicKey
+  accounts  []*solana.AccountMeta
+  data      []byte
+}
+
+func (i *instruction) ProgramID() solana.PublicKey{ return i.programID }
+func (i *instruction) Accounts() []*solana.AccountMeta{ return i.accounts }
+func (i *instruction) Data() ([]byte, error){ return i.data, nil }
+
+// Build loads the signers, builds and signs the instruction specs and decodes the prebuilt transactions,
+// returning the bundle's transactions in order.
+func (m *Manifest) Build(opts *BuildOpts) ([]*solana.Transaction, error){
+  if opts == nil{
+    opts = &BuildOpts{}
+  }
+  signers, err := m.loadSigners(opts.Signers)
+  if err != nil{
+    return nil, err
+  }
+  blockhash := opts.Blockhash
+  if m.Blockhash != ""{
+    if blockhash, err = solana.HashFromBase58(m.Blockhash); err != nil{
+      return nil, fmt.Errorf("invalid blockhash %q: %w", m.Blockhash, err)
+    }
+  }
+
+  var tip solana.Instruction
+  var tipPayer string
+  if m.Tip != nil{
+    tipAccount, err := m.tipAccount(opts.TipAccounts, blockhash)
+    if err != nil{
+      return nil, err
+    }
+    tipPayer = firstNonEmpty(m.Tip.Payer, m.FeePayer)
+    if _, ok := signers[tipPayer]; !ok{
+      return nil, fmt.Errorf("tip: unknown payer %q", tipPayer)
+    }
+    tip = system.NewTransferInstruction(m.Tip.Lamports, signers[tipPayer].PublicKey(), tipAccount).Build()
+  }
+  build := func(feePayer string, instrs []solana.Instruction) (*solana.Transaction, error){
+    if blockhash.IsZero(){
+      return nil, errors.New("no blockhash to build transactions with")
+    }
+    if _, ok := signers[feePayer]; !ok{
+      return nil, fmt.Errorf("unknown fee payer %q", feePayer)
+    }
+    tx, err := solana.NewTransaction(instrs, blockhash, solana.TransactionPayer(signers[feePayer].PublicKey()))
+    if err != nil{
+      return nil, err
+    }
+    _, err = tx.Sign(func(pk solana.PublicKey) *solana.PrivateKey{
+      for _, key := range signers{
+        if key.PublicKey() == pk{
+          return &key
+        }
+      }
+      return nil
+    })
+    return tx, err
+  }
+
+  txns := make([]*solana.Transaction, 0, len(m.Transactions)+1)
+  for i, spec := range m.Transactions{
+    if spec.prebuilt(){
+      tx, err := DecodeTransaction(spec.Encoded, firstNonEmpty(spec.Encoding, m.Encoding))
+      if err != nil{
+        return nil, fmt.Errorf("transaction %d: %w", i, err)
+      }
+      txns = append(txns, tx)
+      continue
+    }
+    instrs := make([]solana.Instruction, 0, len(spec.Instructions)+1)
+    for j, inst := range spec.Instructions{
+      built, err := inst.build(signers)
+      if err != nil{
+        return nil, fmt.Errorf("transaction %d: instruction %d: %w", i, j, err)
+      }
+      instrs = append(instrs, built)
+    }
+    if tip != nil && i == len(m.Transactions)-1 && m.Tip.Placement != TipInOwnTransaction{
+      instrs = append(instrs, tip)
+    }
+    tx, err := build(firstNonEmpty(spec.FeePayer, m.FeePayer), instrs)
+    if err != nil{
+      return nil, fmt.Errorf("transaction %d: %w", i, err)
+    }
+    txns = append(txns, tx)
+  }
+  if tip != nil && m.Tip.Placement == TipInOwnTransaction{
+    tx, err := build(tipPayer, []solana.Instruction{tip})
+    if err != nil{
+      return nil, fmt.Errorf("tip transaction: %w", err)
+    }
+    txns = append(txns, tx)
+  }
+  return txns, nil
+}
+
+// Bundle builds the manifest's transactions and assembles them into a bundle. The tip accounts are fetched
+// from the block engine unless opts provides them or the manifest doesn't need them.
+func (m *Manifest) Bundle(cl *searcher_client.Client, opts *BuildOpts) (*jito_pb.Bundle, []*solana.Transaction, error){
+  if opts == nil{
+    opts = &BuildOpts{}
+  }
+  if m.needsTipAccounts() && len(opts.TipAccounts) == 0{
+    resp, err := cl.GetTipAccounts()
+    if err != nil{
+      return nil, nil, fmt.Errorf("failed to get tip accounts: %w", err)
+    }
+    withAccounts := *opts
+    withAccounts.TipAccounts = resp.Accounts
+    opts = &withAccounts
+  }
+  txns, err := m.Build(opts)
+  if err != nil{
+    return nil, nil, err
+  }
+  bundle, err := cl.AssembleBundle(txns)
+  if err != nil{
+    return nil, nil, err
+  }
+  return bundle, txns, nil
+}
+
+// NeedsBlockhash reports whether the manifest builds transactions (instruction specs or a tip) without pinning a blockhash.
+func (m *Manifest) NeedsBlockhash() bool{
+  if m.Blockhash != ""{
+    return false
+  }
+  if m.Tip != nil{
+    return true // the tip always goes into a built transaction
+  }
+  for _, tx := range m.Transactions{
+    if !tx.prebuilt(){
+      return true
+    }
+  }
+  return false
+}
+
+func (m *Manifest) needsTipAccounts() bool{
+  return m.Tip != nil && (m.Tip.Account == "" || m.Tip.Account == TipAccountRandom || m.Tip.Account == TipAccountFirst)
+}
+
+// tipAccount resolves the tip account strategy. The random pick is seeded with the manifest's content and the
+// blockhash, so that the same manifest and blockhash always build the same bundle while tips still spread over
+// the tip accounts.
+func (m *Manifest) tipAccount(tipAccounts []string, blockhash solana.Hash) (solana.PublicKey, error){
+  switch m.Tip.Account{
+  case "", TipAccountRandom, TipAccountFirst:
+    if len(tipAccounts) == 0{
+      return solana.PublicKey{}, errors.New("tip: no tip accounts to pick from")
+    }
+    account := tipAccounts[0]
+    if m.Tip.Account != TipAccountFirst{
+      content, err := json.Marshal(m)
+      if err != nil{
+        return solana.PublicKey{}, fmt.Errorf("tip: %w", err)
+      }
+      seed := sha256.Sum256(append(content, blockhash[:]...))
+      // the block engine doesn't return its tip accounts in a fixed order
+      sorted := slices.Sorted(slices.Values(tipAccounts))
+      account = sorted[binary.LittleEndian.Uint64(seed[:8])%uint64(len(sorted))]
+    }
+    return solana.PublicKeyFromBase58(account)
+  }
+  return solana.PublicKeyFromBase58(m.Tip.Account)
+}
+
+// SimulateOpts returns the simulation options of the manifest (nil without simulation requirements).
+func (m *Manifest) SimulateOpts() *searcher_client.SimulateBundleOpts{
+  if m.Simulation == nil{
+    return nil
+  }
+  opts := &searcher_client.SimulateBundleOpts{
+    SkipSigVerify:          m.Simulation.SkipSigVerify,
+    ReplaceRecentBlockhash: m.Simulation.ReplaceRecentBlockhash,
+  }
+  for _, account := range m.Simulation.WatchedAccounts{
+    opts.WatchedAccounts = append(opts.WatchedAccounts, solana.MustPubkeyFromBase58(account))
+  }
+  return opts
+}
+
+// SimulationRequired reports whether the bundle must simulate successfully before it's sent.
+func (m *Manifest) SimulationRequired() bool{
+  return m.Simulation != nil && m.Simulation.Required
+}
+
+// CheckSimulation returns an error if sim doesn't meet the manifest's simulation requirements.
+func (m *Manifest) CheckSimulation(sim *searcher_client.BundleSimulation) error{
+  if err := sim.Summary.Err(); err != nil{
+    return err
+  }
+  if m.Simulation == nil || m.Simulation.MaxUnits == 0{
+    return nil
+  }
+  for i, res := range sim.TransactionResults{
+    if res.UnitsConsumed > m.Simulation.MaxUnits{
+      return fmt.Errorf("transaction %d consumed %d compute units, more than the maximum of %d", i, res.UnitsConsumed, m.Simulation.MaxUnits)
+    }
+  }
+  return nil
+}
+
+// loadSigners reads the keypair files of the manifest's signers, then applies overrides.
+func (m *Manifest) loadSigners(overrides map[string]solana.PrivateKey) (map[string]solana.PrivateKey, error){
+  signers := make(map[string]solana.PrivateKey, len(m.Signers))
+  for name, path := range m.Signers{
+    if _, ok := overrides[name]; ok{
+      continue
+    }
+    if !filepath.IsAbs(path){
+      path = filepath.Join(m.dir, path)
+    }
+    key, err := ReadKeypair(path)
+    if err != nil{
+      return nil, fmt.Errorf("signer %q: %w", name, err)
+    }
+    signers[name] = key
+  }
+  for name, key := range overrides{
+    signers[name] = key
+  }
+  return signers, nil
+}
+
+// ReadKeypair reads a keypair file, either a JSON byte array as written by solana-keygen or a base58 private key.
+func ReadKeypair(path string) (solana.PrivateKey, error){
+  data, err := os.ReadFile(path)
+  if err != nil{
+    return nil, err
+  }
+  var key []byte
+  if err = json.Unmarshal(data, &key); err != nil{
+    if key, err = solana.PrivateKeyFromBase58(strings.TrimSpace(string(data))); err != nil{
+      return nil, fmt.Errorf("invalid keypair file %s", path)
+    }
+  }
+  if len(key) != ed25519.PrivateKeySize || !bytes.Equal(ed25519.NewKeyFromSeed(key[:ed25519.SeedSize]), key){
+    return nil, fmt.Errorf("invalid keypair file %s: not an ed25519 keypair", path)
+  }
+  return solana.PrivateKey(key), nil
+}
+
+// account resolves a signer name or a base58 public key.
+func account(signers map[string]solana.PrivateKey, s string) (solana.PublicKey, error){
+  if key, ok := signers[s]; ok{
+    return key.PublicKey(), nil
+  }
+  return solana.PublicKeyFromBase58(s)
+}
+
+func (inst *Instruction) build(signers map[string]solana.PrivateKey) (solana.Instruction, error){
+  var keys []solana.PublicKey
+  resolve := func(names ...string) error{
+    keys = keys[:0]
+    for _, name := range names{
+      pk, err := account(signers, name)
+      if err != nil{
+        return fmt.Errorf("%q: %w", name, err)
+      }
+      keys = append(keys, pk)
+    }
+    return nil
+  }
+
+  switch inst.Type{
+  case SOLTransfer:
+    if err := resolve(inst.From, inst.To); err != nil{
+      return nil, err
+    }
+    return system.NewTransferInstruction(inst.Lamports, keys[0], keys[1]).Build(), nil
+
+  case SPLTransfer:
+    program := TokenProgramID
+    if inst.TokenProgram != ""{
+      var err error
+      if program, err = solana.PublicKeyFromBase58(inst.TokenProgram); err != nil{
+        return nil, fmt.Errorf("token program: %w", err)
+      }
+    }
+    if inst.Mint == ""{
+      if err := resolve(inst.Source, inst.Destination, inst.From); err != nil{
+        return nil, err
+      }
+      data := binary.LittleEndian.AppendUint64([]byte{tokenTransfer}, inst.Amount)
+      return &instruction{programID: program, data: data, accounts: []*solana.AccountMeta{
+        solana.Meta(keys[0]).WRITE(),
+        solana.Meta(keys[1]).WRITE(),
+        solana.Meta(keys[2]).SIGNER(),
+      }}, nil
+    }
+    if err := resolve(inst.Source, inst.Mint, inst.Destination, inst.From); err != nil{
+      return nil, err
+    }
+    data := append(binary.LittleEndian.AppendUint64([]byte{tokenTransferChecked}, inst.Amount), inst.Decimals)
+    return &instruction{programID: program, data: data, accounts: []*solana.AccountMeta{
+      solana.Meta(keys[0]).WRITE(),
+      solana.Meta(keys[1]),
+      solana.Meta(keys[2]).WRITE(),
+      solana.Meta(keys[3]).SIGNER(),
+    }}, nil
+
+  case Memo:
+    if err := resolve(inst.Signers...); err != nil{
+      return nil, err
+    }
+    accounts := make([]*solana.AccountMeta, 0, len(keys))
+    for _, pk := range keys{
+      accounts = append(accounts, solana.Meta(pk).SIGNER())
+    }
+    return &instruction{programID: MemoProgramID, data: []byte(inst.Text), accounts: accounts}, nil
+  }
+  return nil, fmt.Errorf("unknown instruction type %q", inst.Type)
+}
@@ -0,0 +1,357 @@
+// Package manifest implements a declarative JSON/YAML bundle format: prebuilt transactions or instruction specs,
+// the tip, signers referenced by keypair file and simulation requirements. A manifest builds the exact same
+// bundle every time it's given the same blockhash and tip accounts, which makes failed bundles reproducible.
+package manifest
+import(
+  "encoding/base64"
+  "encoding/json"
+  "errors"
+  "fmt"
+  "os"
+  "path/filepath"
+  "strings"
+
+  bin "github.com/gagliardetto/binary"
+  "github.com/mr-tron/base58"
+  "github.com/scatkit/pumpdexer/solana"
+
+  "gopkg.in/yaml.v3"
+)
+
+// Version is the manifest format version written by Record.
+const Version = 1
+
+// Instruction types.
+const (
+  SOLTransfer = "sol-transfer"
+  SPLTransfer = "spl-transfer"
+  Memo        = "memo"
+)
+
+// Tip account strategies.
+const (
+  TipAccountRandom = "random" // a tip account picked pseudo-randomly from the manifest and blockhash (default)
+  TipAccountFirst  = "first"  // the first tip account returned by the block engine
+)
+
+// Tip placements.
+const (
+  TipInLastTransaction = "last-transaction" // the transfer is appended to the last built transaction (default)
+  TipInOwnTransaction  = "own-transaction"  // the transfer is sent as an extra transaction at the end of the bundle
+)
+
+type Manifest struct{
+  Version int    `json:"version" yaml:"version"`
+  Name    string `json:"name,omitempty" yaml:"name,omitempty"`
+  // Signers maps the signer names used in the manifest to keypair files, relative to the manifest's directory.
+  Signers map[string]string `json:"signers,omitempty" yaml:"signers,omitempty"`
+  // FeePayer is the default fee payer of built transactions.
+  FeePayer string `json:"fee_payer,omitempty" yaml:"fee_payer,omitempty"`
+  // Blockhash pins the recent blockhash of built transactions; the caller provides one when empty.
+  Blockhash string `json:"blockhash,omitempty" yaml:"blockhash,omitempty"`
+  // Encoding is the default encoding of prebuilt transactions: base64, base58 or empty to detect it per transaction.
+  Encoding     string         `json:"encoding,omitempty" yaml:"encoding,omitempty"`
+  Transactions []*Transaction `json:"transactions" yaml:"transactions"`
+  Tip          *Tip           `json:"tip,omitempty" yaml:"tip,omitempty"`
+  Simulation   *Simulation    `json:"simulation,omitempty" yaml:"simulation,omitempty"`
+
+  dir string // directory keypair paths are relative to
+}
+
+// Transaction is either a prebuilt signed transaction or a list of instructions to build and sign.
+// A bare string in the manifest is read as a prebuilt transaction.
+type Transaction struct{
+  Encoded      string         `json:"encoded,omitempty" yaml:"encoded,omitempty"`
+  Encoding     string         `json:"encoding,omitempty" yaml:"encoding,omitempty"`
+  FeePayer     string         `json:"fee_payer,omitempty" yaml:"fee_payer,omitempty"` // signer name
+  Instructions []*Instruction `json:"instructions,omitempty" yaml:"instructions,omitempty"`
+}
+
+func (t *Transaction) prebuilt() bool{ return t.Encoded != "" }
+
+func (t *Transaction) UnmarshalJSON(data []byte) error{
+  var encoded string
+  if json.Unmarshal(data, &encoded) == nil{
+    *t = Transaction{Encoded: encoded}
+    return nil
+  }
+  type plain Transaction
+  return json.Unmarshal(data, (*plain)(t))
+}
+
+func (t *Transaction) UnmarshalYAML(value *yaml.Node) error{
+  if value.Kind == yaml.ScalarNode{
+    *t = Transaction{Encoded: value.Value}
+    return nil
+  }
+  type plain Transaction
+  return value.Decode((*plain)(t))
+}
+
+// Instruction is an instruction spec. Accounts are given either as a signer name or as a base58 public key.
+type Instruction struct{
+  Type string `json:"type" yaml:"type"`
+
+  // sol-transfer: From (signer) sends Lamports to To.
+  // spl-transfer: From (owner signer) sends Amount tokens from Source to Destination. With Mint set the transfer is
+  // checked against Decimals. TokenProgram defaults to the SPL Token program.
+  From         string `json:"from,omitempty" yaml:"from,omitempty"`
+  To           string `json:"to,omitempty" yaml:"to,omitempty"`
+  Lamports     uint64 `json:"lamports,omitempty" yaml:"lamports,omitempty"`
+  Source       string `json:"source,omitempty" yaml:"source,omitempty"`
+  Destination  string `json:"destination,omitempty" yaml:"destination,omitempty"`
+  Amount       uint64 `json:"amount,omitempty" yaml:"amount,omitempty"`
+  Mint         string `json:"mint,omitempty" yaml:"mint,omitempty"`
+  Decimals     uint8  `json:"decimals,omitempty" yaml:"decimals,omitempty"`
+  TokenProgram string `json:"token_program,omitempty" yaml:"token_program,omitempty"`
+
+  // memo: Text signed by Signers (optional).
+  Text    string   `json:"text,omitempty" yaml:"text,omitempty"`
+  Signers []string `json:"signers,omitempty" yaml:"signers,omitempty"`
+}
+
+// Tip is the transfer to a Jito tip account paying for the bundle.
+type Tip struct{
+  Lamports  uint64 `json:"lamports" yaml:"lamports"`
+  Account   string `json:"account,omitempty" yaml:"account,omitempty"`     // random (default), first or a tip account
+  Payer     string `json:"payer,omitempty" yaml:"payer,omitempty"`         // signer name, defaults to the manifest's fee payer
+  Placement string `json:"placement,omitempty" yaml:"placement,omitempty"` // last-transaction (default) or own-transaction
+}
+
+// Simulation are the requirements the bundle's simulation must meet before it's sent.
+type Simulation struct{
+  Required               bool     `json:"required" yaml:"required"` // the bundle is only sent if its simulation succeeds
+  SkipSigVerify          bool     `json:"skip_sig_verify,omitempty" yaml:"skip_sig_verify,omitempty"`
+  ReplaceRecentBlockhash bool     `json:"replace_recent_blockhash,omitempty" yaml:"replace_recent_blockhash,omitempty"`
+  WatchedAccounts        []string `json:"watched_accounts,omitempty" yaml:"watched_accounts,omitempty"`
+  MaxUnits               uint64   `json:"max_units,omitempty" yaml:"max_units,omitempty"` // per transaction, 0 for no limit
+}
+
+// Load reads a manifest from a .json, .yaml or .yml file and validates it.
+func Load(path string) (*Manifest, error){
+  data, err := os.ReadFile(path)
+  if err != nil{
+    return nil, err
+  }
+  format := strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+  m, err := Parse(data, format)
+  if err != nil{
+    return nil, fmt.Errorf("%s: %w", path, err)
+  }
+  m.dir = filepath.Dir(path)
+  return m, nil
+}
+
+// Parse decodes a manifest in format (json, yaml or yml) and validates it.
+// Keypair paths are relative to the working directory.
+func Parse(data []byte, format string) (*Manifest, error){
+  m := new(Manifest)
+  var err error
+  switch format{
+  case "json":
+    err = json.Unmarshal(data, m)
+  case "yaml", "yml":
+    err = yaml.Unmarshal(data, m)
+  default:
+    return nil, fmt.Errorf("unknown manifest format %q", format)
+  }
+  if err != nil{
+    return nil, err
+  }
+  if err = m.Validate(); err != nil{
+    return nil, err
+  }
+  return m, nil
+}
+
+// Marshal encodes the manifest in format (json, yaml or yml).
+func (m *Manifest) Marshal(format string) ([]byte, error){
+  switch format{
+  case "json":
+    return json.MarshalIndent(m, "", "  ")
+  case "yaml", "yml":
+    return yaml.Marshal(m)
+  }
+  return nil, fmt.Errorf("unknown manifest format %q", format)
+}
+
+// Validate checks the manifest without loading keypairs or building transactions. All problems are reported.
+func (m *Manifest) Validate() error{
+  var errs []error
+  fail := func(format string, args ...any){ errs = append(errs, fmt.Errorf(format, args...)) }
+
+  if m.Version > Version{
+    fail("unsupported manifest version %d", m.Version)
+  }
+  if len(m.Transactions) == 0 || len(m.Transactions) > 5{
+    fail("a bundle must contain between 1 and 5 transactions, got %d", len(m.Transactions))
+  }
+  for name, path := range m.Signers{
+    if path == ""{
+      fail("signer %q has no keypair file", name)
+    }
+  }
+  if m.Blockhash != ""{
+    if _, err := solana.HashFromBase58(m.Blockhash); err != nil{
+      fail("invalid blockhash %q", m.Blockhash)
+    }
+  }
+  checkSigner := func(where, name string){
+    if name == ""{
+      fail("%s: missing signer", where)
+    } else if _, ok := m.Signers[name]; !ok{
+      fail("%s: unknown signer %q", where, name)
+    }
+  }
+  checkAccount := func(where, account string){
+    if _, ok := m.Signers[account]; ok{
+      return
+    }
+    if _, err := solana.PublicKeyFromBase58(account); err != nil{
+      fail("%s: %q is neither a signer nor a public key", where, account)
+    }
+  }
+
+  for i, tx := range m.Transactions{
+    where := fmt.Sprintf("transaction %d", i)
+    if tx == nil{
+      fail("%s: empty", where)
+      continue
+    }
+    if tx.prebuilt(){
+      if len(tx.Instructions) > 0{
+        fail("%s: either encoded or instructions, not both", where)
+      }
+      if _, err := DecodeTransaction(tx.Encoded, firstNonEmpty(tx.Encoding, m.Encoding)); err != nil{
+        fail("%s: %v", where, err)
+      }
+      continue
+    }
+    if len(tx.Instructions) == 0{
+      fail("%s: no instructions", where)
+    }
+    checkSigner(where+": fee payer", firstNonEmpty(tx.FeePayer, m.FeePayer))
+    for j, inst := range tx.Instructions{
+      where := fmt.Sprintf("transaction %d: instruction %d", i, j)
+      switch inst.Type{
+      case SOLTransfer:
+        checkSigner(where+": from", inst.From)
+        checkAccount(where+": to", inst.To)
+        if inst.Lamports == 0{
+          fail("%s: transfer of 0 lamports", where)
+        }
+      case SPLTransfer:
+        checkSigner(where+": from", inst.From)
+        checkAccount(where+": source", inst.Source)
+        checkAccount(where+": destination", inst.Destination)
+        if inst.Mint != ""{
+          checkAccount(where+": mint", inst.Mint)
+        }
+        if inst.TokenProgram != ""{
+          checkAccount(where+": token program", inst.TokenProgram)
+        }
+        if inst.Amount == 0{
+          fail("%s: transfer of 0 tokens", where)
+        }
+      case Memo:
+        if inst.Text == ""{
+          fail("%s: empty memo", where)
+        }
+        for _, signer := range inst.Signers{
+          checkSigner(where+": signers", signer)
+        }
+      default:
+        fail("%s: unknown instruction type %q", where, inst.Type)
+      }
+    }
+  }
+
+  if tip := m.Tip; tip != nil{
+    if tip.Lamports == 0{
+      fail("tip: 0 lamports")
+    }
+    switch tip.Account{
+    case "", TipAccountRandom, TipAccountFirst:
+    default:
+      if _, err := solana.PublicKeyFromBase58(tip.Account); err != nil{
+        fail("tip: account %q is neither a strategy nor a public key", tip.Account)
+      }
+    }
+    checkSigner("tip: payer", firstNonEmpty(tip.Payer, m.FeePayer))
+    switch tip.Placement{
+    case "", TipInLastTransaction:
+      if n := len(m.Transactions); n > 0 && m.Transactions[n-1] != nil && m.Transactions[n-1].prebuilt(){
+        fail("tip: can't be appended to the prebuilt last transaction, use placement %q", TipInOwnTransaction)
+      }
+    case TipInOwnTransaction:
+      if len(m.Transactions) >= 5{
+        fail("tip: no room for its own transaction in a bundle of %d transactions", len(m.Transactions))
+      }
+    default:
+      fail("tip: unknown placement %q", tip.Placement)
+    }
+  }
+
+  if sim := m.Simulation; sim != nil{
+    for _, account := range sim.WatchedAccounts{
+      if _, err := solana.PublicKeyFromBase58(account); err != nil{
+        fail("simulation: invalid watched account %q", account)
+      }
+    }
+  }
+  return errors.Join(errs...)
+}
+
+// Record returns a manifest replaying signed transactions exactly, e.g. to keep a copy of a bundle that failed.
+func Record(name string, txns []*solana.Transaction) (*Manifest, error){
+  m := &Manifest{Version: Version, Name: name, Encoding: "base64"}
+  for i, tx := range txns{
+    data, err := tx.MarshalBinary()
+    if err != nil{
+      return nil, fmt.Errorf("transaction %d: %w", i, err)
+    }
+    m.Transactions = append(m.Transactions, &Transaction{Encoded: base64.StdEncoding.EncodeToString(data)})
+  }
+  return m, nil
+}
+
+// DecodeTransaction decodes a signed base64 or base58 wire transaction. With an empty or "auto" encoding,
+// base64 is tried first since base58 strings rarely decode as valid base64 transactions.
+func DecodeTransaction(s, encoding string) (*solana.Transaction, error){
+  var decoders []func(string) ([]byte, error)
+  switch strings.ToLower(encoding){
+  case "", "auto":
+    decoders = append(decoders, base64.StdEncoding.DecodeString, base58.Decode)
+  case "base64":
+    decoders = append(decoders, base64.StdEncoding.DecodeString)
+  case "base58":
+    decoders = append(decoders, base58.Decode)
+  default:
+    return nil, fmt.Errorf("unknown encoding %q", encoding)
+  }
+
+  var err error
+  for _, decode := range decoders{
+    var data []byte
+    if data, err = decode(s); err != nil{
+      continue
+    }
+    var tx *solana.Transaction
+    if tx, err = solana.TransactionFromDecoder(bin.NewBinDecoder(data)); err == nil{
+      if len(tx.Signatures) == 0{
+        return nil, errors.New("transaction is not signed")
+      }
+      return tx, nil
+    }
+  }
+  return nil, err
+}
+
+func firstNonEmpty(values ...string) string{
+  for _, v := range values{
+    if v != ""{
+      return v
+    }
+  }
+  return ""
+}
@@ -0,0 +1,106 @@
+// Package metrics defines the instrumentation hooks used by the searcher and JSON-RPC clients.
+// The core packages only depend on the Recorder interface; Prometheus and OpenTelemetry
+// adapters live in the prommetrics and otelmetrics sub-packages.
+package metrics
+import(
+  "time"
+  
+  "github.com/scatkit/gojito/pb"
+)
+
+// Submission kinds.
+const(
+  KindBundle      = "bundle"
+  KindTransaction = "transaction"
+)
+
+// Outcomes used as label values.
+const(
+  OutcomeSuccess   = "success"
+  OutcomeError     = "error"
+  OutcomeAccepted  = "accepted"
+  OutcomeRejected  = "rejected"
+  OutcomeDropped   = "dropped"
+  OutcomeProcessed = "processed"
+  OutcomeFinalized = "finalized"
+  OutcomeLanded    = "landed"
+  OutcomeCoalesced = "coalesced"
+  OutcomeExpired   = "expired"
+)
+
+// Recorder receives measurements from the clients. Implementations must be safe for concurrent use.
+type Recorder interface{
+  // Submitted records a SendBundle/sendTransaction call. Outcome is OutcomeAccepted or OutcomeError.
+  Submitted(kind, outcome string, latency time.Duration)
+  // BundleResult records an event received for a bundle (result stream or status polling).
+  // Reason is set for rejections and drops, empty otherwise.
+  BundleResult(outcome, reason string)
+  // Landed records the time it took a bundle to land since it was submitted.
+  Landed(timeToLand time.Duration)
+  // InFlight adjusts the number of bundles that are waiting for a terminal result.
+  InFlight(delta int)
+  // TipAccountsFetched records a tip accounts lookup. Outcome is OutcomeSuccess or OutcomeError.
+  TipAccountsFetched(outcome string, latency time.Duration)
+  // AuthRefreshed records an access token refresh. Outcome is OutcomeSuccess or OutcomeError.
+  AuthRefreshed(outcome string)
+  // Reconnected records a gRPC reconnect attempt to a block engine region (empty for the global endpoint).
+  Reconnected(region string)
+  // Deduplicated records a repeat submission caught by a dedup guard. Outcome is OutcomeRejected or OutcomeCoalesced.
+  Deduplicated(kind, outcome string)
+}
+
+// Nop is a Recorder that discards all measurements.
+type Nop struct{}
+
+func (Nop) Submitted(string, string, time.Duration)    {}
+func (Nop) BundleResult(string, string)                {}
+func (Nop) Landed(time.Duration)                       {}
+func (Nop) InFlight(int)                               {}
+func (Nop) TipAccountsFetched(string, time.Duration)   {}
+func (Nop) AuthRefreshed(string)                       {}
+func (Nop) Reconnected(string)                         {}
+func (Nop) Deduplicated(string, string)                {}
+
+// OutcomeOf returns an error-aware outcome for calls that either succeed or fail.
+func OutcomeOf(err error, success string) string{
+  if err != nil{
+    return OutcomeError
+  }
+  return success
+}
+
+// ClassifyBundleResult maps a result-stream event to an outcome and an (optional) reason label.
+func ClassifyBundleResult(result *jito_pb.BundleResult) (outcome, reason string){
+  switch r := result.GetResult().(type){
+  case *jito_pb.BundleResult_Accepted:
+    return OutcomeAccepted, ""
+  case *jito_pb.BundleResult_Rejected:
+    return OutcomeRejected, RejectionReason(r.Rejected)
+  case *jito_pb.BundleResult_Dropped:
+    return OutcomeDropped, r.Dropped.GetReason().String()
+  case *jito_pb.BundleResult_Processed:
+    return OutcomeProcessed, ""
+  case *jito_pb.BundleResult_Finalized:
+    return OutcomeFinalized, ""
+  default:
+    return "unknown", ""
+  }
+}
+
+// RejectionReason returns a low-cardinality label for a bundle rejection.
+func RejectionReason(rejected *jito_pb.Rejected) string{
+  switch rejected.GetReason().(type){
+  case *jito_pb.Rejected_StateAuctionBidRejected:
+    return "state_auction_bid_rejected"
+  case *jito_pb.Rejected_WinningBatchBidRejected:
+    return "winning_batch_bid_rejected"
+  case *jito_pb.Rejected_SimulationFailure:
+    return "simulation_failure"
+  case *jito_pb.Rejected_InternalError:
+    return "internal_error"
+  case *jito_pb.Rejected_DroppedBundle:
+    return "dropped_bundle"
+  default:
+    return "unknown"
+  }
+}
@@ -0,0 +1,95 @@
+// Package otelmetrics implements metrics.Recorder on top of an OpenTelemetry meter.
+package otelmetrics
+import(
+  "context"
+  "errors"
+  "time"
+  
+  "go.opentelemetry.io/otel/attribute"
+  "go.opentelemetry.io/otel/metric"
+  "github.com/scatkit/gojito/metrics"
+)
+
+// Recorder exports gojito measurements as OpenTelemetry instruments.
+type Recorder struct{
+  submitted    metric.Int64Counter
+  submitLat    metric.Float64Histogram
+  results      metric.Int64Counter
+  timeToLand   metric.Float64Histogram
+  inFlight     metric.Int64UpDownCounter
+  tipAccounts  metric.Int64Counter
+  tipLat       metric.Float64Histogram
+  authRefresh  metric.Int64Counter
+  reconnects   metric.Int64Counter
+  duplicates   metric.Int64Counter
+}
+
+var _ metrics.Recorder = (*Recorder)(nil)
+
+// New creates the instruments on meter, e.g. otel.Meter("github.com/scatkit/gojito").
+func New(meter metric.Meter) (*Recorder, error){
+  var r Recorder
+  var err, e error
+  
+  r.submitted, e = meter.Int64Counter("gojito.submissions", metric.WithDescription("Bundles and transactions submitted to the block engine."))
+  err = errors.Join(err, e)
+  r.submitLat, e = meter.Float64Histogram("gojito.submit.latency", metric.WithUnit("s"), metric.WithDescription("Latency of SendBundle/sendTransaction calls."))
+  err = errors.Join(err, e)
+  r.results, e = meter.Int64Counter("gojito.bundle.results", metric.WithDescription("Bundle result events by outcome and reason."))
+  err = errors.Join(err, e)
+  r.timeToLand, e = meter.Float64Histogram("gojito.bundle.time_to_land", metric.WithUnit("s"), metric.WithDescription("Time between bundle submission and landing."))
+  err = errors.Join(err, e)
+  r.inFlight, e = meter.Int64UpDownCounter("gojito.bundles.in_flight", metric.WithDescription("Bundles waiting for a terminal result."))
+  err = errors.Join(err, e)
+  r.tipAccounts, e = meter.Int64Counter("gojito.tip_accounts.fetches", metric.WithDescription("Tip account lookups."))
+  err = errors.Join(err, e)
+  r.tipLat, e = meter.Float64Histogram("gojito.tip_accounts.latency", metric.WithUnit("s"), metric.WithDescription("Latency of tip account lookups."))
+  err = errors.Join(err, e)
+  r.authRefresh, e = meter.Int64Counter("gojito.auth.refreshes", metric.WithDescription("Access token refreshes."))
+  err = errors.Join(err, e)
+  r.reconnects, e = meter.Int64Counter("gojito.grpc.reconnects", metric.WithDescription("gRPC reconnect attempts."))
+  err = errors.Join(err, e)
+  r.duplicates, e = meter.Int64Counter("gojito.duplicate_submissions", metric.WithDescription("Repeat submissions caught by the dedup guard."))
+  err = errors.Join(err, e)
+  
+  if err != nil{
+    return nil, err
+  }
+  return &r, nil
+}
+
+func (r *Recorder) Submitted(kind, outcome string, latency time.Duration){
+  ctx := context.Background()
+  r.submitted.Add(ctx, 1, metric.WithAttributes(attribute.String("kind", kind), attribute.String("outcome", outcome)))
+  r.submitLat.Record(ctx, latency.Seconds(), metric.WithAttributes(attribute.String("kind", kind)))
+}
+
+func (r *Recorder) BundleResult(outcome, reason string){
+  r.results.Add(context.Background(), 1, metric.WithAttributes(attribute.String("outcome", outcome), attribute.String("reason", reason)))
+}
+
+func (r *Recorder) Landed(timeToLand time.Duration){
+  r.timeToLand.Record(context.Background(), timeToLand.Seconds())
+}
+
+func (r *Recorder) InFlight(delta int){
+  r.inFlight.Add(context.Background(), int64(delta))
+}
+
+func (r *Recorder) TipAccountsFetched(outcome string, latency time.Duration){
+  ctx := context.Background()
+  r.tipAccounts.Add(ctx, 1, metric.WithAttributes(attribute.String("outcome", outcome)))
+  r.tipLat.Record(ctx, latency.Seconds())
+}
+
+func (r *Recorder) AuthRefreshed(outcome string){
+  r.authRefresh.Add(context.Background(), 1, metric.WithAttributes(attribute.String("outcome", outcome)))
+}
+
+func (r *Recorder) Reconnected(region string){
+  r.reconnects.Add(context.Background(), 1, metric.WithAttributes(attribute.String("region", region)))
+}
+
+func (r *Recorder) Deduplicated(kind, outcome string){
+  r.duplicates.Add(context.Background(), 1, metric.WithAttributes(attribute.String("kind", kind), attribute.String("outcome", outcome)))
+}
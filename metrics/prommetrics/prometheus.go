@@ -0,0 +1,128 @@
+// Package prommetrics implements metrics.Recorder on top of the Prometheus client library.
+package prommetrics
+import(
+  "time"
+  
+  "github.com/prometheus/client_golang/prometheus"
+  "github.com/scatkit/gojito/metrics"
+)
+
+// Recorder exports gojito measurements as Prometheus collectors.
+type Recorder struct{
+  submitted    *prometheus.CounterVec
+  submitLat    *prometheus.HistogramVec
+  results      *prometheus.CounterVec
+  timeToLand   prometheus.Histogram
+  inFlight     prometheus.Gauge
+  tipAccounts  *prometheus.CounterVec
+  tipLat       prometheus.Histogram
+  authRefresh  *prometheus.CounterVec
+  reconnects   *prometheus.CounterVec
+  duplicates   *prometheus.CounterVec
+}
+
+var _ metrics.Recorder = (*Recorder)(nil)
+
+// New creates a Recorder with metric names prefixed by namespace (e.g. "gojito") and registers it with reg.
+func New(namespace string, reg prometheus.Registerer) (*Recorder, error){
+  r := &Recorder{
+    submitted: prometheus.NewCounterVec(prometheus.CounterOpts{
+      Namespace: namespace,
+      Name:      "submissions_total",
+      Help:      "Bundles and transactions submitted to the block engine, by kind and outcome.",
+    }, []string{"kind", "outcome"}),
+    submitLat: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+      Namespace: namespace,
+      Name:      "submit_latency_seconds",
+      Help:      "Latency of SendBundle/sendTransaction calls.",
+      Buckets:   prometheus.ExponentialBuckets(0.005, 2, 12),
+    }, []string{"kind"}),
+    results: prometheus.NewCounterVec(prometheus.CounterOpts{
+      Namespace: namespace,
+      Name:      "bundle_results_total",
+      Help:      "Bundle result events by outcome and rejection/drop reason.",
+    }, []string{"outcome", "reason"}),
+    timeToLand: prometheus.NewHistogram(prometheus.HistogramOpts{
+      Namespace: namespace,
+      Name:      "time_to_land_seconds",
+      Help:      "Time between bundle submission and landing.",
+      Buckets:   prometheus.ExponentialBuckets(0.1, 2, 10),
+    }),
+    inFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+      Namespace: namespace,
+      Name:      "bundles_in_flight",
+      Help:      "Bundles waiting for a terminal result.",
+    }),
+    tipAccounts: prometheus.NewCounterVec(prometheus.CounterOpts{
+      Namespace: namespace,
+      Name:      "tip_account_fetches_total",
+      Help:      "Tip account lookups by outcome.",
+    }, []string{"outcome"}),
+    tipLat: prometheus.NewHistogram(prometheus.HistogramOpts{
+      Namespace: namespace,
+      Name:      "tip_account_fetch_latency_seconds",
+      Help:      "Latency of tip account lookups.",
+      Buckets:   prometheus.ExponentialBuckets(0.005, 2, 12),
+    }),
+    authRefresh: prometheus.NewCounterVec(prometheus.CounterOpts{
+      Namespace: namespace,
+      Name:      "auth_refreshes_total",
+      Help:      "Access token refreshes by outcome.",
+    }, []string{"outcome"}),
+    reconnects: prometheus.NewCounterVec(prometheus.CounterOpts{
+      Namespace: namespace,
+      Name:      "grpc_reconnects_total",
+      Help:      "gRPC reconnect attempts by block engine region.",
+    }, []string{"region"}),
+    duplicates: prometheus.NewCounterVec(prometheus.CounterOpts{
+      Namespace: namespace,
+      Name:      "duplicate_submissions_total",
+      Help:      "Repeat submissions caught by the dedup guard, by kind and outcome.",
+    }, []string{"kind", "outcome"}),
+  }
+  
+  collectors := []prometheus.Collector{
+    r.submitted, r.submitLat, r.results, r.timeToLand, r.inFlight,
+    r.tipAccounts, r.tipLat, r.authRefresh, r.reconnects, r.duplicates,
+  }
+  for _, c := range collectors{
+    if err := reg.Register(c); err != nil{
+      return nil, err
+    }
+  }
+  return r, nil
+}
+
+func (r *Recorder) Submitted(kind, outcome string, latency time.Duration){
+  r.submitted.WithLabelValues(kind, outcome).Inc()
+  r.submitLat.WithLabelValues(kind).Observe(latency.Seconds())
+}
+
+func (r *Recorder) BundleResult(outcome, reason string){
+  r.results.WithLabelValues(outcome, reason).Inc()
+}
+
+func (r *Recorder) Landed(timeToLand time.Duration){
+  r.timeToLand.Observe(timeToLand.Seconds())
+}
+
+func (r *Recorder) InFlight(delta int){
+  r.inFlight.Add(float64(delta))
+}
+
+func (r *Recorder) TipAccountsFetched(outcome string, latency time.Duration){
+  r.tipAccounts.WithLabelValues(outcome).Inc()
+  r.tipLat.Observe(latency.Seconds())
+}
+
+func (r *Recorder) AuthRefreshed(outcome string){
+  r.authRefresh.WithLabelValues(outcome).Inc()
+}
+
+func (r *Recorder) Reconnected(region string){
+  r.reconnects.WithLabelValues(region).Inc()
+}
+
+func (r *Recorder) Deduplicated(kind, outcome string){
+  r.duplicates.WithLabelValues(kind, outcome).Inc()
+}
@@ -29,7 +29,7 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type BlockEngineValidatorClient interface {
 	// / Validators can subscribe to the block engine to receive a stream of packets
-	SubscribePackets(ctx context.Context, in *SubscribePacketsRequest, opts ...grpc.CallOption) (BlockEngineValidator_SubscribePacketsClient, error)
+	SubscribePackets(ctx context.Context, in *SubscribePacketsRequestBlockEngine, opts ...grpc.CallOption) (BlockEngineValidator_SubscribePacketsClient, error)
 	// / Validators can subscribe to the block engine to receive a stream of simulated and profitable bundles
 	SubscribeBundles(ctx context.Context, in *SubscribeBundlesRequest, opts ...grpc.CallOption) (BlockEngineValidator_SubscribeBundlesClient, error)
 	// Block builders can optionally collect fees. This returns fee information if a block builder wants to
@@ -45,7 +45,7 @@ func NewBlockEngineValidatorClient(cc grpc.ClientConnInterface) BlockEngineValid
 	return &blockEngineValidatorClient{cc}
 }
 
-func (c *blockEngineValidatorClient) SubscribePackets(ctx context.Context, in *SubscribePacketsRequest, opts ...grpc.CallOption) (BlockEngineValidator_SubscribePacketsClient, error) {
+func (c *blockEngineValidatorClient) SubscribePackets(ctx context.Context, in *SubscribePacketsRequestBlockEngine, opts ...grpc.CallOption) (BlockEngineValidator_SubscribePacketsClient, error) {
 	stream, err := c.cc.NewStream(ctx, &BlockEngineValidator_ServiceDesc.Streams[0], BlockEngineValidator_SubscribePackets_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
@@ -61,7 +61,7 @@ func (c *blockEngineValidatorClient) SubscribePackets(ctx context.Context, in *S
 }
 
 type BlockEngineValidator_SubscribePacketsClient interface {
-	Recv() (*SubscribePacketsResponse, error)
+	Recv() (*SubscribePacketsResponseBlockEngine, error)
 	grpc.ClientStream
 }
 
@@ -69,8 +69,8 @@ type blockEngineValidatorSubscribePacketsClient struct {
 	grpc.ClientStream
 }
 
-func (x *blockEngineValidatorSubscribePacketsClient) Recv() (*SubscribePacketsResponse, error) {
-	m := new(SubscribePacketsResponse)
+func (x *blockEngineValidatorSubscribePacketsClient) Recv() (*SubscribePacketsResponseBlockEngine, error) {
+	m := new(SubscribePacketsResponseBlockEngine)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
@@ -123,7 +123,7 @@ func (c *blockEngineValidatorClient) GetBlockBuilderFeeInfo(ctx context.Context,
 // for forward compatibility
 type BlockEngineValidatorServer interface {
 	// / Validators can subscribe to the block engine to receive a stream of packets
-	SubscribePackets(*SubscribePacketsRequest, BlockEngineValidator_SubscribePacketsServer) error
+	SubscribePackets(*SubscribePacketsRequestBlockEngine, BlockEngineValidator_SubscribePacketsServer) error
 	// / Validators can subscribe to the block engine to receive a stream of simulated and profitable bundles
 	SubscribeBundles(*SubscribeBundlesRequest, BlockEngineValidator_SubscribeBundlesServer) error
 	// Block builders can optionally collect fees. This returns fee information if a block builder wants to
@@ -136,7 +136,7 @@ type BlockEngineValidatorServer interface {
 type UnimplementedBlockEngineValidatorServer struct {
 }
 
-func (UnimplementedBlockEngineValidatorServer) SubscribePackets(*SubscribePacketsRequest, BlockEngineValidator_SubscribePacketsServer) error {
+func (UnimplementedBlockEngineValidatorServer) SubscribePackets(*SubscribePacketsRequestBlockEngine, BlockEngineValidator_SubscribePacketsServer) error {
 	return status.Errorf(codes.Unimplemented, "method SubscribePackets not implemented")
 }
 func (UnimplementedBlockEngineValidatorServer) SubscribeBundles(*SubscribeBundlesRequest, BlockEngineValidator_SubscribeBundlesServer) error {
@@ -159,7 +159,7 @@ func RegisterBlockEngineValidatorServer(s grpc.ServiceRegistrar, srv BlockEngine
 }
 
 func _BlockEngineValidator_SubscribePackets_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(SubscribePacketsRequest)
+	m := new(SubscribePacketsRequestBlockEngine)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
@@ -167,7 +167,7 @@ func _BlockEngineValidator_SubscribePackets_Handler(srv interface{}, stream grpc
 }
 
 type BlockEngineValidator_SubscribePacketsServer interface {
-	Send(*SubscribePacketsResponse) error
+	Send(*SubscribePacketsResponseBlockEngine) error
 	grpc.ServerStream
 }
 
@@ -175,7 +175,7 @@ type blockEngineValidatorSubscribePacketsServer struct {
 	grpc.ServerStream
 }
 
-func (x *blockEngineValidatorSubscribePacketsServer) Send(m *SubscribePacketsResponse) error {
+func (x *blockEngineValidatorSubscribePacketsServer) Send(m *SubscribePacketsResponseBlockEngine) error {
 	return x.ServerStream.SendMsg(m)
 }
 
@@ -60,7 +60,7 @@ func (x *HeartbeatShredStream) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Heartbeat.ProtoReflect.Descriptor instead.
+// Deprecated: Use HeartbeatShredStream.ProtoReflect.Descriptor instead.
 func (*HeartbeatShredStream) Descriptor() ([]byte, []int) {
 	return file_shredstream_proto_rawDescGZIP(), []int{0}
 }
@@ -132,21 +132,23 @@ var File_shredstream_proto protoreflect.FileDescriptor
 var file_shredstream_proto_rawDesc = []byte{
 	0x0a, 0x11, 0x73, 0x68, 0x72, 0x65, 0x64, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x73, 0x68, 0x72, 0x65, 0x64, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d,
-	0x1a, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x4d,
-	0x0a, 0x09, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x12, 0x26, 0x0a, 0x06, 0x73,
-	0x6f, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x73, 0x68,
-	0x61, 0x72, 0x65, 0x64, 0x2e, 0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x06, 0x73, 0x6f, 0x63,
-	0x6b, 0x65, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02,
-	0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x2a, 0x0a,
-	0x11, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x74, 0x74, 0x6c, 0x5f, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x05, 0x74, 0x74, 0x6c, 0x4d, 0x73, 0x32, 0x58, 0x0a, 0x0b, 0x53, 0x68, 0x72,
-	0x65, 0x64, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x49, 0x0a, 0x0d, 0x53, 0x65, 0x6e, 0x64,
-	0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x12, 0x16, 0x2e, 0x73, 0x68, 0x72, 0x65,
-	0x64, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x2e, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61,
-	0x74, 0x1a, 0x1e, 0x2e, 0x73, 0x68, 0x72, 0x65, 0x64, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x2e,
-	0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x00, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x1a, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x58,
+	0x0a, 0x14, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x53, 0x68, 0x72, 0x65, 0x64,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x26, 0x0a, 0x06, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e,
+	0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x06, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x18,
+	0x0a, 0x07, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x07, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x2a, 0x0a, 0x11, 0x48, 0x65, 0x61, 0x72,
+	0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x15, 0x0a,
+	0x06, 0x74, 0x74, 0x6c, 0x5f, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x74,
+	0x74, 0x6c, 0x4d, 0x73, 0x32, 0x63, 0x0a, 0x0b, 0x53, 0x68, 0x72, 0x65, 0x64, 0x73, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x12, 0x54, 0x0a, 0x0d, 0x53, 0x65, 0x6e, 0x64, 0x48, 0x65, 0x61, 0x72, 0x74,
+	0x62, 0x65, 0x61, 0x74, 0x12, 0x21, 0x2e, 0x73, 0x68, 0x72, 0x65, 0x64, 0x73, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x2e, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x53, 0x68, 0x72, 0x65,
+	0x64, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x1a, 0x1e, 0x2e, 0x73, 0x68, 0x72, 0x65, 0x64, 0x73,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x2e, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -163,13 +165,13 @@ func file_shredstream_proto_rawDescGZIP() []byte {
 
 var file_shredstream_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_shredstream_proto_goTypes = []interface{}{
-	(*Heartbeat)(nil),         // 0: shredstream.Heartbeat
-	(*HeartbeatResponse)(nil), // 1: shredstream.HeartbeatResponse
-	(*Socket)(nil),            // 2: shared.Socket
+	(*HeartbeatShredStream)(nil), // 0: shredstream.HeartbeatShredStream
+	(*HeartbeatResponse)(nil),    // 1: shredstream.HeartbeatResponse
+	(*Socket)(nil),               // 2: shared.Socket
 }
 var file_shredstream_proto_depIdxs = []int32{
-	2, // 0: shredstream.Heartbeat.socket:type_name -> shared.Socket
-	0, // 1: shredstream.Shredstream.SendHeartbeat:input_type -> shredstream.Heartbeat
+	2, // 0: shredstream.HeartbeatShredStream.socket:type_name -> shared.Socket
+	0, // 1: shredstream.Shredstream.SendHeartbeat:input_type -> shredstream.HeartbeatShredStream
 	1, // 2: shredstream.Shredstream.SendHeartbeat:output_type -> shredstream.HeartbeatResponse
 	2, // [2:3] is the sub-list for method output_type
 	1, // [1:2] is the sub-list for method input_type
@@ -186,7 +188,7 @@ func file_shredstream_proto_init() {
 	file_shared_proto_init()
 	if !protoimpl.UnsafeEnabled {
 		file_shredstream_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Heartbeat); i {
+			switch v := v.(*HeartbeatShredStream); i {
 			case 0:
 				return &v.state
 			case 1:
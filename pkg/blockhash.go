@@ -0,0 +1,222 @@
+package pkg
+import(
+  "context"
+  "errors"
+  "log/slog"
+  "sync"
+  "time"
+
+  "github.com/scatkit/pumpdexer/rpc"
+  "github.com/scatkit/pumpdexer/solana"
+)
+
+// Blockhash is a recent blockhash with the last block height at which transactions using it can be processed.
+type Blockhash struct{
+  Hash                 solana.Hash
+  LastValidBlockHeight uint64
+  FetchedAt            time.Time
+}
+
+// BlockhashProvider prefetches the latest blockhash and the current block height in the background, so that
+// transactions are built without a round trip and bundles expire by block height rather than by wall clock.
+type BlockhashProvider struct{
+  RPC             *rpc.Client
+  Commitment      rpc.CommitmentType // of the blockhashes handed out, default confirmed
+  RefreshInterval time.Duration      // default 2s, about 5 slots
+  Logger          *slog.Logger       // nil discards all records
+
+  mu        sync.RWMutex
+  latest    *Blockhash
+  height    uint64
+  heightAt  time.Time
+  known     map[solana.Hash]uint64 // last valid block height of every blockhash handed out and still valid
+}
+
+func NewBlockhashProvider(rpcClient *rpc.Client) *BlockhashProvider{
+  return &BlockhashProvider{
+    RPC:             rpcClient,
+    Commitment:      rpc.CommitmentConfirmed,
+    RefreshInterval: 2 * time.Second,
+    Logger:          NopLogger(),
+    known:           make(map[solana.Hash]uint64),
+  }
+}
+
+// Start fetches the latest blockhash, then keeps refreshing it and the block height until ctx is done.
+func (p *BlockhashProvider) Start(ctx context.Context) error{
+  if err := p.Refresh(ctx); err != nil{
+    return err
+  }
+  go func(){
+    ticker := time.NewTicker(p.refreshInterval())
+    defer ticker.Stop()
+    for{
+      select{
+      case <-ctx.Done():
+        return
+      case <-ticker.C:
+        if err := p.Refresh(ctx); err != nil && ctx.Err() == nil{
+          p.logger().Warn("blockhash refresh failed", slog.Any("error", err))
+        }
+      }
+    }
+  }()
+  return nil
+}
+
+// Refresh fetches the latest blockhash and the current block height.
+func (p *BlockhashProvider) Refresh(ctx context.Context) error{
+  resp, err := p.RPC.GetLatestBlockhash(ctx, p.commitment())
+  if err != nil{
+    return err
+  }
+  if resp == nil || resp.Value == nil{
+    return errors.New("getLatestBlockhash returned no blockhash")
+  }
+  height, heightErr := p.fetchBlockHeight(ctx)
+
+  p.mu.Lock()
+  defer p.mu.Unlock()
+  now := time.Now()
+  if p.known == nil{
+    p.known = make(map[solana.Hash]uint64)
+  }
+  p.latest = &Blockhash{Hash: resp.Value.Blockhash, LastValidBlockHeight: resp.Value.LastValueBlockHeight, FetchedAt: now}
+  p.known[resp.Value.Blockhash] = resp.Value.LastValueBlockHeight
+  if heightErr == nil{
+    p.height, p.heightAt = height, now
+    for hash, lastValid := range p.known{
+      if height > lastValid{
+        delete(p.known, hash)
+      }
+    }
+  }
+  p.logger().Debug("blockhash refreshed",
+    slog.String("blockhash", resp.Value.Blockhash.String()),
+    slog.Uint64("last_valid_block_height", resp.Value.LastValueBlockHeight),
+    slog.Uint64("block_height", p.height),
+  )
+  return heightErr
+}
+
+// Latest returns the cached blockhash, fetching it if the provider wasn't started or its refreshes are failing.
+func (p *BlockhashProvider) Latest(ctx context.Context) (*Blockhash, error){
+  p.mu.RLock()
+  latest := p.latest
+  p.mu.RUnlock()
+  if latest != nil && time.Since(latest.FetchedAt) < 2*p.refreshInterval(){
+    return latest, nil
+  }
+  if err := p.Refresh(ctx); err != nil && !p.hasLatest(){
+    return nil, err
+  }
+  p.mu.RLock()
+  defer p.mu.RUnlock()
+  return p.latest, nil
+}
+
+func (p *BlockhashProvider) hasLatest() bool{
+  p.mu.RLock()
+  defer p.mu.RUnlock()
+  return p.latest != nil
+}
+
+// LastValidBlockHeight returns the last valid block height of a blockhash handed out by the provider.
+func (p *BlockhashProvider) LastValidBlockHeight(hash solana.Hash) (uint64, bool){
+  p.mu.RLock()
+  defer p.mu.RUnlock()
+  lastValid, ok := p.known[hash]
+  return lastValid, ok
+}
+
+// BlockHeight returns the current block height, cached for one refresh interval.
+func (p *BlockhashProvider) BlockHeight(ctx context.Context) (uint64, error){
+  p.mu.RLock()
+  height, at := p.height, p.heightAt
+  p.mu.RUnlock()
+  if !at.IsZero() && time.Since(at) < p.refreshInterval(){
+    return height, nil
+  }
+  height, err := p.fetchBlockHeight(ctx)
+  if err != nil{
+    return 0, err
+  }
+  p.mu.Lock()
+  if height > p.height{
+    p.height, p.heightAt = height, time.Now()
+  }
+  p.mu.Unlock()
+  return height, nil
+}
+
+// Expired is BlockhashExpired with the provider's cached block height and the last valid block height of the
+// blockhashes it handed out. It also returns that last valid block height, 0 when hash wasn't handed out.
+func (p *BlockhashProvider) Expired(ctx context.Context, hash solana.Hash) (expired bool, lastValid, height uint64, err error){
+  lastValid, _ = p.LastValidBlockHeight(hash)
+  expired, height, err = BlockhashExpired(ctx, p.RPC, hash, lastValid, p.BlockHeight)
+  return expired, lastValid, height, err
+}
+
+// BlockhashExpired reports whether transactions using hash can no longer be processed. A known
+// lastValidBlockHeight is compared with the block height read by blockHeight (getBlockHeight when nil), which is
+// returned as well; otherwise the node is asked with isBlockhashValid and the returned height is 0.
+//
+// Check the expiry before the signature statuses of a bundle: one whose blockhash had expired by then and whose
+// signatures are still unknown afterwards can't land anymore, while one landing meanwhile shows up in the statuses.
+func BlockhashExpired(ctx context.Context, client *rpc.Client, hash solana.Hash, lastValidBlockHeight uint64,
+  blockHeight func(context.Context) (uint64, error),
+) (bool, uint64, error){
+  if lastValidBlockHeight != 0{
+    if blockHeight == nil{
+      blockHeight = func(ctx context.Context) (uint64, error){
+        var height uint64
+        params := []interface{}{map[string]any{"commitment": rpc.CommitmentProcessed}}
+        err := client.RPCCallForInfo(ctx, &height, "getBlockHeight", params)
+        return height, err
+      }
+    }
+    height, err := blockHeight(ctx)
+    if err != nil{
+      return false, 0, err
+    }
+    return height > lastValidBlockHeight, height, nil
+  }
+  var out struct{
+    Value bool `json:"value"`
+  }
+  params := []interface{}{hash.String(), map[string]any{"commitment": rpc.CommitmentProcessed}}
+  if err := client.RPCCallForInfo(ctx, &out, "isBlockhashValid", params); err != nil{
+    return false, 0, err
+  }
+  return !out.Value, 0, nil
+}
+
+func (p *BlockhashProvider) fetchBlockHeight(ctx context.Context) (uint64, error){
+  var height uint64
+  params := []interface{}{map[string]any{"commitment": p.commitment()}}
+  err := p.RPC.RPCCallForInfo(ctx, &height, "getBlockHeight", params)
+  return height, err
+}
+
+// The defaults below apply to providers built as struct literals rather than with NewBlockhashProvider.
+
+func (p *BlockhashProvider) refreshInterval() time.Duration{
+  if p.RefreshInterval <= 0{
+    return 2 * time.Second
+  }
+  return p.RefreshInterval
+}
+
+func (p *BlockhashProvider) commitment() rpc.CommitmentType{
+  if p.Commitment == ""{
+    return rpc.CommitmentConfirmed
+  }
+  return p.Commitment
+}
+
+func (p *BlockhashProvider) logger() *slog.Logger{
+  if p.Logger == nil{
+    return NopLogger()
+  }
+  return p.Logger
+}
@@ -0,0 +1,164 @@
+package pkg
+import(
+  "encoding/binary"
+  "errors"
+  "fmt"
+
+  "github.com/scatkit/pumpdexer/solana"
+)
+
+var ComputeBudgetProgramID = solana.MustPubkeyFromBase58("ComputeBudget111111111111111111111111111111")
+
+const (
+  // MaxComputeUnitLimit is the maximum number of compute units a transaction can request.
+  MaxComputeUnitLimit = 1_400_000
+  // PacketDataSize is the maximum size of a serialized transaction.
+  PacketDataSize = 1232
+  // ComputeBudgetInstructionUnits is the cost of executing a single compute budget instruction.
+  ComputeBudgetInstructionUnits = 150
+
+  setComputeUnitLimit = 2
+  setComputeUnitPrice = 3
+)
+
+// computeBudgetInstruction implements solana.Instruction; compute budget instructions take no accounts.
+type computeBudgetInstruction struct{
+  data []byte
+}
+
+func (inst *computeBudgetInstruction) ProgramID() solana.PublicKey{ return ComputeBudgetProgramID }
+func (inst *computeBudgetInstruction) Accounts() []*solana.AccountMeta{ return nil }
+func (inst *computeBudgetInstruction) Data() ([]byte, error){ return inst.data, nil }
+
+func setComputeUnitLimitData(units uint32) []byte{
+  data := make([]byte, 5)
+  data[0] = setComputeUnitLimit
+  binary.LittleEndian.PutUint32(data[1:], units)
+  return data
+}
+
+func setComputeUnitPriceData(microLamports uint64) []byte{
+  data := make([]byte, 9)
+  data[0] = setComputeUnitPrice
+  binary.LittleEndian.PutUint64(data[1:], microLamports)
+  return data
+}
+
+func NewSetComputeUnitLimitInstruction(units uint32) solana.Instruction{
+  return &computeBudgetInstruction{data: setComputeUnitLimitData(units)}
+}
+
+// NewSetComputeUnitPriceInstruction sets the priority fee in micro-lamports per compute unit.
+func NewSetComputeUnitPriceInstruction(microLamports uint64) solana.Instruction{
+  return &computeBudgetInstruction{data: setComputeUnitPriceData(microLamports)}
+}
+
+// PriorityFeeLamports returns the priority fee paid for a compute unit limit at the given price.
+func PriorityFeeLamports(units uint32, microLamports uint64) uint64{
+  return (uint64(units)*microLamports + 999_999) / 1_000_000
+}
+
+// ComputeBudget returns the compute unit limit and price requested by tx (zero when not set).
+func ComputeBudget(tx *solana.Transaction) (units uint32, microLamports uint64){
+  keys := tx.Message.AccountKeys
+  for _, inst := range tx.Message.Instructions{
+    if int(inst.ProgramIDIndex) >= len(keys) || !keys[inst.ProgramIDIndex].Equals(ComputeBudgetProgramID) || len(inst.Data) == 0{
+      continue
+    }
+    switch{
+    case inst.Data[0] == setComputeUnitLimit && len(inst.Data) == 5:
+      units = binary.LittleEndian.Uint32(inst.Data[1:])
+    case inst.Data[0] == setComputeUnitPrice && len(inst.Data) == 9:
+      microLamports = binary.LittleEndian.Uint64(inst.Data[1:])
+    }
+  }
+  return units, microLamports
+}
+
+// SetComputeBudget replaces the compute unit limit (and the price, if microLamports is not nil) of a compiled
+// transaction by rewriting its message in place. The signatures are cleared and the transaction must be re-signed.
+// Messages with resolved address table lookups are not supported.
+func SetComputeBudget(tx *solana.Transaction, units uint32, microLamports *uint64) error{
+  if units > MaxComputeUnitLimit{
+    return fmt.Errorf("compute unit limit %d exceeds %d", units, MaxComputeUnitLimit)
+  }
+  msg := &tx.Message
+
+  programIndex := -1
+  for i, key := range msg.AccountKeys{
+    if key.Equals(ComputeBudgetProgramID){
+      programIndex = i
+      break
+    }
+  }
+  if programIndex < 0{
+    // Readonly unsigned accounts come last among the static keys, so the program can be appended.
+    // Indexes past the static keys point into address table lookups and shift by one.
+    if msg.Header.NumReadonlyUnsignedAccounts == 255{
+      return errors.New("too many readonly accounts")
+    }
+    static := uint16(len(msg.AccountKeys))
+    for i := range msg.Instructions{
+      inst := &msg.Instructions[i]
+      if inst.ProgramIDIndex >= static{
+        inst.ProgramIDIndex++
+      }
+      for j := range inst.Accounts{
+        if inst.Accounts[j] >= static{
+          inst.Accounts[j]++
+        }
+      }
+    }
+    msg.AccountKeys = append(msg.AccountKeys, ComputeBudgetProgramID)
+    msg.Header.NumReadonlyUnsignedAccounts++
+    programIndex = len(msg.AccountKeys) - 1
+  }
+
+  // drop the instructions being replaced, keep everything else in order
+  instructions := make([]solana.CompiledInstruction, 0, len(msg.Instructions)+2)
+  budget := []solana.CompiledInstruction{{ProgramIDIndex: uint16(programIndex), Data: setComputeUnitLimitData(units)}}
+  if microLamports != nil{
+    budget = append(budget, solana.CompiledInstruction{ProgramIDIndex: uint16(programIndex), Data: setComputeUnitPriceData(*microLamports)})
+  }
+  for _, inst := range msg.Instructions{
+    if int(inst.ProgramIDIndex) == programIndex && len(inst.Data) > 0{
+      if inst.Data[0] == setComputeUnitLimit || (microLamports != nil && inst.Data[0] == setComputeUnitPrice){
+        continue
+      }
+    }
+    instructions = append(instructions, inst)
+  }
+  msg.Instructions = append(budget, instructions...)
+  tx.Signatures = nil
+  return nil
+}
+
+// CheckPacketSize returns an error if the serialized transaction doesn't fit in a single packet.
+func CheckPacketSize(tx *solana.Transaction) error{
+  data, err := tx.MarshalBinary()
+  if err != nil{
+    return err
+  }
+  if len(data) > PacketDataSize{
+    return fmt.Errorf("transaction is %d bytes, exceeds the %d byte packet limit", len(data), PacketDataSize)
+  }
+  return nil
+}
+
+// CloneTransaction returns a deep copy of tx's signatures, account keys and instructions.
+func CloneTransaction(tx *solana.Transaction) *solana.Transaction{
+  out := &solana.Transaction{
+    Signatures: append([]solana.Signature(nil), tx.Signatures...),
+    Message:    tx.Message,
+  }
+  out.Message.AccountKeys = append(solana.PublicKeySlice(nil), tx.Message.AccountKeys...)
+  out.Message.Instructions = make([]solana.CompiledInstruction, 0, len(tx.Message.Instructions))
+  for _, inst := range tx.Message.Instructions{
+    out.Message.Instructions = append(out.Message.Instructions, solana.CompiledInstruction{
+      ProgramIDIndex: inst.ProgramIDIndex,
+      Accounts:       append([]uint16(nil), inst.Accounts...),
+      Data:           append(solana.Base58(nil), inst.Data...),
+    })
+  }
+  return out
+}
@@ -0,0 +1,171 @@
+package pkg
+import(
+  "bytes"
+  "testing"
+
+  "github.com/scatkit/pumpdexer/programs/system"
+  "github.com/scatkit/pumpdexer/solana"
+)
+
+var (
+  budgetPayer    = solana.NewWallet().PublicKey()
+  budgetCosigner = solana.NewWallet().PublicKey()
+  budgetDest     = solana.NewWallet().PublicKey()
+  budgetMint     = solana.NewWallet().PublicKey()
+  budgetProgram  = solana.NewWallet().PublicKey()
+  budgetSystem   = solana.MustPubkeyFromBase58("11111111111111111111111111111111")
+)
+
+// signed gives tx a placeholder signature per required signer.
+func signed(tx *solana.Transaction) *solana.Transaction{
+  tx.Signatures = make([]solana.Signature, tx.Message.Header.NumRequiredSignatures)
+  for i := range tx.Signatures{
+    tx.Signatures[i][0] = byte(i + 1)
+  }
+  return tx
+}
+
+func assertInstruction(t *testing.T, got solana.CompiledInstruction, program uint16, accounts []uint16, data []byte){
+  t.Helper()
+  if got.ProgramIDIndex != program{
+    t.Errorf("program index %d, want %d", got.ProgramIDIndex, program)
+  }
+  if len(got.Accounts) != len(accounts){
+    t.Fatalf("accounts %v, want %v", got.Accounts, accounts)
+  }
+  for i := range accounts{
+    if got.Accounts[i] != accounts[i]{
+      t.Fatalf("accounts %v, want %v", got.Accounts, accounts)
+    }
+  }
+  if !bytes.Equal(got.Data, data){
+    t.Errorf("data %x, want %x", []byte(got.Data), data)
+  }
+}
+
+func TestSetComputeBudgetAddsProgram(t *testing.T){
+  transfer := solana.CompiledInstruction{ProgramIDIndex: 2, Accounts: []uint16{0, 1}, Data: []byte{2, 0, 0, 0, 1}}
+  tx := signed(&solana.Transaction{Message: solana.Message{
+    AccountKeys:  solana.PublicKeySlice{budgetPayer, budgetDest, budgetSystem},
+    Header:       solana.MessageHeader{NumRequiredSignatures: 1, NumReadonlyUnsignedAccounts: 1},
+    Instructions: []solana.CompiledInstruction{transfer},
+  }})
+  price := uint64(7)
+
+  if err := SetComputeBudget(tx, 200_000, &price); err != nil{
+    t.Fatal(err)
+  }
+  keys := tx.Message.AccountKeys
+  if len(keys) != 4 || !keys[3].Equals(ComputeBudgetProgramID){
+    t.Fatalf("account keys %v, want the compute budget program appended", keys)
+  }
+  if h := tx.Message.Header; h.NumRequiredSignatures != 1 || h.NumReadonlySignedAccounts != 0 || h.NumReadonlyUnsignedAccounts != 2{
+    t.Errorf("header %+v, want the program counted as readonly unsigned", h)
+  }
+  if len(tx.Message.Instructions) != 3{
+    t.Fatalf("%d instructions, want 3", len(tx.Message.Instructions))
+  }
+  assertInstruction(t, tx.Message.Instructions[0], 3, nil, setComputeUnitLimitData(200_000))
+  assertInstruction(t, tx.Message.Instructions[1], 3, nil, setComputeUnitPriceData(7))
+  assertInstruction(t, tx.Message.Instructions[2], 2, []uint16{0, 1}, transfer.Data)
+  if units, micro := ComputeBudget(tx); units != 200_000 || micro != 7{
+    t.Errorf("budget %d at %d, want 200000 at 7", units, micro)
+  }
+  if tx.Signatures != nil{
+    t.Error("signatures not cleared")
+  }
+}
+
+func TestSetComputeBudgetReplacesExistingInstructions(t *testing.T){
+  tx, err := solana.NewTransaction(
+    []solana.Instruction{
+      NewSetComputeUnitLimitInstruction(1_000),
+      NewSetComputeUnitPriceInstruction(5),
+      system.NewTransferInstruction(1, budgetPayer, budgetDest).Build(),
+    },
+    solana.Hash{1},
+    solana.TransactionPayer(budgetPayer),
+  )
+  if err != nil{
+    t.Fatal(err)
+  }
+  signed(tx)
+  keys := append(solana.PublicKeySlice(nil), tx.Message.AccountKeys...)
+  header := tx.Message.Header
+  program := tx.Message.Instructions[0].ProgramIDIndex
+  transfer := tx.Message.Instructions[2]
+
+  // without a price only the limit is replaced
+  if err = SetComputeBudget(tx, 300_000, nil); err != nil{
+    t.Fatal(err)
+  }
+  if len(tx.Message.AccountKeys) != len(keys) || tx.Message.Header != header{
+    t.Fatalf("account keys %v with header %+v changed, the program was already present", tx.Message.AccountKeys, tx.Message.Header)
+  }
+  if len(tx.Message.Instructions) != 3{
+    t.Fatalf("%d instructions, want 3", len(tx.Message.Instructions))
+  }
+  assertInstruction(t, tx.Message.Instructions[0], program, nil, setComputeUnitLimitData(300_000))
+  assertInstruction(t, tx.Message.Instructions[1], program, nil, setComputeUnitPriceData(5))
+  assertInstruction(t, tx.Message.Instructions[2], transfer.ProgramIDIndex, transfer.Accounts, transfer.Data)
+  if tx.Signatures != nil{
+    t.Error("signatures not cleared")
+  }
+
+  signed(tx)
+  price := uint64(9)
+  if err = SetComputeBudget(tx, 400_000, &price); err != nil{
+    t.Fatal(err)
+  }
+  if len(tx.Message.Instructions) != 3{
+    t.Fatalf("%d instructions, want 3", len(tx.Message.Instructions))
+  }
+  assertInstruction(t, tx.Message.Instructions[0], program, nil, setComputeUnitLimitData(400_000))
+  assertInstruction(t, tx.Message.Instructions[1], program, nil, setComputeUnitPriceData(9))
+  assertInstruction(t, tx.Message.Instructions[2], transfer.ProgramIDIndex, transfer.Accounts, transfer.Data)
+  if units, micro := ComputeBudget(tx); units != 400_000 || micro != 9{
+    t.Errorf("budget %d at %d, want 400000 at 9", units, micro)
+  }
+  if tx.Signatures != nil{
+    t.Error("signatures not cleared")
+  }
+}
+
+func TestSetComputeBudgetKeepsKeyOrdering(t *testing.T){
+  // writable signer, readonly signer, writable, then the readonly unsigned mint and program; indexes 5 and 6
+  // point into address table lookups
+  keys := solana.PublicKeySlice{budgetPayer, budgetCosigner, budgetDest, budgetMint, budgetProgram}
+  tx := signed(&solana.Transaction{Message: solana.Message{
+    AccountKeys: append(solana.PublicKeySlice(nil), keys...),
+    Header:      solana.MessageHeader{NumRequiredSignatures: 2, NumReadonlySignedAccounts: 1, NumReadonlyUnsignedAccounts: 2},
+    Instructions: []solana.CompiledInstruction{
+      {ProgramIDIndex: 4, Accounts: []uint16{0, 1, 2, 3, 5, 6}, Data: []byte{1}},
+      {ProgramIDIndex: 4, Accounts: []uint16{6, 3}, Data: []byte{2}},
+    },
+  }})
+
+  if err := SetComputeBudget(tx, 50_000, nil); err != nil{
+    t.Fatal(err)
+  }
+  msg := tx.Message
+  if len(msg.AccountKeys) != 6 || !msg.AccountKeys[5].Equals(ComputeBudgetProgramID){
+    t.Fatalf("account keys %v, want the compute budget program after the static keys", msg.AccountKeys)
+  }
+  for i, key := range keys{
+    if !msg.AccountKeys[i].Equals(key){
+      t.Errorf("account key %d moved", i)
+    }
+  }
+  if h := msg.Header; h.NumRequiredSignatures != 2 || h.NumReadonlySignedAccounts != 1 || h.NumReadonlyUnsignedAccounts != 3{
+    t.Errorf("header %+v, want 2 signers, 1 readonly signer and 3 readonly unsigned", h)
+  }
+  if len(msg.Instructions) != 3{
+    t.Fatalf("%d instructions, want 3", len(msg.Instructions))
+  }
+  assertInstruction(t, msg.Instructions[0], 5, nil, setComputeUnitLimitData(50_000))
+  assertInstruction(t, msg.Instructions[1], 4, []uint16{0, 1, 2, 3, 6, 7}, []byte{1})
+  assertInstruction(t, msg.Instructions[2], 4, []uint16{7, 3}, []byte{2})
+  if tx.Signatures != nil{
+    t.Error("signatures not cleared")
+  }
+}
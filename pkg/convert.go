@@ -1,5 +1,8 @@
 package pkg
 import(
+  "fmt"
+
+  bin "github.com/gagliardetto/binary"
   "github.com/scatkit/pumpdexer/solana"
   "github.com/scatkit/gojito/pb"
 )
@@ -21,3 +24,19 @@ func ConvertTransactionToProtobufPacket(transaction *solana.Transaction) (jito_p
     },
   }, nil
 }
+
+// Converts a pb.Packet back to a pumpdexer's `solana.Transaction`. Only the first Meta.Size bytes are decoded.
+func ConvertProtobufPacketToTransaction(packet *jito_pb.Packet) (*solana.Transaction, error){
+  data := packet.GetData()
+  if size := packet.GetMeta().GetSize(); size > 0{
+    if size > uint64(len(data)){
+      return nil, fmt.Errorf("packet size %d exceeds its %d bytes of data", size, len(data))
+    }
+    data = data[:size]
+  }
+  tx := new(solana.Transaction)
+  if err := tx.UnmarshalWithDecoder(bin.NewBinDecoder(data)); err != nil{
+    return nil, err
+  }
+  return tx, nil
+}
@@ -9,6 +9,16 @@ import (
 
 // CreateAndObserveGRPCConn creates a new gRPC connection and observes its conn status.
 func CreateAndObserveGRPCConn(ctx context.Context, chErr chan error, target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	return CreateAndObserveGRPCConnWithHook(ctx, chErr, nil, target, opts...)
+}
+
+// CreateAndObserveGRPCConnWithHook is CreateAndObserveGRPCConn with onReconnect invoked on every reconnect attempt,
+// i.e. every transition into Connecting after a TransientFailure or Shutdown. The initial connect and idle
+// reconnects aren't counted.
+func CreateAndObserveGRPCConnWithHook(ctx context.Context, chErr chan error, onReconnect func(), target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	if onReconnect == nil {
+		onReconnect = func() {}
+	}
 	conn, err := grpc.NewClient(target, opts...)
 	if err != nil {
 		return nil, err
@@ -16,6 +26,7 @@ func CreateAndObserveGRPCConn(ctx context.Context, chErr chan error, target stri
 	
 	go func() {
 		var retries int
+		var failed bool // the last state seen other than Idle was TransientFailure or Shutdown
 		for {
 			select {
 			case <-ctx.Done():
@@ -25,6 +36,17 @@ func CreateAndObserveGRPCConn(ctx context.Context, chErr chan error, target stri
 				return
 			default:
 				state := conn.GetState()
+				switch state {
+				case connectivity.Connecting, connectivity.Ready, connectivity.TransientFailure:
+					// a failed connection only leaves TransientFailure through Connecting, even when it
+					// went by between two polls
+					if failed {
+						onReconnect()
+					}
+					failed = state == connectivity.TransientFailure
+				case connectivity.Shutdown:
+					failed = true
+				}
 				if state == connectivity.Ready {
 					retries = 0
 					time.Sleep(1 * time.Second)
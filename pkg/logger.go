@@ -0,0 +1,39 @@
+package pkg
+import(
+  "context"
+  "log/slog"
+  "strings"
+)
+
+// NopLogger returns a logger that drops every record. Clients use it until a logger is injected.
+func NopLogger() *slog.Logger{
+  return slog.New(discardHandler{})
+}
+
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
+// RegionFromURL extracts the block engine region from a dial URL or endpoint,
+// e.g. "ny" from "ny.mainnet.block-engine.jito.wtf:443". Returns "" for the global endpoint.
+func RegionFromURL(endpoint string) string{
+  host := endpoint
+  if i := strings.Index(host, "://"); i >= 0{
+    host = host[i+3:]
+  }
+  if i := strings.IndexAny(host, ":/"); i >= 0{
+    host = host[:i]
+  }
+  
+  labels := strings.Split(host, ".")
+  if len(labels) < 2 || labels[0] == "mainnet" || labels[0] == "testnet"{
+    return ""
+  }
+  if labels[1] != "mainnet" && labels[1] != "testnet"{
+    return ""
+  }
+  return labels[0]
+}
@@ -0,0 +1,44 @@
+package pkg
+import(
+  "encoding/binary"
+  
+  "github.com/scatkit/pumpdexer/solana"
+)
+
+var SystemProgramID = solana.MustPubkeyFromBase58("11111111111111111111111111111111")
+
+const systemTransferInstruction = 2
+
+// TipLamports sums the lamports transferred to any of tipAccounts by System Program transfers in txns.
+func TipLamports(txns []*solana.Transaction, tipAccounts []string) uint64{
+  if len(tipAccounts) == 0{
+    return 0
+  }
+  tips := make(map[solana.PublicKey]struct{}, len(tipAccounts))
+  for _, acc := range tipAccounts{
+    if pubkey, err := solana.PublicKeyFromBase58(acc); err == nil{
+      tips[pubkey] = struct{}{}
+    }
+  }
+  
+  var total uint64
+  for _, tx := range txns{
+    keys := tx.Message.AccountKeys
+    for _, inst := range tx.Message.Instructions{
+      if int(inst.ProgramIDIndex) >= len(keys) || !keys[inst.ProgramIDIndex].Equals(SystemProgramID){
+        continue
+      }
+      // Transfer: u32 instruction index, u64 lamports; accounts: [from, to]
+      if len(inst.Data) != 12 || binary.LittleEndian.Uint32(inst.Data[:4]) != systemTransferInstruction || len(inst.Accounts) != 2{
+        continue
+      }
+      if int(inst.Accounts[1]) >= len(keys){
+        continue
+      }
+      if _, ok := tips[keys[inst.Accounts[1]]]; ok{
+        total += binary.LittleEndian.Uint64(inst.Data[4:])
+      }
+    }
+  }
+  return total
+}
@@ -1,9 +1,11 @@
 package pkg
 import(
   "github.com/scatkit/gojito/pb"
+  "github.com/scatkit/gojito/metrics"
   "github.com/scatkit/pumpdexer/solana"
   "github.com/mr-tron/base58"
   "context"
+  "log/slog"
   "sync"
   "time"
   "fmt"
@@ -18,6 +20,8 @@ type AuthenticationService struct{
   BearerToken   string
   ExpiresAt     int64 // seconds
   ErrChan       chan error
+  Logger        *slog.Logger
+  Metrics       metrics.Recorder
   mu            sync.Mutex
 } 
 
@@ -27,6 +31,8 @@ func NewAuthenticationService(grpcConn *grpc.ClientConn, privateKey solana.Priva
     AuthService:  jito_pb.NewAuthServiceClient(grpcConn),
     KeyPair:      NewKeyPair(privateKey),
     ErrChan:      make(chan error),
+    Logger:       NopLogger(),
+    Metrics:      metrics.Nop{},
     mu:           sync.Mutex{},
   }
 }
@@ -72,15 +78,23 @@ func (as *AuthenticationService) AuthenticateAndRefresh(role jito_pb.Role) error
 				as.ErrChan <- as.GrpcCtx.Err()
 			default:
 				var resp *jito_pb.RefreshAccessTokenResponse
+				start := time.Now()
 				resp, err = as.AuthService.RefreshAccessToken(as.GrpcCtx, &jito_pb.RefreshAccessTokenRequest{
 					RefreshToken: respToken.RefreshToken.Value,
 				})
+				as.Metrics.AuthRefreshed(metrics.OutcomeOf(err, metrics.OutcomeSuccess))
 				if err != nil {
+					as.Logger.Warn("access token refresh failed", slog.String("role", role.String()), slog.Any("error", err))
 					as.ErrChan <- fmt.Errorf("failed to refresh access token: %w", err)
 					continue
 				}
 
 				as.updateAuthorizationMetadata(resp.AccessToken)
+				as.Logger.Debug("access token refreshed",
+					slog.String("role", role.String()),
+					slog.Duration("latency", time.Since(start)),
+					slog.Time("expires_at", resp.AccessToken.ExpiresAtUtc.AsTime()),
+				)
 				time.Sleep(time.Until(resp.AccessToken.ExpiresAtUtc.AsTime()) - 15*time.Second)
 			}
 		}
@@ -108,3 +122,16 @@ func (as *AuthenticationService) updateAuthorizationMetadata(token *jito_pb.Toke
 	as.ExpiresAt = token.ExpiresAtUtc.Seconds
 }
 
+
+// Context returns ctx carrying the current authorization metadata. Unlike GrpcCtx it keeps ctx's deadline and
+// cancellation, which long-lived streams need to be torn down.
+func (as *AuthenticationService) Context(ctx context.Context) context.Context {
+	as.mu.Lock()
+	md, _ := metadata.FromOutgoingContext(as.GrpcCtx)
+	as.mu.Unlock()
+
+	if existing, ok := metadata.FromOutgoingContext(ctx); ok {
+		md = metadata.Join(existing, md)
+	}
+	return metadata.NewOutgoingContext(ctx, md)
+}
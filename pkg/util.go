@@ -1,5 +1,9 @@
 package pkg
 import(
+  "fmt"
+
+  "github.com/mr-tron/base58"
+  "github.com/scatkit/pumpdexer/rpc"
   "github.com/scatkit/pumpdexer/solana"
 )
 
@@ -16,3 +20,42 @@ func BatchExtractSigFromTx(txns []*solana.Transaction) []solana.Signature{
   }
   return sigs
 }
+
+// SignatureStrings returns base58 representations of signatures (e.g. for log fields).
+func SignatureStrings(sigs []solana.Signature) []string{
+  out := make([]string, 0, len(sigs))
+  for _, sig := range sigs{
+    out = append(out, sig.String())
+  }
+  return out
+}
+
+// DecodeSignatures parses base58 signatures, e.g. the ones of a journal entry or a bundle status.
+func DecodeSignatures(encoded []string) ([]solana.Signature, error){
+  sigs := make([]solana.Signature, 0, len(encoded))
+  for _, s := range encoded{
+    raw, err := base58.Decode(s)
+    if err != nil || len(raw) != len(solana.Signature{}){
+      return nil, fmt.Errorf("invalid signature %q", s)
+    }
+    sigs = append(sigs, solana.Signature(raw))
+  }
+  return sigs, nil
+}
+
+// FoldSignatureStatuses summarizes the statuses of a bundle's signatures: how many are known to the node, the
+// highest slot they landed in and the first transaction error. Bundles land atomically, so while some but not all
+// are known, the remaining statuses are still to show up.
+func FoldSignatureStatuses(statuses []*rpc.SignatureStatusesResult) (landed int, slot uint64, txErr any){
+  for _, st := range statuses{
+    if st == nil{
+      continue
+    }
+    landed++
+    slot = max(slot, st.Slot)
+    if st.Err != nil && txErr == nil{
+      txErr = st.Err
+    }
+  }
+  return landed, slot, txErr
+}
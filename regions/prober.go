@@ -0,0 +1,282 @@
+package regions
+import(
+  "context"
+  "crypto/tls"
+  "errors"
+  "log/slog"
+  "slices"
+  "sync"
+  "time"
+
+  "github.com/scatkit/gojito/jitorpc"
+  "github.com/scatkit/gojito/pb"
+  "github.com/scatkit/gojito/pkg"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/credentials"
+)
+
+// DefaultAlpha weighs a new sample against the average: about the last 5 probes dominate it.
+const DefaultAlpha = 0.3
+
+type ProberOpts struct{
+  Interval    time.Duration // between probes, default 30s
+  Timeout     time.Duration // of each round trip, default 2s
+  Alpha       float64       // weight of a new sample in the moving averages, default DefaultAlpha
+  MaxFailures int           // consecutive failed probes after which a region ranks last, default 3
+  Logger      *slog.Logger  // nil discards all records
+}
+
+// Stats are the round trip times measured to a region, as exponentially weighted moving averages.
+type Stats struct{
+  Region    Region
+  GRPC      time.Duration // of GetTipAccounts, 0 until measured
+  HTTP      time.Duration // of getTipAccounts, 0 until measured
+  Samples   int
+  Failures  int   // consecutive probes in which a transport failed
+  LastError error // of the last failed probe
+  UpdatedAt time.Time
+}
+
+// Latency is the mean of the transports' averages, 0 until one was measured.
+func (s Stats) Latency() time.Duration{
+  switch{
+  case s.GRPC == 0:
+    return s.HTTP
+  case s.HTTP == 0:
+    return s.GRPC
+  }
+  return (s.GRPC + s.HTTP) / 2
+}
+
+// Prober periodically measures the round trip time to block engine regions over gRPC and JSON-RPC and ranks them.
+// Connections are kept open between probes, so handshakes aren't measured.
+type Prober struct{
+  opts ProberOpts
+
+  mu      sync.Mutex
+  targets []*target
+}
+
+type target struct{
+  stats    Stats
+  conn     *grpc.ClientConn
+  searcher jito_pb.SearcherServiceClient
+  jito     *jitorpc.JitoClient
+  warm     bool // a first round trip was made on both transports
+}
+
+func NewProber(regions []Region, opts *ProberOpts) *Prober{
+  var o ProberOpts
+  if opts != nil{
+    o = *opts
+  }
+  if o.Interval <= 0{
+    o.Interval = 30 * time.Second
+  }
+  if o.Timeout <= 0{
+    o.Timeout = 2 * time.Second
+  }
+  if o.Alpha <= 0 || o.Alpha > 1{
+    o.Alpha = DefaultAlpha
+  }
+  if o.MaxFailures <= 0{
+    o.MaxFailures = 3
+  }
+  if o.Logger == nil{
+    o.Logger = pkg.NopLogger()
+  }
+  p := &Prober{opts: o}
+  for _, r := range regions{
+    p.targets = append(p.targets, &target{stats: Stats{Region: r}})
+  }
+  return p
+}
+
+// Start probes every region once, then keeps probing them every Interval until ctx is done, when the connections
+// are closed. It fails if every region failed its first probe.
+func (p *Prober) Start(ctx context.Context) error{
+  err := p.Probe(ctx)
+  go func(){
+    defer p.Close()
+    ticker := time.NewTicker(p.opts.Interval)
+    defer ticker.Stop()
+    for{
+      select{
+      case <-ctx.Done():
+        return
+      case <-ticker.C:
+        if err := p.Probe(ctx); err != nil && ctx.Err() == nil{
+          p.opts.Logger.Warn("region probe failed", slog.Any("error", err))
+        }
+      }
+    }
+  }()
+  return err
+}
+
+// Probe measures the round trip time to every region once, concurrently. It fails if every region failed.
+func (p *Prober) Probe(ctx context.Context) error{
+  var wg sync.WaitGroup
+  for _, t := range p.targets{
+    wg.Add(1)
+    go func(){
+      defer wg.Done()
+      p.probe(ctx, t)
+    }()
+  }
+  wg.Wait()
+
+  p.mu.Lock()
+  defer p.mu.Unlock()
+  var errs []error
+  for _, t := range p.targets{
+    if t.stats.Failures == 0{
+      return nil
+    }
+    errs = append(errs, t.stats.LastError)
+  }
+  return errors.Join(errs...)
+}
+
+func (p *Prober) probe(ctx context.Context, t *target){
+  p.mu.Lock()
+  region := t.stats.Region
+  if t.conn == nil{
+    if err := p.connect(t); err != nil{
+      t.stats.Failures++
+      t.stats.LastError = err
+      p.mu.Unlock()
+      return
+    }
+  }
+  searcher, jito, warm := t.searcher, t.jito, t.warm
+  p.mu.Unlock()
+
+  grpcCall := func(ctx context.Context) error{
+    _, err := searcher.GetTipAccounts(ctx, &jito_pb.GetTipAccountsRequest{})
+    return err
+  }
+  httpCall := func(ctx context.Context) error{
+    _, err := jito.GetTipAccounts(ctx)
+    return err
+  }
+  if !warm{
+    // the first round trips establish the connections
+    p.roundTrip(ctx, grpcCall)
+    p.roundTrip(ctx, httpCall)
+  }
+  grpcRTT, grpcErr := p.roundTrip(ctx, grpcCall)
+  httpRTT, httpErr := p.roundTrip(ctx, httpCall)
+
+  p.mu.Lock()
+  defer p.mu.Unlock()
+  t.warm = true
+  s := &t.stats
+  if grpcErr == nil{
+    s.GRPC = ewma(s.GRPC, grpcRTT, p.opts.Alpha)
+  }
+  if httpErr == nil{
+    s.HTTP = ewma(s.HTTP, httpRTT, p.opts.Alpha)
+  }
+  if err := errors.Join(grpcErr, httpErr); err != nil{
+    s.Failures++
+    s.LastError = err
+  } else{
+    s.Failures = 0
+  }
+  s.Samples++
+  s.UpdatedAt = time.Now()
+  p.opts.Logger.Debug("region probed",
+    slog.String("region", region.Name),
+    slog.Duration("grpc", grpcRTT),
+    slog.Duration("http", httpRTT),
+    slog.Duration("latency", s.Latency()),
+    slog.Any("error", s.LastError),
+  )
+}
+
+// connect opens the connections of a region. p.mu must be held.
+func (p *Prober) connect(t *target) error{
+  region := t.stats.Region
+  tlsConfig := region.TLSConfig
+  if tlsConfig == nil{
+    tlsConfig = &tls.Config{}
+  }
+  opts := append([]grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))}, region.DialOptions...)
+  conn, err := grpc.NewClient(region.GRPC, opts...)
+  if err != nil{
+    return err
+  }
+  t.conn = conn
+  t.searcher = jito_pb.NewSearcherServiceClient(conn)
+  t.jito = jitorpc.NewJitoWithOpts(region.HTTP, "", &jitorpc.JitoClientOpts{HTTPClient: region.HTTPClient})
+  return nil
+}
+
+func (p *Prober) roundTrip(ctx context.Context, call func(ctx context.Context) error) (time.Duration, error){
+  ctx, cancel := context.WithTimeout(ctx, p.opts.Timeout)
+  defer cancel()
+  start := time.Now()
+  err := call(ctx)
+  return time.Since(start), err
+}
+
+func ewma(avg, sample time.Duration, alpha float64) time.Duration{
+  if avg == 0{
+    return sample
+  }
+  return time.Duration(alpha*float64(sample) + (1-alpha)*float64(avg))
+}
+
+// Ranked returns the stats of every region, closest first. Regions that failed MaxFailures probes in a row or
+// were never reached rank last.
+func (p *Prober) Ranked() []Stats{
+  p.mu.Lock()
+  stats := make([]Stats, 0, len(p.targets))
+  for _, t := range p.targets{
+    stats = append(stats, t.stats)
+  }
+  p.mu.Unlock()
+
+  slices.SortStableFunc(stats, func(a, b Stats) int{
+    ua, ub := p.usable(a), p.usable(b)
+    switch{
+    case ua && !ub:
+      return -1
+    case !ua && ub:
+      return 1
+    case !ua && !ub:
+      return 0
+    }
+    return int(a.Latency() - b.Latency())
+  })
+  return stats
+}
+
+func (p *Prober) usable(s Stats) bool{
+  return s.Latency() > 0 && s.Failures < p.opts.MaxFailures
+}
+
+// BestRegion returns the closest region, or Global and false if none was reached yet.
+func (p *Prober) BestRegion() (Region, bool){
+  ranked := p.Ranked()
+  if len(ranked) == 0 || !p.usable(ranked[0]){
+    return Global, false
+  }
+  return ranked[0].Region, true
+}
+
+// Close closes the gRPC connections. The prober reconnects if it's used again.
+func (p *Prober) Close() error{
+  p.mu.Lock()
+  defer p.mu.Unlock()
+  var errs []error
+  for _, t := range p.targets{
+    if t.conn != nil{
+      errs = append(errs, t.conn.Close())
+      t.conn, t.searcher, t.jito, t.warm = nil, nil, nil, false
+    }
+  }
+  return errors.Join(errs...)
+}
@@ -0,0 +1,151 @@
+package regions_test
+import(
+  "context"
+  "testing"
+  "time"
+
+  "github.com/scatkit/gojito/jitotest"
+  "github.com/scatkit/gojito/regions"
+)
+
+// startRegion serves a fake block engine region answering after latency, over gRPC and JSON-RPC.
+func startRegion(t *testing.T, name string, latency time.Duration) (regions.Region, *jitotest.BlockEngine, func()){
+  t.Helper()
+  be := jitotest.NewBlockEngine(jitotest.WithLatency(latency))
+  if err := be.Start(); err != nil{
+    t.Fatal(err)
+  }
+  srv := be.NewHTTPServer()
+  stop := func(){
+    srv.Close()
+    be.Close()
+  }
+  t.Cleanup(stop)
+  return regions.Region{
+    Name:        name,
+    GRPC:        be.Target(),
+    HTTP:        srv.URL,
+    TLSConfig:   be.TLSConfig(),
+    DialOptions: be.DialOptions(),
+  }, be, stop
+}
+
+func newProber(t *testing.T, rs []regions.Region, opts *regions.ProberOpts) *regions.Prober{
+  t.Helper()
+  if opts == nil{
+    opts = &regions.ProberOpts{}
+  }
+  if opts.Timeout == 0{
+    opts.Timeout = time.Second
+  }
+  p := regions.NewProber(rs, opts)
+  t.Cleanup(func(){ p.Close() })
+  return p
+}
+
+func rankedNames(p *regions.Prober) []string{
+  var names []string
+  for _, s := range p.Ranked(){
+    names = append(names, s.Region.Name)
+  }
+  return names
+}
+
+func assertOrder(t *testing.T, got []string, want ...string){
+  t.Helper()
+  if len(got) != len(want){
+    t.Fatalf("ranked %v, want %v", got, want)
+  }
+  for i := range want{
+    if got[i] != want[i]{
+      t.Fatalf("ranked %v, want %v", got, want)
+    }
+  }
+}
+
+func TestProberRanksRegionsByLatency(t *testing.T){
+  slow, _, _ := startRegion(t, "slow", 80*time.Millisecond)
+  fast, _, _ := startRegion(t, "fast", 0)
+  mid, _, _ := startRegion(t, "mid", 40*time.Millisecond)
+  p := newProber(t, []regions.Region{slow, fast, mid}, nil)
+
+  if err := p.Probe(context.Background()); err != nil{
+    t.Fatal(err)
+  }
+  assertOrder(t, rankedNames(p), "fast", "mid", "slow")
+  for _, s := range p.Ranked(){
+    if s.GRPC == 0 || s.HTTP == 0 || s.Samples != 1 || s.Failures != 0{
+      t.Errorf("%s: unexpected stats %+v", s.Region.Name, s)
+    }
+  }
+  best, ok := p.BestRegion()
+  if !ok || best.Name != "fast"{
+    t.Fatalf("best region %q (%v), want fast", best.Name, ok)
+  }
+}
+
+func TestProberFollowsLatencyChanges(t *testing.T){
+  a, beA, _ := startRegion(t, "a", 0)
+  b, _, _ := startRegion(t, "b", 40*time.Millisecond)
+  // a new sample replaces the average
+  p := newProber(t, []regions.Region{a, b}, &regions.ProberOpts{Alpha: 1})
+
+  if err := p.Probe(context.Background()); err != nil{
+    t.Fatal(err)
+  }
+  if best, _ := p.BestRegion(); best.Name != "a"{
+    t.Fatalf("best region %q, want a", best.Name)
+  }
+  beA.SetLatency(80 * time.Millisecond)
+  if err := p.Probe(context.Background()); err != nil{
+    t.Fatal(err)
+  }
+  if best, _ := p.BestRegion(); best.Name != "b"{
+    t.Fatalf("best region %q after a slowed down, want b", best.Name)
+  }
+}
+
+func TestProberDemotesFailingRegion(t *testing.T){
+  fast, _, stopFast := startRegion(t, "fast", 0)
+  slow, _, _ := startRegion(t, "slow", 40*time.Millisecond)
+  p := newProber(t, []regions.Region{fast, slow}, &regions.ProberOpts{MaxFailures: 2, Timeout: 300 * time.Millisecond})
+
+  ctx := context.Background()
+  if err := p.Probe(ctx); err != nil{
+    t.Fatal(err)
+  }
+  assertOrder(t, rankedNames(p), "fast", "slow")
+
+  stopFast()
+  p.Probe(ctx)
+  // a single failure keeps the region's average
+  assertOrder(t, rankedNames(p), "fast", "slow")
+  p.Probe(ctx)
+  assertOrder(t, rankedNames(p), "slow", "fast")
+
+  ranked := p.Ranked()
+  if ranked[1].Failures != 2 || ranked[1].LastError == nil{
+    t.Errorf("failing region stats %+v", ranked[1])
+  }
+  if best, ok := p.BestRegion(); !ok || best.Name != "slow"{
+    t.Fatalf("best region %q (%v), want slow", best.Name, ok)
+  }
+}
+
+func TestBestRegionFallsBackToGlobal(t *testing.T){
+  region, _, stop := startRegion(t, "dead", 0)
+  p := newProber(t, []regions.Region{region}, &regions.ProberOpts{Timeout: 300 * time.Millisecond})
+
+  // nothing probed yet
+  if best, ok := p.BestRegion(); ok || best.GRPC != regions.Global.GRPC{
+    t.Fatalf("best region %+v (%v) before probing, want Global", best, ok)
+  }
+
+  stop()
+  if err := p.Probe(context.Background()); err == nil{
+    t.Fatal("probe of an unreachable region succeeded")
+  }
+  if best, ok := p.BestRegion(); ok || best.GRPC != regions.Global.GRPC{
+    t.Fatalf("best region %+v (%v) with every region down, want Global", best, ok)
+  }
+}
@@ -0,0 +1,41 @@
+// Package regions lists the Jito block engine regions and measures the latency to each of them, so that clients
+// dial the closest region instead of a hardcoded one.
+package regions
+import(
+  "crypto/tls"
+
+  "github.com/scatkit/gojito/jitorpc/jsonrpc"
+
+  "google.golang.org/grpc"
+)
+
+// Region is a block engine region reachable over gRPC and JSON-RPC.
+type Region struct{
+  Name        string
+  GRPC        string             // gRPC dial address, e.g. "ny.mainnet.block-engine.jito.wtf:443"
+  HTTP        string             // JSON-RPC base URL, e.g. "https://ny.mainnet.block-engine.jito.wtf"
+  TLSConfig   *tls.Config        // of the gRPC connection, nil trusts the system roots
+  DialOptions []grpc.DialOption  // appended when dialing, e.g. to reach an in-process fake
+  HTTPClient  jsonrpc.HTTPClient // nil uses the default client
+}
+
+// MainnetRegion returns the mainnet endpoints of a region.
+func MainnetRegion(name string) Region{
+  host := name + ".mainnet.block-engine.jito.wtf"
+  return Region{Name: name, GRPC: host + ":443", HTTP: "https://" + host}
+}
+
+// Mainnet lists the mainnet block engine regions.
+var Mainnet = []Region{
+  MainnetRegion("amsterdam"),
+  MainnetRegion("dublin"),
+  MainnetRegion("frankfurt"),
+  MainnetRegion("london"),
+  MainnetRegion("ny"),
+  MainnetRegion("slc"),
+  MainnetRegion("singapore"),
+  MainnetRegion("tokyo"),
+}
+
+// Global is the global mainnet endpoint, which routes to a region by itself. It's what BestRegion falls back to.
+var Global = Region{GRPC: "mainnet.block-engine.jito.wtf:443", HTTP: "https://mainnet.block-engine.jito.wtf"}
@@ -0,0 +1,60 @@
+package main
+import(
+  "context"
+  "flag"
+  "log"
+  "log/slog"
+  "net"
+  "os"
+  "os/signal"
+
+  "github.com/scatkit/gojito/server"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/credentials"
+)
+
+// Runs the reference auth and block engine services on a local port with a self-signed certificate.
+func main(){
+  addr := flag.String("addr", "127.0.0.1:1005", "address to listen on")
+  region := flag.String("region", "local", "block engine region")
+  slot := flag.Uint64("slot", 1, "first auction slot")
+  noAuth := flag.Bool("no-auth", false, "serve without the auth service")
+  flag.Parse()
+
+  ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+  defer cancel()
+
+  host, _, err := net.SplitHostPort(*addr)
+  if err != nil{
+    log.Fatal(err)
+  }
+  cert, _, err := server.SelfSignedTLS(host)
+  if err != nil{
+    log.Fatal(err)
+  }
+
+  logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+  be := server.NewBlockEngine(*region, *slot)
+  be.Logger = logger
+
+  var auth *server.AuthServer
+  if !*noAuth{
+    auth = server.NewAuthServer(nil)
+  }
+  srv := server.NewGRPCServer(auth, be, grpc.Creds(credentials.NewServerTLSFromCert(&cert)))
+
+  lis, err := net.Listen("tcp", *addr)
+  if err != nil{
+    log.Fatal(err)
+  }
+  go be.Run(ctx)
+  go func(){
+    <-ctx.Done()
+    srv.GracefulStop()
+  }()
+  logger.Info("local block engine listening", slog.String("addr", lis.Addr().String()))
+  if err := srv.Serve(lis); err != nil{
+    log.Fatal(err)
+  }
+}
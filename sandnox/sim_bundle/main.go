@@ -4,6 +4,8 @@ import (
   "github.com/scatkit/pumpdexer/solana"
   "github.com/scatkit/pumpdexer/rpc"
   "github.com/scatkit/gojito/clients/searcher_client"
+  "github.com/scatkit/gojito/pkg"
+  "github.com/scatkit/gojito/regions"
   "github.com/scatkit/pumpdexer/programs/system"
   "log"
   "time"
@@ -17,10 +19,17 @@ func main(){
     log.Fatal(err)
   }
   
+  // Picking the region with the lowest latency instead of a fixed one
+  prober := regions.NewProber(regions.Mainnet, nil)
+  if err := prober.Start(context.Background()); err != nil{
+    log.Fatal(err)
+  }
+  region, _ := prober.BestRegion()
+  
   // Creating a searcher client
   client, err := searcher_client.New(
     context.Background(),
-    "ny.mainnet.block-engine.jito.wtf:443", // grpcDialUrl
+    region.GRPC, // grpcDialUrl
     rpc.New("https://mainnet.block-engine.jito.wtf/api/v1"), // jitoRPCClient
     rpc.New("https://api.mainnet-beta.solana.com"), // solana's rpc
     key, // private key
@@ -41,7 +50,12 @@ func main(){
   
   fundedWalletPubKey := fundedWallet.PublicKey()
 
-  blockHash, err := client.RpcConn.GetLatestBlockhash(ctx, rpc.CommitmentConfirmed)
+  // keeps the blockhash warm and lets the client wait for the bundle until its blockhash expires
+  client.Blockhashes = pkg.NewBlockhashProvider(client.RpcConn)
+  if err := client.Blockhashes.Start(ctx); err != nil{
+    log.Fatal(err)
+  }
+  blockHash, err := client.Blockhashes.Latest(ctx)
   if err != nil{
     log.Fatal(err)
   } 
@@ -57,7 +71,7 @@ func main(){
       ).Build(),
       tipInstr,
     },
-    blockHash.Value.Blockhash,
+    blockHash.Hash,
     solana.TransactionPayer(fundedWalletPubKey),
   )
   
@@ -80,22 +94,9 @@ func main(){
   
   resp, err := client.SimulateBundle(
     ctx,
-    searcher_client.SimulateBundleParams{
-      EncodedTransactions: []string{tx.MustToBase64()},
-    },
-    searcher_client.SimulateBundleConfig{
-      PreExecutionAccountsConfigs: []searcher_client.ExecutionAccounts{
-				{
-					Encoding:  "base64",
-					Addresses: []string{"3vjULHsUbX4J2nXZJQQSHkTHoBqhedvHQPDNaAgT9dwG"},
-				},
-			},
-      PostExecutionAccountsConfigs: []searcher_client.ExecutionAccounts{
-				{
-					Encoding:  "base64",
-					Addresses: []string{"3vjULHsUbX4J2nXZJQQSHkTHoBqhedvHQPDNaAgT9dwG"},
-				},
-			},
+    []*solana.Transaction{tx},
+    &searcher_client.SimulateBundleOpts{
+      WatchedAccounts: []solana.PublicKey{solana.MustPubkeyFromBase58("3vjULHsUbX4J2nXZJQQSHkTHoBqhedvHQPDNaAgT9dwG")},
     },
   )
   
@@ -103,5 +104,8 @@ func main(){
 		log.Fatal(err)
 	}
   
-  log.Println(resp)
+  if err := resp.Summary.Err(); err != nil{
+    log.Fatal(err)
+  }
+  log.Println(resp.TransactionResults)
 }
@@ -5,6 +5,8 @@ import (
   "github.com/davecgh/go-spew/spew"
   "github.com/scatkit/pumpdexer/rpc"
   "github.com/scatkit/gojito/clients/searcher_client"
+  "github.com/scatkit/gojito/pkg"
+  "github.com/scatkit/gojito/regions"
   "github.com/scatkit/pumpdexer/programs/system"
   "log"
   "time"
@@ -12,10 +14,17 @@ import (
 
 func main(){
   
+  // Picking the region with the lowest latency instead of a fixed one
+  prober := regions.NewProber(regions.Mainnet, nil)
+  if err := prober.Start(context.Background()); err != nil{
+    log.Fatal(err)
+  }
+  region, _ := prober.BestRegion()
+  
   // Creating a searcher client
   client, err := searcher_client.NewNoAuth(
     context.Background(),
-    "ny.mainnet.block-engine.jito.wtf:443", // grpcDialUrl
+    region.GRPC, // grpcDialUrl
     rpc.New("https://mainnet.block-engine.jito.wtf/api/v1"), // jitoRPCClient
     rpc.New("https://api.mainnet-beta.solana.com"), // solana's rpc
     nil, // tls.config
@@ -32,7 +41,12 @@ func main(){
 
    // Max 5 transactions per bundle
   txns := make([]*solana.Transaction, 0, 5)
-  blockHash, err := client.RpcConn.GetLatestBlockhash(ctx, rpc.CommitmentConfirmed)
+  // keeps the blockhash warm and lets the client wait for the bundle until its blockhash expires
+  client.Blockhashes = pkg.NewBlockhashProvider(client.RpcConn)
+  if err := client.Blockhashes.Start(ctx); err != nil{
+    log.Fatal(err)
+  }
+  blockHash, err := client.Blockhashes.Latest(ctx)
   if err != nil{
     log.Fatal(err)
   } 
@@ -55,7 +69,7 @@ func main(){
       ).Build(),
       tipInstr,
     },
-    blockHash.Value.Blockhash,
+    blockHash.Hash,
     solana.TransactionPayer(fromWallet.PublicKey()),
   )
   
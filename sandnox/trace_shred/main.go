@@ -0,0 +1,77 @@
+package main
+import(
+  "context"
+  "encoding/json"
+  "flag"
+  "fmt"
+  "log"
+  "os"
+  "os/signal"
+  "strings"
+  "time"
+  "github.com/scatkit/pumpdexer/solana"
+  "github.com/scatkit/gojito/pb"
+  "github.com/scatkit/gojito/shredstream"
+)
+
+// Subscribes to ShredStream from several regions and prints the trace shred latency of each one.
+func main(){
+  blockEngine := flag.String("block-engine", "mainnet.block-engine.jito.wtf:443", "block engine gRPC address")
+  keyFile := flag.String("key", "wallet.json", "keypair approved for ShredStream")
+  publicIP := flag.String("ip", "", "public IP shreds are sent to")
+  port := flag.Int("port", 20000, "UDP port to receive shreds on")
+  regions := flag.String("regions", "amsterdam,frankfurt,ny,tokyo", "comma separated regions to compare")
+  interval := flag.Duration("interval", 10*time.Second, "report interval")
+  flag.Parse()
+
+  walletData, err := os.ReadFile(*keyFile)
+  if err != nil{
+    log.Fatal(err)
+  }
+  var bts []byte
+  if err := json.Unmarshal(walletData, &bts); err != nil{
+    log.Fatal(err)
+  }
+  key := solana.PrivateKey(bts)
+
+  ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+  defer cancel()
+
+  receiver, err := shredstream.Listen(fmt.Sprintf("0.0.0.0:%d", *port), 4096)
+  if err != nil{
+    log.Fatal(err)
+  }
+  defer receiver.Close()
+
+  client, err := shredstream.New(ctx, *blockEngine, key, nil)
+  if err != nil{
+    log.Fatal(err)
+  }
+  defer client.Close()
+  client.StartHeartbeat(ctx, &jito_pb.Socket{Ip: *publicIP, Port: int64(*port)}, strings.Split(*regions, ","))
+
+  monitor := shredstream.NewTraceMonitor(0)
+  go func(){
+    for shred := range receiver.Shreds{
+      monitor.Observe(shred)
+    }
+  }()
+
+  ticker := time.NewTicker(*interval)
+  defer ticker.Stop()
+  for{
+    select{
+    case <-ctx.Done():
+      return
+    case <-ticker.C:
+      fmt.Printf("%-12s %8s %8s %10s %10s %10s %10s\n", "region", "received", "loss", "min", "p50", "p90", "p99")
+      for _, stat := range monitor.Stats(){
+        fmt.Printf("%-12s %8d %7.2f%% %10s %10s %10s %10s\n", stat.Region, stat.Received, stat.LossRate()*100,
+          stat.Min, stat.P50, stat.P90, stat.P99)
+      }
+      if region, ok := monitor.Fastest(); ok{
+        fmt.Println("fastest:", region)
+      }
+    }
+  }
+}
@@ -17,13 +17,20 @@ fi
 # pwd: scripts/mev-protos/
 cd $REPO_DIR
 
+# Every proto is generated into the single jito_pb package, where messages sharing a name across proto packages
+# collide. Rename them first; message names aren't part of the wire format, so the RPCs are unaffected.
+echo "Renaming colliding messages..."
+perl -pi -e 's/\bHeartbeat\b/HeartbeatShredStream/g' shredstream.proto
+perl -pi -e 's/\bSubscribePackets(Request|Response)\b/SubscribePackets${1}BlockEngine/g' block_engine.proto
+
 PROTO_FILES=$(find . -name '*.proto')
 MAPPING_ARGS=""
 
 for file in $PROTO_FILES; do
   REL_PATH="${file#./}"
-  # add the mapping arg with the correct Go import path
-  MAPPING_ARGS+="M${REL_PATH}=${IMPORT_PATH},"
+  # add the mapping arg with the correct Go import path; the package name overrides go_package options
+  # like confirmed_block.proto's "./proto"
+  MAPPING_ARGS+="M${REL_PATH}=${IMPORT_PATH};jito_pb,"
 done
 
 mkdir -p "../$OUTPUT_DIR"
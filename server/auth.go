@@ -0,0 +1,249 @@
+package server
+import(
+  "context"
+  "crypto/ed25519"
+  "crypto/hmac"
+  "crypto/rand"
+  "crypto/sha256"
+  "encoding/base64"
+  "encoding/hex"
+  "encoding/json"
+  "errors"
+  "fmt"
+  "strings"
+  "sync"
+  "time"
+
+  "github.com/mr-tron/base58"
+  "github.com/scatkit/pumpdexer/solana"
+  "github.com/scatkit/gojito/pb"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/metadata"
+  "google.golang.org/grpc/status"
+  "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+  DefaultAccessTTL    = 30 * time.Minute
+  DefaultRefreshTTL   = 24 * time.Hour
+  DefaultChallengeTTL = time.Minute
+)
+
+// jwtHeader is the fixed header of the HS256 tokens issued by AuthServer.
+var jwtHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// serviceRoles is the role required by the methods of each service.
+var serviceRoles = map[string]jito_pb.Role{
+  "/searcher.SearcherService/":           jito_pb.Role_SEARCHER,
+  "/block_engine.BlockEngineValidator/":  jito_pb.Role_VALIDATOR,
+  "/block_engine.BlockEngineRelayer/":    jito_pb.Role_RELAYER,
+  "/relayer.Relayer/":                    jito_pb.Role_VALIDATOR,
+  "/shredstream.Shredstream/":            jito_pb.Role_SHREDSTREAM_SUBSCRIBER,
+}
+
+// Claims are the contents of an access or refresh token.
+type Claims struct{
+  Subject   string       `json:"sub"` // base58 public key of the client
+  Role      jito_pb.Role `json:"role"`
+  Refresh   bool         `json:"refresh,omitempty"`
+  IssuedAt  int64        `json:"iat"`
+  ExpiresAt int64        `json:"exp"`
+}
+
+type claimsKey struct{}
+
+// ClaimsFromContext returns the claims of the access token that authorized the call.
+func ClaimsFromContext(ctx context.Context) (*Claims, bool){
+  claims, ok := ctx.Value(claimsKey{}).(*Claims)
+  return claims, ok
+}
+
+type challenge struct{
+  value     string
+  role      jito_pb.Role
+  expiresAt time.Time
+}
+
+// AuthServer is a reference implementation of the Jito auth service: clients sign "<pubkey>-<challenge>" with
+// their ed25519 key and receive HMAC signed JWTs, which the interceptors verify on every other service.
+type AuthServer struct{
+  jito_pb.UnimplementedAuthServiceServer
+
+  AccessTTL    time.Duration
+  RefreshTTL   time.Duration
+  ChallengeTTL time.Duration
+  // Allow decides whether pubkey may authenticate with role; every key is allowed when nil.
+  Allow func(pubkey solana.PublicKey, role jito_pb.Role) bool
+
+  secret     []byte
+  mu         sync.Mutex
+  challenges map[string]challenge // by base58 pubkey
+}
+
+// NewAuthServer signs tokens with secret, or with a random secret if it is empty.
+func NewAuthServer(secret []byte) *AuthServer{
+  if len(secret) == 0{
+    secret = make([]byte, 32)
+    rand.Read(secret)
+  }
+  return &AuthServer{
+    AccessTTL:    DefaultAccessTTL,
+    RefreshTTL:   DefaultRefreshTTL,
+    ChallengeTTL: DefaultChallengeTTL,
+    secret:       secret,
+    challenges:   make(map[string]challenge),
+  }
+}
+
+func (a *AuthServer) GenerateAuthChallenge(_ context.Context, req *jito_pb.GenerateAuthChallengeRequest) (*jito_pb.GenerateAuthChallengeResponse, error){
+  if len(req.GetPubkey()) != ed25519.PublicKeySize{
+    return nil, status.Error(codes.InvalidArgument, "pubkey must be 32 bytes")
+  }
+  if a.Allow != nil && !a.Allow(solana.PublicKeyFromBytes(req.Pubkey), req.GetRole()){
+    return nil, status.Errorf(codes.PermissionDenied, "pubkey is not allowed to authenticate as %s", req.GetRole())
+  }
+  b := make([]byte, 9)
+  rand.Read(b)
+  value := hex.EncodeToString(b)
+
+  a.mu.Lock()
+  a.challenges[base58.Encode(req.Pubkey)] = challenge{value: value, role: req.GetRole(), expiresAt: time.Now().Add(a.ChallengeTTL)}
+  a.mu.Unlock()
+  return &jito_pb.GenerateAuthChallengeResponse{Challenge: value}, nil
+}
+
+// GenerateAuthTokens verifies the signed challenge and issues an access and a refresh token for the challenged role.
+func (a *AuthServer) GenerateAuthTokens(_ context.Context, req *jito_pb.GenerateAuthTokensRequest) (*jito_pb.GenerateAuthTokensResponse, error){
+  if len(req.GetClientPubkey()) != ed25519.PublicKeySize{
+    return nil, status.Error(codes.InvalidArgument, "client pubkey must be 32 bytes")
+  }
+  pubkey := base58.Encode(req.ClientPubkey)
+
+  a.mu.Lock()
+  pending, ok := a.challenges[pubkey]
+  delete(a.challenges, pubkey)
+  a.mu.Unlock()
+
+  if !ok || time.Now().After(pending.expiresAt) || req.GetChallenge() != fmt.Sprintf("%s-%s", pubkey, pending.value){
+    return nil, status.Error(codes.PermissionDenied, "unknown or expired challenge")
+  }
+  if !ed25519.Verify(req.ClientPubkey, []byte(req.Challenge), req.GetSignedChallenge()){
+    return nil, status.Error(codes.PermissionDenied, "invalid challenge signature")
+  }
+
+  access, err := a.issue(pubkey, pending.role, false)
+  if err != nil{
+    return nil, status.Error(codes.Internal, err.Error())
+  }
+  refresh, err := a.issue(pubkey, pending.role, true)
+  if err != nil{
+    return nil, status.Error(codes.Internal, err.Error())
+  }
+  return &jito_pb.GenerateAuthTokensResponse{AccessToken: access, RefreshToken: refresh}, nil
+}
+
+func (a *AuthServer) RefreshAccessToken(_ context.Context, req *jito_pb.RefreshAccessTokenRequest) (*jito_pb.RefreshAccessTokenResponse, error){
+  claims, err := a.Verify(req.GetRefreshToken())
+  if err != nil || !claims.Refresh{
+    return nil, status.Error(codes.Unauthenticated, "invalid or expired refresh token")
+  }
+  access, err := a.issue(claims.Subject, claims.Role, false)
+  if err != nil{
+    return nil, status.Error(codes.Internal, err.Error())
+  }
+  return &jito_pb.RefreshAccessTokenResponse{AccessToken: access}, nil
+}
+
+func (a *AuthServer) issue(subject string, role jito_pb.Role, refresh bool) (*jito_pb.Token, error){
+  ttl := a.AccessTTL
+  if refresh{
+    ttl = a.RefreshTTL
+  }
+  now := time.Now()
+  expiresAt := now.Add(ttl)
+  payload, err := json.Marshal(&Claims{Subject: subject, Role: role, Refresh: refresh, IssuedAt: now.Unix(), ExpiresAt: expiresAt.Unix()})
+  if err != nil{
+    return nil, err
+  }
+  unsigned := jwtHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+  return &jito_pb.Token{Value: unsigned + "." + a.sign(unsigned), ExpiresAtUtc: timestamppb.New(expiresAt)}, nil
+}
+
+func (a *AuthServer) sign(unsigned string) string{
+  mac := hmac.New(sha256.New, a.secret)
+  mac.Write([]byte(unsigned))
+  return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature and expiry of a token and returns its claims.
+func (a *AuthServer) Verify(token string) (*Claims, error){
+  i := strings.LastIndexByte(token, '.')
+  if i < 0 || !strings.HasPrefix(token, jwtHeader+"."){
+    return nil, errors.New("malformed token")
+  }
+  if !hmac.Equal([]byte(token[i+1:]), []byte(a.sign(token[:i]))){
+    return nil, errors.New("invalid token signature")
+  }
+  payload, err := base64.RawURLEncoding.DecodeString(token[len(jwtHeader)+1 : i])
+  if err != nil{
+    return nil, err
+  }
+  claims := new(Claims)
+  if err = json.Unmarshal(payload, claims); err != nil{
+    return nil, err
+  }
+  if time.Now().Unix() >= claims.ExpiresAt{
+    return nil, errors.New("token expired")
+  }
+  return claims, nil
+}
+
+// authorize verifies the access token of a call and the role its service requires.
+// Calls to the auth service itself are not authenticated.
+func (a *AuthServer) authorize(ctx context.Context, method string) (context.Context, error){
+  if strings.HasPrefix(method, "/auth."){
+    return ctx, nil
+  }
+  md, _ := metadata.FromIncomingContext(ctx)
+  values := md.Get("authorization")
+  if len(values) == 0{
+    return nil, status.Error(codes.Unauthenticated, "missing access token")
+  }
+  claims, err := a.Verify(strings.TrimPrefix(values[0], "Bearer "))
+  if err != nil || claims.Refresh{
+    return nil, status.Error(codes.Unauthenticated, "invalid or expired access token")
+  }
+  for prefix, role := range serviceRoles{
+    if strings.HasPrefix(method, prefix) && claims.Role != role{
+      return nil, status.Errorf(codes.PermissionDenied, "%s requires the %s role", method, role)
+    }
+  }
+  return context.WithValue(ctx, claimsKey{}, claims), nil
+}
+
+// UnaryInterceptor authenticates unary calls and makes the token's claims available via ClaimsFromContext.
+func (a *AuthServer) UnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error){
+  ctx, err := a.authorize(ctx, info.FullMethod)
+  if err != nil{
+    return nil, err
+  }
+  return handler(ctx, req)
+}
+
+// StreamInterceptor authenticates streaming calls and makes the token's claims available via ClaimsFromContext.
+func (a *AuthServer) StreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error{
+  ctx, err := a.authorize(ss.Context(), info.FullMethod)
+  if err != nil{
+    return err
+  }
+  return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+}
+
+type authenticatedStream struct{
+  grpc.ServerStream
+  ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context{ return s.ctx }
@@ -0,0 +1,349 @@
+package server
+import(
+  "context"
+  "crypto/sha256"
+  "encoding/hex"
+  "fmt"
+  "log/slog"
+  "sort"
+  "sync"
+  "time"
+
+  "github.com/scatkit/pumpdexer/solana"
+  "github.com/scatkit/gojito/pb"
+  "github.com/scatkit/gojito/pkg"
+
+  "google.golang.org/grpc/codes"
+  "google.golang.org/grpc/status"
+  "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// DefaultTipAccounts are the mainnet tip accounts.
+var DefaultTipAccounts = []string{
+  "96gYZGLnJYVFmbjzopPSU6QiEV5fGqZNyN9nmNhvrZU5",
+  "HFqU5x63VTqvQss8hp11i4wVV8bD44PvwucfZ2bU7gRe",
+  "Cw8CFyM9FkoMi7K7Crf6HNQqf4uEMzpKw6QNghXLvLkY",
+  "ADaUMid9yfUytqMBgopwjb2DTLSokTSzL1zt6iGPaS49",
+  "DfXygSm4jCyNCybVYYK6DwvWqjKee8pbDmJGcLWNDXjh",
+  "ADuUkR4vqLUMWXxW9gh6D6L8pMSawimctcNZ5pGwDcEt",
+  "DttWaMuVvTiduZRnguLF7jNxTgiMBZ1hyAumKUiL2KRL",
+  "3AVi9Tg9Uo68tJfuvoKvqKNWKkC5wPdSSdeBnizKZ6jT",
+}
+
+const (
+  DefaultSlotDuration   = 400 * time.Millisecond
+  DefaultMaxBundleSize  = 5
+  DefaultMinTipLamports = 1000
+)
+
+// bid is a bundle waiting for the next auction.
+type bid struct{
+  uuid     string
+  searcher string // base58 pubkey from the access token, "" without auth
+  bundle   *jito_pb.Bundle
+  tip      uint64
+}
+
+// BlockEngine is a reference block engine: searchers send bundles, a single auction per slot picks the bundle
+// paying the highest tip and the winner is forwarded to every validator subscribed to bundles.
+// Searchers are told the outcome on SubscribeBundleResults.
+type BlockEngine struct{
+  jito_pb.UnimplementedSearcherServiceServer
+  jito_pb.UnimplementedBlockEngineValidatorServer
+
+  Region         string
+  Regions        []string
+  TipAccounts    []string
+  SlotDuration   time.Duration // auction interval when started with Run
+  MaxBundleSize  int
+  MinTipLamports uint64 // bundles tipping less are rejected on SendBundle
+  // BlockBuilderPubkey and BlockBuilderCommission are returned by GetBlockBuilderFeeInfo.
+  BlockBuilderPubkey     string
+  BlockBuilderCommission uint64
+  Logger                 *slog.Logger
+
+  mu         sync.Mutex
+  slot       uint64
+  auctions   uint64
+  pending    []*bid
+  validators map[chan *jito_pb.BundleUuid]string // subscription => validator identity
+  searchers  map[chan *jito_pb.BundleResult]string // subscription => searcher pubkey
+}
+
+// NewBlockEngine returns a block engine starting at slot with the mainnet tip accounts.
+func NewBlockEngine(region string, slot uint64) *BlockEngine{
+  return &BlockEngine{
+    Region:         region,
+    Regions:        []string{region},
+    TipAccounts:    DefaultTipAccounts,
+    SlotDuration:   DefaultSlotDuration,
+    MaxBundleSize:  DefaultMaxBundleSize,
+    MinTipLamports: DefaultMinTipLamports,
+    Logger:         pkg.NopLogger(),
+    slot:           slot,
+    validators:     make(map[chan *jito_pb.BundleUuid]string),
+    searchers:      make(map[chan *jito_pb.BundleResult]string),
+  }
+}
+
+// Slot returns the slot of the next auction.
+func (be *BlockEngine) Slot() uint64{
+  be.mu.Lock()
+  defer be.mu.Unlock()
+  return be.slot
+}
+
+// Run runs an auction every SlotDuration until ctx is done.
+func (be *BlockEngine) Run(ctx context.Context){
+  ticker := time.NewTicker(be.SlotDuration)
+  defer ticker.Stop()
+  for{
+    select{
+    case <-ctx.Done():
+      return
+    case <-ticker.C:
+      be.RunAuction()
+    }
+  }
+}
+
+// RunAuction closes the auction of the current slot and advances to the next one. The highest tip wins,
+// ties going to the bundle received first. It returns the winning bundle's UUID, or "" without bids.
+func (be *BlockEngine) RunAuction() string{
+  be.mu.Lock()
+  slot, bids := be.slot, be.pending
+  be.slot++
+  be.auctions++
+  auctionID := fmt.Sprintf("%d-%d", slot, be.auctions)
+  be.pending = nil
+  be.mu.Unlock()
+
+  if len(bids) == 0{
+    return ""
+  }
+  sort.SliceStable(bids, func(i, j int) bool{ return bids[i].tip > bids[j].tip })
+  winner := bids[0]
+
+  identity, forwarded := be.forward(&jito_pb.BundleUuid{Bundle: winner.bundle, Uuid: winner.uuid})
+  if forwarded{
+    be.Logger.Info("auction won", slog.Uint64("slot", slot), slog.String("uuid", winner.uuid), slog.Uint64("tip", winner.tip))
+    be.emit(winner.searcher, &jito_pb.BundleResult{BundleId: winner.uuid, Result: &jito_pb.BundleResult_Accepted{
+      Accepted: &jito_pb.Accepted{Slot: slot, ValidatorIdentity: identity},
+    }})
+  } else{
+    be.Logger.Warn("no validator connected, dropping auction winner", slog.Uint64("slot", slot), slog.String("uuid", winner.uuid))
+    be.emit(winner.searcher, &jito_pb.BundleResult{BundleId: winner.uuid, Result: &jito_pb.BundleResult_Rejected{
+      Rejected: &jito_pb.Rejected{Reason: &jito_pb.Rejected_InternalError{InternalError: &jito_pb.InternalError{Msg: "no validator connected"}}},
+    }})
+  }
+
+  msg := fmt.Sprintf("outbid by %s with %d lamports", winner.uuid, winner.tip)
+  for _, loser := range bids[1:]{
+    be.emit(loser.searcher, &jito_pb.BundleResult{BundleId: loser.uuid, Result: &jito_pb.BundleResult_Rejected{
+      Rejected: &jito_pb.Rejected{Reason: &jito_pb.Rejected_WinningBatchBidRejected{WinningBatchBidRejected: &jito_pb.WinningBatchBidRejected{
+        AuctionId:            auctionID,
+        SimulatedBidLamports: loser.tip,
+        Msg:                  &msg,
+      }}},
+    }})
+  }
+  return winner.uuid
+}
+
+// forward sends bundle to every subscribed validator and returns the identity of one of them.
+func (be *BlockEngine) forward(bundle *jito_pb.BundleUuid) (string, bool){
+  be.mu.Lock()
+  defer be.mu.Unlock()
+  var identity string
+  forwarded := false
+  for sub, id := range be.validators{
+    select{
+    case sub <- bundle:
+      identity, forwarded = id, true
+    default:
+      be.Logger.Warn("validator is lagging, bundle not forwarded", slog.String("validator", id))
+    }
+  }
+  return identity, forwarded
+}
+
+// emit sends result to the subscriptions of searcher.
+func (be *BlockEngine) emit(searcher string, result *jito_pb.BundleResult){
+  be.mu.Lock()
+  defer be.mu.Unlock()
+  for sub, pubkey := range be.searchers{
+    if pubkey != searcher{
+      continue
+    }
+    select{
+    case sub <- result:
+    default:
+    }
+  }
+}
+
+// subject returns the pubkey of the authenticated caller, or "" when the server runs without auth.
+func subject(ctx context.Context) string{
+  if claims, ok := ClaimsFromContext(ctx); ok{
+    return claims.Subject
+  }
+  return ""
+}
+
+// Searcher service
+
+// SendBundle enters a bundle in the auction of the current slot. The UUID is the sha256 of the
+// transactions' first signatures.
+func (be *BlockEngine) SendBundle(ctx context.Context, req *jito_pb.SendBundleRequest) (*jito_pb.SendBundleResponse, error){
+  packets := req.GetBundle().GetPackets()
+  if len(packets) == 0 || len(packets) > be.MaxBundleSize{
+    return nil, status.Errorf(codes.InvalidArgument, "bundle must contain between 1 and %d transactions, got %d", be.MaxBundleSize, len(packets))
+  }
+  hash := sha256.New()
+  txns := make([]*solana.Transaction, 0, len(packets))
+  for i, packet := range packets{
+    tx, err := pkg.ConvertProtobufPacketToTransaction(packet)
+    if err != nil{
+      return nil, status.Errorf(codes.InvalidArgument, "transaction %d: %v", i, err)
+    }
+    if len(tx.Signatures) == 0{
+      return nil, status.Errorf(codes.InvalidArgument, "transaction %d is not signed", i)
+    }
+    hash.Write(tx.Signatures[0][:])
+    txns = append(txns, tx)
+  }
+  tip := pkg.TipLamports(txns, be.TipAccounts)
+  if tip < be.MinTipLamports{
+    return nil, status.Errorf(codes.InvalidArgument, "bundle tips %d lamports, the minimum is %d", tip, be.MinTipLamports)
+  }
+
+  uuid := hex.EncodeToString(hash.Sum(nil))
+  bundle := &jito_pb.Bundle{Header: &jito_pb.Header{Ts: timestamppb.Now()}, Packets: packets}
+  be.mu.Lock()
+  be.pending = append(be.pending, &bid{uuid: uuid, searcher: subject(ctx), bundle: bundle, tip: tip})
+  be.mu.Unlock()
+  return &jito_pb.SendBundleResponse{Uuid: uuid}, nil
+}
+
+// SubscribeBundleResults streams the results of the bundles sent by the same searcher key.
+func (be *BlockEngine) SubscribeBundleResults(_ *jito_pb.SubscribeBundleResultsRequest, stream jito_pb.SearcherService_SubscribeBundleResultsServer) error{
+  sub := make(chan *jito_pb.BundleResult, 64)
+  be.mu.Lock()
+  be.searchers[sub] = subject(stream.Context())
+  be.mu.Unlock()
+  defer func(){
+    be.mu.Lock()
+    delete(be.searchers, sub)
+    be.mu.Unlock()
+  }()
+
+  for{
+    select{
+    case <-stream.Context().Done():
+      return nil
+    case result := <-sub:
+      if err := stream.Send(result); err != nil{
+        return err
+      }
+    }
+  }
+}
+
+// leaders returns the identities of the connected validators, sorted.
+func (be *BlockEngine) leaders() []string{
+  be.mu.Lock()
+  defer be.mu.Unlock()
+  seen := make(map[string]struct{}, len(be.validators))
+  var out []string
+  for _, id := range be.validators{
+    if _, ok := seen[id]; !ok{
+      seen[id] = struct{}{}
+      out = append(out, id)
+    }
+  }
+  sort.Strings(out)
+  return out
+}
+
+// GetNextScheduledLeader reports the first connected validator as leader of the current slot.
+func (be *BlockEngine) GetNextScheduledLeader(context.Context, *jito_pb.NextScheduledLeaderRequest) (*jito_pb.NextScheduledLeaderResponse, error){
+  slot := be.Slot()
+  resp := &jito_pb.NextScheduledLeaderResponse{CurrentSlot: slot}
+  if leaders := be.leaders(); len(leaders) > 0{
+    resp.NextLeaderSlot = slot
+    resp.NextLeaderIdentity = leaders[0]
+    resp.NextLeaderRegion = be.Region
+  }
+  return resp, nil
+}
+
+// GetConnectedLeaders reports every connected validator as leader of the current slot.
+func (be *BlockEngine) GetConnectedLeaders(context.Context, *jito_pb.ConnectedLeadersRequest) (*jito_pb.ConnectedLeadersResponse, error){
+  slot := be.Slot()
+  resp := &jito_pb.ConnectedLeadersResponse{ConnectedValidators: make(map[string]*jito_pb.SlotList)}
+  for _, id := range be.leaders(){
+    resp.ConnectedValidators[id] = &jito_pb.SlotList{Slots: []uint64{slot}}
+  }
+  return resp, nil
+}
+
+func (be *BlockEngine) GetConnectedLeadersRegioned(ctx context.Context, req *jito_pb.ConnectedLeadersRegionedRequest) (*jito_pb.ConnectedLeadersRegionedResponse, error){
+  leaders, _ := be.GetConnectedLeaders(ctx, nil)
+  out := &jito_pb.ConnectedLeadersRegionedResponse{ConnectedValidators: make(map[string]*jito_pb.ConnectedLeadersResponse)}
+  for _, region := range req.GetRegions(){
+    if region == be.Region{
+      out.ConnectedValidators[region] = leaders
+    }
+  }
+  if len(req.GetRegions()) == 0{
+    out.ConnectedValidators[be.Region] = leaders
+  }
+  return out, nil
+}
+
+func (be *BlockEngine) GetTipAccounts(context.Context, *jito_pb.GetTipAccountsRequest) (*jito_pb.GetTipAccountsResponse, error){
+  return &jito_pb.GetTipAccountsResponse{Accounts: be.TipAccounts}, nil
+}
+
+func (be *BlockEngine) GetRegions(context.Context, *jito_pb.GetRegionsRequest) (*jito_pb.GetRegionsResponse, error){
+  return &jito_pb.GetRegionsResponse{CurrentRegion: be.Region, AvailableRegions: be.Regions}, nil
+}
+
+// Validator service
+
+// SubscribeBundles streams the auction winners to the validator until it disconnects.
+func (be *BlockEngine) SubscribeBundles(_ *jito_pb.SubscribeBundlesRequest, stream jito_pb.BlockEngineValidator_SubscribeBundlesServer) error{
+  sub := make(chan *jito_pb.BundleUuid, 64)
+  identity := subject(stream.Context())
+  be.mu.Lock()
+  be.validators[sub] = identity
+  be.mu.Unlock()
+  be.Logger.Info("validator connected", slog.String("validator", identity))
+  defer func(){
+    be.mu.Lock()
+    delete(be.validators, sub)
+    be.mu.Unlock()
+    be.Logger.Info("validator disconnected", slog.String("validator", identity))
+  }()
+
+  for{
+    select{
+    case <-stream.Context().Done():
+      return nil
+    case bundle := <-sub:
+      if err := stream.Send(&jito_pb.SubscribeBundlesResponse{Bundles: []*jito_pb.BundleUuid{bundle}}); err != nil{
+        return err
+      }
+    }
+  }
+}
+
+// SubscribePackets keeps the stream open without sending packets: the reference block engine only deals in bundles.
+func (be *BlockEngine) SubscribePackets(_ *jito_pb.SubscribePacketsRequestBlockEngine, stream jito_pb.BlockEngineValidator_SubscribePacketsServer) error{
+  <-stream.Context().Done()
+  return nil
+}
+
+func (be *BlockEngine) GetBlockBuilderFeeInfo(context.Context, *jito_pb.BlockBuilderFeeInfoRequest) (*jito_pb.BlockBuilderFeeInfoResponse, error){
+  return &jito_pb.BlockBuilderFeeInfoResponse{Pubkey: be.BlockBuilderPubkey, Commission: be.BlockBuilderCommission}, nil
+}
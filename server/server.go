@@ -0,0 +1,23 @@
+// Package server provides reference implementations of the Jito gRPC services for running a local stack:
+// the auth challenge/token flow, searcher bundle intake with a per-slot auction and bundle forwarding to validators.
+package server
+import(
+  "github.com/scatkit/gojito/pb"
+
+  "google.golang.org/grpc"
+)
+
+// NewGRPCServer returns a gRPC server serving auth and the searcher and validator services of be.
+// When auth is nil the services are served without authentication and bundle results are not routed per searcher.
+func NewGRPCServer(auth *AuthServer, be *BlockEngine, opts ...grpc.ServerOption) *grpc.Server{
+  if auth != nil{
+    opts = append(opts, grpc.ChainUnaryInterceptor(auth.UnaryInterceptor), grpc.ChainStreamInterceptor(auth.StreamInterceptor))
+  }
+  srv := grpc.NewServer(opts...)
+  if auth != nil{
+    jito_pb.RegisterAuthServiceServer(srv, auth)
+  }
+  jito_pb.RegisterSearcherServiceServer(srv, be)
+  jito_pb.RegisterBlockEngineValidatorServer(srv, be)
+  return srv
+}
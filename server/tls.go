@@ -0,0 +1,51 @@
+package server
+import(
+  "crypto/ecdsa"
+  "crypto/elliptic"
+  "crypto/rand"
+  "crypto/tls"
+  "crypto/x509"
+  "crypto/x509/pkix"
+  "math/big"
+  "net"
+  "time"
+)
+
+// SelfSignedTLS returns a server certificate for host and a client config trusting it,
+// for local stacks whose clients insist on TLS.
+func SelfSignedTLS(host string) (tls.Certificate, *tls.Config, error){
+  key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+  if err != nil{
+    return tls.Certificate{}, nil, err
+  }
+  template := &x509.Certificate{
+    SerialNumber: big.NewInt(1),
+    Subject:      pkix.Name{Organization: []string{"gojito"}},
+    DNSNames:     []string{host},
+    IPAddresses:  ipAddresses(host),
+    NotBefore:    time.Now().Add(-time.Hour),
+    NotAfter:     time.Now().Add(24 * time.Hour),
+    KeyUsage:     x509.KeyUsageDigitalSignature,
+    ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+  }
+  der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+  if err != nil{
+    return tls.Certificate{}, nil, err
+  }
+  leaf, err := x509.ParseCertificate(der)
+  if err != nil{
+    return tls.Certificate{}, nil, err
+  }
+
+  pool := x509.NewCertPool()
+  pool.AddCert(leaf)
+  cert := tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}
+  return cert, &tls.Config{RootCAs: pool, ServerName: host}, nil
+}
+
+func ipAddresses(host string) []net.IP{
+  if ip := net.ParseIP(host); ip != nil{
+    return []net.IP{ip}
+  }
+  return nil
+}
@@ -0,0 +1,105 @@
+package shredstream
+import(
+  "context"
+  "crypto/tls"
+  "log/slog"
+  "time"
+
+  "github.com/scatkit/pumpdexer/solana"
+  "github.com/scatkit/gojito/pb"
+  "github.com/scatkit/gojito/pkg"
+  "github.com/scatkit/gojito/tracing"
+
+  "google.golang.org/grpc"
+  "google.golang.org/grpc/credentials"
+)
+
+// minHeartbeatInterval bounds the heartbeat rate when the server advertises a tiny TTL.
+const minHeartbeatInterval = 100 * time.Millisecond
+
+type Client struct{
+  GrpcConn           *grpc.ClientConn
+  ShredstreamService jito_pb.ShredstreamClient
+  Auth               *pkg.AuthenticationService
+  ErrChan            chan error // ErrChan is used for dispatching errors from functions executed within goroutines.
+  Logger             *slog.Logger
+}
+
+// New creates a ShredStream client authenticated with the shredstream subscriber role.
+// The private key must be the one approved for ShredStream access.
+func New(
+  ctx context.Context,
+  grpcDialURL string,
+  privateKey solana.PrivateKey,
+  tlsConfig *tls.Config,
+  opts ...grpc.DialOption,
+) (*Client, error){
+  if tlsConfig != nil{
+    opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+  } else{
+    opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{})))
+  }
+  opts = append(opts, tracing.DialOption())
+
+  chErr := make(chan error)
+  conn, err := pkg.CreateAndObserveGRPCConn(ctx, chErr, grpcDialURL, opts...)
+  if err != nil{
+    return nil, err
+  }
+
+  authService := pkg.NewAuthenticationService(conn, privateKey)
+  if err = authService.AuthenticateAndRefresh(jito_pb.Role_SHREDSTREAM_SUBSCRIBER); err != nil{
+    return nil, err
+  }
+
+  return &Client{
+    GrpcConn:           conn,
+    ShredstreamService: jito_pb.NewShredstreamClient(conn),
+    Auth:               authService,
+    ErrChan:            chErr,
+    Logger:             pkg.NopLogger(),
+  }, nil
+}
+
+// SetLogger sets the structured logger used by the client and its authentication service.
+func (c *Client) SetLogger(logger *slog.Logger){
+  if logger == nil{
+    logger = pkg.NopLogger()
+  }
+  c.Logger = logger
+  c.Auth.Logger = logger
+}
+
+func (c *Client) Close() error{
+  return c.GrpcConn.Close()
+}
+
+// SendHeartbeat advertises the UDP socket shreds should be sent to and the regions to receive them from.
+func (c *Client) SendHeartbeat(ctx context.Context, socket *jito_pb.Socket, regions []string, opts ...grpc.CallOption,
+) (*jito_pb.HeartbeatResponse, error){
+  return c.ShredstreamService.SendHeartbeat(c.Auth.Context(ctx),
+    &jito_pb.HeartbeatShredStream{Socket: socket, Regions: regions}, opts...)
+}
+
+// StartHeartbeat keeps shreds flowing by sending heartbeats until ctx is done. Heartbeats are sent at half
+// the TTL returned by the server; failed heartbeats are logged and retried after a second.
+func (c *Client) StartHeartbeat(ctx context.Context, socket *jito_pb.Socket, regions []string){
+  go func(){
+    for{
+      interval := time.Second
+      resp, err := c.SendHeartbeat(ctx, socket, regions)
+      if err != nil{
+        c.Logger.Warn("shredstream heartbeat failed", slog.Any("regions", regions), slog.Any("error", err))
+      } else{
+        interval = max(time.Duration(resp.TtlMs)*time.Millisecond/2, minHeartbeatInterval)
+        c.Logger.Debug("shredstream heartbeat sent", slog.Any("regions", regions), slog.Uint64("ttl_ms", uint64(resp.TtlMs)))
+      }
+
+      select{
+      case <-ctx.Done():
+        return
+      case <-time.After(interval):
+      }
+    }
+  }()
+}
@@ -0,0 +1,320 @@
+package shredstream
+import(
+  "cmp"
+  "fmt"
+  "log/slog"
+
+  bin "github.com/gagliardetto/binary"
+  "github.com/klauspost/reedsolomon"
+  "github.com/scatkit/pumpdexer/solana"
+  "github.com/scatkit/gojito/pkg"
+)
+
+// DefaultMaxSlots is the number of slots a Deshredder keeps incomplete shreds for.
+const DefaultMaxSlots = 32
+
+// Entry is a ledger entry reassembled from data shreds. Tick entries carry no transactions.
+type Entry struct{
+  Slot         uint64
+  Index        uint64 // position of the entry within its slot
+  NumHashes    uint64
+  Hash         solana.Hash
+  Transactions []*solana.Transaction
+}
+
+// Deshredder groups data shreds by slot and FEC set, recovers missing data shreds from coding shreds
+// and decodes entries as soon as a contiguous batch of data shreds is complete.
+// Entries of a slot are emitted in order. A Deshredder is not safe for concurrent use.
+type Deshredder struct{
+  MaxSlots uint64 // shreds older than the newest slot minus MaxSlots are discarded
+  Logger   *slog.Logger
+
+  slots    map[uint64]*slotShreds
+  highest  uint64
+  encoders map[[2]int]reedsolomon.Encoder
+}
+
+type slotShreds struct{
+  data    map[uint32]*Shred
+  fecSets map[uint32]*fecSet
+  next    uint32 // first data shred index of the next batch to decode
+  entries uint64 // entries decoded so far
+  done    bool
+}
+
+type fecSet struct{
+  data      map[uint32]*Shred // by position in the set
+  code      map[uint16]*Shred // by position in the set
+  numData   int               // known once a coding shred arrived
+  numCoding int
+  recovered bool
+}
+
+func NewDeshredder() *Deshredder{
+  return &Deshredder{
+    MaxSlots: DefaultMaxSlots,
+    Logger:   pkg.NopLogger(),
+    slots:    make(map[uint64]*slotShreds),
+    encoders: make(map[[2]int]reedsolomon.Encoder),
+  }
+}
+
+// Run deshreds shreds until in is closed and publishes the decoded entries on the returned channel,
+// which is closed afterwards. Errors are logged and the offending batch is skipped.
+func (d *Deshredder) Run(in <-chan *Shred, buffer int) <-chan *Entry{
+  out := make(chan *Entry, buffer)
+  go func(){
+    defer close(out)
+    for shred := range in{
+      entries, err := d.Push(shred)
+      if err != nil{
+        d.Logger.Warn("deshred failed", slog.Uint64("slot", shred.Slot), slog.Any("index", shred.Index), slog.Any("error", err))
+      }
+      for _, entry := range entries{
+        out <- entry
+      }
+    }
+  }()
+  return out
+}
+
+// Push adds a shred and returns the entries it completed. Duplicate and trace shreds are ignored.
+// When a batch fails to decode it is skipped and the error is returned along with the entries of the other batches.
+func (d *Deshredder) Push(shred *Shred) ([]*Entry, error){
+  if shred.IsTrace(){
+    return nil, nil
+  }
+  if shred.Slot > d.highest{
+    d.highest = shred.Slot
+    d.evict()
+  }
+  if d.highest > d.MaxSlots && shred.Slot < d.highest-d.MaxSlots{
+    return nil, nil
+  }
+  slot := d.slots[shred.Slot]
+  if slot == nil{
+    slot = &slotShreds{data: make(map[uint32]*Shred), fecSets: make(map[uint32]*fecSet)}
+    d.slots[shred.Slot] = slot
+  }
+  if slot.done{
+    return nil, nil
+  }
+
+  set := slot.fecSets[shred.FECSetIndex]
+  if set == nil{
+    set = &fecSet{data: make(map[uint32]*Shred), code: make(map[uint16]*Shred)}
+    slot.fecSets[shred.FECSetIndex] = set
+  }
+  switch shred.Type{
+  case ShredTypeData:
+    if _, ok := slot.data[shred.Index]; ok || shred.Index < shred.FECSetIndex{
+      return nil, nil
+    }
+    slot.data[shred.Index] = shred
+    set.data[shred.Index-shred.FECSetIndex] = shred
+  case ShredTypeCode:
+    if _, ok := set.code[shred.Position]; ok{
+      return nil, nil
+    }
+    set.code[shred.Position] = shred
+    set.numData, set.numCoding = int(shred.NumData), int(shred.NumCoding)
+  }
+
+  if err := d.recover(slot, set, shred.FECSetIndex); err != nil{
+    d.Logger.Debug("shred recovery failed", slog.Uint64("slot", shred.Slot), slog.Any("fec_set", shred.FECSetIndex), slog.Any("error", err))
+  }
+  return d.decode(shred.Slot, slot)
+}
+
+// evict drops the slots that fell out of the retention window.
+func (d *Deshredder) evict(){
+  if d.highest <= d.MaxSlots{
+    return
+  }
+  for slot := range d.slots{
+    if slot < d.highest-d.MaxSlots{
+      delete(d.slots, slot)
+    }
+  }
+}
+
+// recover reconstructs the missing data shreds of a merkle FEC set once enough shards arrived.
+func (d *Deshredder) recover(slot *slotShreds, set *fecSet, fecSetIndex uint32) error{
+  if set.recovered || set.numData == 0 || len(set.data) >= set.numData || len(set.data)+len(set.code) < set.numData{
+    return nil
+  }
+
+  var ref *Shred
+  for _, shred := range set.code{
+    ref = shred
+    break
+  }
+  if !ref.Merkle{
+    return fmt.Errorf("recovery of legacy shreds is not supported")
+  }
+  shards := make([][]byte, set.numData+set.numCoding)
+  for pos, shred := range set.data{
+    if int(pos) >= set.numData{
+      return fmt.Errorf("data shred position %d exceeds %d data shreds", pos, set.numData)
+    }
+    shard, err := shred.erasureShard()
+    if err != nil{
+      return err
+    }
+    shards[pos] = append([]byte(nil), shard...)
+  }
+  for pos, shred := range set.code{
+    if int(pos) >= set.numCoding{
+      return fmt.Errorf("coding shred position %d exceeds %d coding shreds", pos, set.numCoding)
+    }
+    shard, err := shred.erasureShard()
+    if err != nil{
+      return err
+    }
+    shards[set.numData+int(pos)] = append([]byte(nil), shard...)
+  }
+
+  enc, err := d.encoder(set.numData, set.numCoding)
+  if err != nil{
+    return err
+  }
+  if err = enc.ReconstructData(shards); err != nil{
+    return err
+  }
+  // a failed attempt is retried with the next shred of the set
+  set.recovered = true
+
+  // The shard of a data shred is the shred without its signature and merkle fields.
+  // All shreds of a merkle FEC set are signed with the same signature.
+  for pos := 0; pos < set.numData; pos++{
+    if _, ok := set.data[uint32(pos)]; ok{
+      continue
+    }
+    payload := make([]byte, 0, merkleDataShredSize)
+    payload = append(payload, ref.Raw[:len(solana.Signature{})]...)
+    payload = append(payload, shards[pos]...)
+    payload = append(payload, make([]byte, ref.trailerSize())...)
+    shred, err := ParseShred(payload)
+    if err != nil{
+      return err
+    }
+    if shred.Type != ShredTypeData || shred.Slot != ref.Slot || shred.Index != fecSetIndex+uint32(pos){
+      return fmt.Errorf("recovered shred %d/%d doesn't belong to fec set %d", shred.Slot, shred.Index, fecSetIndex)
+    }
+    shred.ReceivedAt = ref.ReceivedAt
+    set.data[uint32(pos)] = shred
+    if _, ok := slot.data[shred.Index]; !ok{
+      slot.data[shred.Index] = shred
+    }
+  }
+  return nil
+}
+
+func (d *Deshredder) encoder(numData, numCoding int) (reedsolomon.Encoder, error){
+  key := [2]int{numData, numCoding}
+  if enc, ok := d.encoders[key]; ok{
+    return enc, nil
+  }
+  enc, err := reedsolomon.New(numData, numCoding)
+  if err != nil{
+    return nil, err
+  }
+  d.encoders[key] = enc
+  return enc, nil
+}
+
+// decode decodes every contiguous batch of data shreds ending with a data complete shred.
+// A batch that fails to decode is skipped; the first error is returned with the entries of the other batches.
+func (d *Deshredder) decode(slotNum uint64, slot *slotShreds) ([]*Entry, error){
+  var out []*Entry
+  var firstErr error
+  for !slot.done{
+    batch, end, err := slot.batch()
+    if batch == nil && err == nil{
+      break
+    }
+    slot.next = end + 1
+    slot.done = slot.data[end].LastInSlot()
+    if err != nil{
+      firstErr = cmp.Or(firstErr, err)
+      continue
+    }
+
+    entries, err := DecodeEntries(batch)
+    if err != nil{
+      firstErr = cmp.Or(firstErr, fmt.Errorf("decode entries of slot %d: %w", slotNum, err))
+      continue
+    }
+    for _, entry := range entries{
+      entry.Slot, entry.Index = slotNum, slot.entries
+      slot.entries++
+      out = append(out, entry)
+    }
+  }
+  if slot.done{
+    // keep the slot only to ignore late shreds
+    slot.data, slot.fecSets = nil, nil
+  }
+  return out, firstErr
+}
+
+// batch concatenates the data of the shreds from slot.next up to the next data complete shred.
+// It returns a nil batch if a shred in between is still missing.
+func (slot *slotShreds) batch() ([]byte, uint32, error){
+  batch := []byte{}
+  for end := slot.next; ; end++{
+    shred, ok := slot.data[end]
+    if !ok{
+      return nil, 0, nil
+    }
+    data, err := shred.Data()
+    if err != nil{
+      return nil, end, err
+    }
+    batch = append(batch, data...)
+    if shred.DataComplete(){
+      return batch, end, nil
+    }
+  }
+}
+
+// DecodeEntries decodes a batch of entries, i.e. the bincode serialized Vec<Entry> carried by the data shreds
+// from one data complete shred to the next.
+func DecodeEntries(data []byte) ([]*Entry, error){
+  dec := bin.NewBinDecoder(data)
+  count, err := dec.ReadUint64(bin.LE)
+  if err != nil{
+    return nil, err
+  }
+  // every entry takes at least num_hashes + hash + transaction count
+  if count > uint64(dec.Remaining()/48){
+    return nil, fmt.Errorf("entry count %d is too large for %d bytes", count, dec.Remaining())
+  }
+  entries := make([]*Entry, 0, count)
+  for i := uint64(0); i < count; i++{
+    entry := &Entry{}
+    if entry.NumHashes, err = dec.ReadUint64(bin.LE); err != nil{
+      return nil, err
+    }
+    if _, err = dec.Read(entry.Hash[:]); err != nil{
+      return nil, err
+    }
+    numTxs, err := dec.ReadUint64(bin.LE)
+    if err != nil{
+      return nil, err
+    }
+    if numTxs > uint64(dec.Remaining()){
+      return nil, fmt.Errorf("transaction count %d is too large for %d bytes", numTxs, dec.Remaining())
+    }
+    entry.Transactions = make([]*solana.Transaction, 0, numTxs)
+    for j := uint64(0); j < numTxs; j++{
+      tx := new(solana.Transaction)
+      if err = tx.UnmarshalWithDecoder(dec); err != nil{
+        return nil, fmt.Errorf("entry %d transaction %d: %w", i, j, err)
+      }
+      entry.Transactions = append(entry.Transactions, tx)
+    }
+    entries = append(entries, entry)
+  }
+  return entries, nil
+}
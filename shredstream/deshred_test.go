@@ -0,0 +1,96 @@
+package shredstream
+import(
+  "bytes"
+  "encoding/binary"
+  "testing"
+
+  "github.com/klauspost/reedsolomon"
+)
+
+// merkleFECSet crafts the data and coding shreds of the merkle FEC set 0 of slot, with the parity computed like the
+// leader does.
+func merkleFECSet(t *testing.T, slot uint64, numData, numCoding int) (data, code []*Shred){
+  t.Helper()
+  shards := make([][]byte, numData+numCoding)
+  var raws [][]byte
+  for i := 0; i < numData; i++{
+    raw := shredSpec{variant: 0x86, slot: slot, index: uint32(i), size: merkleDataShredSize,
+      parentOffset: 1, dataSize: DataHeaderSize, typ: ShredTypeData, merkle: true, proofSize: 6}.craft()
+    binary.LittleEndian.PutUint32(raw[79:83], 0) // fec set index
+    raws = append(raws, raw)
+  }
+  shardSize := merkleCodeShredSize - CodingHeaderSize - 6*merkleProofEntrySize
+  for i, raw := range raws{
+    shards[i] = raw[64 : 64+shardSize]
+  }
+  for i := numData; i < len(shards); i++{
+    shards[i] = make([]byte, shardSize)
+  }
+  enc, err := reedsolomon.New(numData, numCoding)
+  if err != nil{
+    t.Fatal(err)
+  }
+  if err = enc.Encode(shards); err != nil{
+    t.Fatal(err)
+  }
+
+  for _, raw := range raws{
+    shred, err := ParseShred(raw)
+    if err != nil{
+      t.Fatal(err)
+    }
+    data = append(data, shred)
+  }
+  for i := 0; i < numCoding; i++{
+    raw := shredSpec{variant: 0x46, slot: slot, index: uint32(i), size: merkleCodeShredSize,
+      numData: uint16(numData), numCoding: uint16(numCoding), position: uint16(i), typ: ShredTypeCode, merkle: true, proofSize: 6}.craft()
+    binary.LittleEndian.PutUint32(raw[79:83], 0)
+    copy(raw[CodingHeaderSize:], shards[numData+i])
+    shred, err := ParseShred(raw)
+    if err != nil{
+      t.Fatal(err)
+    }
+    code = append(code, shred)
+  }
+  return data, code
+}
+
+func TestDeshredderRecoversMissingDataShred(t *testing.T){
+  data, code := merkleFECSet(t, 310_000_010, 2, 2)
+  d := NewDeshredder()
+  for _, shred := range []*Shred{data[0], code[1]}{
+    if _, err := d.Push(shred); err != nil{
+      t.Fatal(err)
+    }
+  }
+  recovered := d.slots[310_000_010].data[1]
+  if recovered == nil{
+    t.Fatal("data shred 1 not recovered")
+  }
+  if !bytes.Equal(recovered.Raw[:merkleDataShredSize-6*merkleProofEntrySize], data[1].Raw[:merkleDataShredSize-6*merkleProofEntrySize]){
+    t.Error("recovered shred differs from the original")
+  }
+}
+
+func TestDeshredderRetriesFailedRecovery(t *testing.T){
+  data, code := merkleFECSet(t, 310_000_011, 2, 2)
+  // a coding shred announcing fewer coding shreds than its position fails the first attempt
+  bogus := *code[1]
+  bogus.NumCoding = 1
+  d := NewDeshredder()
+  for _, shred := range []*Shred{data[0], &bogus}{
+    if _, err := d.Push(shred); err != nil{
+      t.Fatal(err)
+    }
+  }
+  if d.slots[310_000_011].data[1] != nil{
+    t.Fatal("data shred 1 recovered from an inconsistent set")
+  }
+
+  if _, err := d.Push(code[0]); err != nil{
+    t.Fatal(err)
+  }
+  if d.slots[310_000_011].data[1] == nil{
+    t.Fatal("data shred 1 not recovered after a failed attempt")
+  }
+}
@@ -0,0 +1,87 @@
+package shredstream
+import(
+  "errors"
+  "log/slog"
+  "net"
+  "sync/atomic"
+  "time"
+
+  "github.com/scatkit/gojito/pkg"
+)
+
+// Receiver listens for shreds forwarded by ShredStream and publishes them on Shreds.
+type Receiver struct{
+  Shreds chan *Shred // closed once the receiver stops
+  Logger *slog.Logger
+
+  conn     *net.UDPConn
+  received atomic.Uint64
+  dropped  atomic.Uint64
+  invalid  atomic.Uint64
+}
+
+// Listen binds a UDP socket on addr (e.g. "0.0.0.0:20000"). buffer sizes the Shreds channel;
+// shreds that don't fit are dropped rather than blocking the socket.
+func Listen(addr string, buffer int) (*Receiver, error){
+  udpAddr, err := net.ResolveUDPAddr("udp", addr)
+  if err != nil{
+    return nil, err
+  }
+  conn, err := net.ListenUDP("udp", udpAddr)
+  if err != nil{
+    return nil, err
+  }
+  r := &Receiver{
+    Shreds: make(chan *Shred, buffer),
+    Logger: pkg.NopLogger(),
+    conn:   conn,
+  }
+  go r.run()
+  return r, nil
+}
+
+// LocalAddr is the bound UDP address, useful when listening on port 0.
+func (r *Receiver) LocalAddr() *net.UDPAddr{
+  return r.conn.LocalAddr().(*net.UDPAddr)
+}
+
+func (r *Receiver) Close() error{
+  return r.conn.Close()
+}
+
+// Stats returns the number of received, dropped (channel full) and unparsable packets.
+func (r *Receiver) Stats() (received, dropped, invalid uint64){
+  return r.received.Load(), r.dropped.Load(), r.invalid.Load()
+}
+
+func (r *Receiver) run(){
+  defer close(r.Shreds)
+  buf := make([]byte, MaxShredSize+1)
+  for{
+    n, _, err := r.conn.ReadFromUDP(buf)
+    if err != nil{
+      if !errors.Is(err, net.ErrClosed){
+        r.Logger.Warn("shred receiver stopped", slog.Any("error", err))
+      }
+      return
+    }
+    receivedAt := time.Now()
+    r.received.Add(1)
+
+    packet := make([]byte, n)
+    copy(packet, buf[:n])
+    shred, err := ParseShred(packet)
+    if err != nil{
+      r.invalid.Add(1)
+      r.Logger.Debug("invalid shred", slog.Int("size", n), slog.Any("error", err))
+      continue
+    }
+    shred.ReceivedAt = receivedAt
+
+    select{
+    case r.Shreds <- shred:
+    default:
+      r.dropped.Add(1)
+    }
+  }
+}
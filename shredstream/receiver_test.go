@@ -0,0 +1,167 @@
+package shredstream
+import(
+  "encoding/binary"
+  "net"
+  "testing"
+  "time"
+)
+
+type shredSpec struct{
+  name    string
+  variant byte
+  slot    uint64
+  index   uint32
+  size    int // packet size
+
+  // data shreds
+  parentOffset uint16
+  flags        byte
+  dataSize     uint16
+
+  // coding shreds
+  numData, numCoding, position uint16
+
+  typ       ShredType
+  merkle    bool
+  proofSize uint8
+  chained   bool
+  resigned  bool
+}
+
+// craft lays out the headers of spec like solana-ledger does; the payload is left zeroed.
+func (spec shredSpec) craft() []byte{
+  packet := make([]byte, spec.size)
+  for i := 0; i < 64; i++{
+    packet[i] = byte(i)
+  }
+  packet[64] = spec.variant
+  binary.LittleEndian.PutUint64(packet[65:73], spec.slot)
+  binary.LittleEndian.PutUint32(packet[73:77], spec.index)
+  binary.LittleEndian.PutUint16(packet[77:79], 50093)
+  binary.LittleEndian.PutUint32(packet[79:83], spec.index)
+  if spec.typ == ShredTypeData{
+    binary.LittleEndian.PutUint16(packet[83:85], spec.parentOffset)
+    packet[85] = spec.flags
+    binary.LittleEndian.PutUint16(packet[86:88], spec.dataSize)
+  } else{
+    binary.LittleEndian.PutUint16(packet[83:85], spec.numData)
+    binary.LittleEndian.PutUint16(packet[85:87], spec.numCoding)
+    binary.LittleEndian.PutUint16(packet[87:89], spec.position)
+  }
+  return packet
+}
+
+var shredSpecs = []shredSpec{
+  {name: "legacy data", variant: 0xa5, slot: 310_000_001, index: 0, size: MaxShredSize,
+    parentOffset: 1, flags: FlagDataComplete, dataSize: 200, typ: ShredTypeData},
+  {name: "legacy code", variant: 0x5a, slot: 310_000_001, index: 1, size: MaxShredSize,
+    numData: 32, numCoding: 32, position: 3, typ: ShredTypeCode},
+  {name: "merkle data", variant: 0x86, slot: 310_000_002, index: 2, size: merkleDataShredSize,
+    parentOffset: 2, flags: FlagLastInSlot, dataSize: 300, typ: ShredTypeData, merkle: true, proofSize: 6},
+  {name: "merkle code", variant: 0x46, slot: 310_000_002, index: 3, size: merkleCodeShredSize,
+    numData: 16, numCoding: 16, position: 7, typ: ShredTypeCode, merkle: true, proofSize: 6},
+  {name: "chained merkle data", variant: 0x96, slot: 310_000_003, index: 4, size: merkleDataShredSize,
+    parentOffset: 1, dataSize: 100, typ: ShredTypeData, merkle: true, proofSize: 6, chained: true},
+  {name: "resigned merkle code", variant: 0x75, slot: 310_000_003, index: 5, size: merkleCodeShredSize,
+    numData: 8, numCoding: 8, position: 1, typ: ShredTypeCode, merkle: true, proofSize: 5, chained: true, resigned: true},
+  {name: "resigned merkle data", variant: 0xb5, slot: 310_000_003, index: 6, size: merkleDataShredSize,
+    parentOffset: 1, dataSize: 100, typ: ShredTypeData, merkle: true, proofSize: 5, chained: true, resigned: true},
+}
+
+func TestReceiverParsesLoopbackShreds(t *testing.T){
+  r, err := Listen("127.0.0.1:0", len(shredSpecs))
+  if err != nil{
+    t.Fatal(err)
+  }
+  defer r.Close()
+  conn, err := net.DialUDP("udp", nil, r.LocalAddr())
+  if err != nil{
+    t.Fatal(err)
+  }
+  defer conn.Close()
+
+  for _, spec := range shredSpecs{
+    if _, err := conn.Write(spec.craft()); err != nil{
+      t.Fatal(err)
+    }
+  }
+  // not a shred: counted as invalid
+  if _, err := conn.Write([]byte{1, 2, 3}); err != nil{
+    t.Fatal(err)
+  }
+
+  got := make(map[uint32]*Shred)
+  timeout := time.After(2 * time.Second)
+  for len(got) < len(shredSpecs){
+    select{
+    case s := <-r.Shreds:
+      got[s.Index] = s
+    case <-timeout:
+      t.Fatalf("received %d of %d shreds", len(got), len(shredSpecs))
+    }
+  }
+
+  for _, spec := range shredSpecs{
+    s := got[spec.index]
+    if s == nil{
+      t.Errorf("%s: not received", spec.name)
+      continue
+    }
+    if s.Slot != spec.slot || s.Variant != spec.variant || s.Type != spec.typ{
+      t.Errorf("%s: slot %d variant 0x%02x type %s, want %d 0x%02x %s", spec.name, s.Slot, s.Variant, s.Type, spec.slot, spec.variant, spec.typ)
+    }
+    if s.Merkle != spec.merkle || s.ProofSize != spec.proofSize || s.Chained != spec.chained || s.Resigned != spec.resigned{
+      t.Errorf("%s: merkle %v proof %d chained %v resigned %v", spec.name, s.Merkle, s.ProofSize, s.Chained, s.Resigned)
+    }
+    if s.Version != 50093 || s.FECSetIndex != spec.index || s.Signature[63] != 63 || len(s.Raw) != spec.size || s.ReceivedAt.IsZero(){
+      t.Errorf("%s: common header %+v", spec.name, s)
+    }
+    switch spec.typ{
+    case ShredTypeData:
+      if s.ParentOffset != spec.parentOffset || s.Flags != spec.flags || s.Size != spec.dataSize{
+        t.Errorf("%s: parent offset %d flags 0x%02x size %d", spec.name, s.ParentOffset, s.Flags, s.Size)
+      }
+      if s.ParentSlot() != spec.slot-uint64(spec.parentOffset){
+        t.Errorf("%s: parent slot %d", spec.name, s.ParentSlot())
+      }
+    case ShredTypeCode:
+      if s.NumData != spec.numData || s.NumCoding != spec.numCoding || s.Position != spec.position{
+        t.Errorf("%s: data %d coding %d position %d", spec.name, s.NumData, s.NumCoding, s.Position)
+      }
+    }
+  }
+  if !got[0].DataComplete() || got[0].LastInSlot() || !got[2].LastInSlot(){
+    t.Errorf("data shred flags not reported")
+  }
+
+  // the invalid packet may still be in flight after the last shred
+  deadline := time.Now().Add(time.Second)
+  for{
+    received, dropped, invalid := r.Stats()
+    if received == uint64(len(shredSpecs))+1 && invalid == 1{
+      if dropped != 0{
+        t.Errorf("dropped %d shreds", dropped)
+      }
+      break
+    }
+    if time.Now().After(deadline){
+      t.Fatalf("stats: received %d dropped %d invalid %d", received, dropped, invalid)
+    }
+    time.Sleep(10 * time.Millisecond)
+  }
+}
+
+func TestParseShredRejectsInvalidPackets(t *testing.T){
+  if _, err := ParseShred(make([]byte, CommonHeaderSize-1)); err != ErrShredTooShort{
+    t.Errorf("short packet: %v", err)
+  }
+  legacyData := shredSpecs[0].craft()
+  if _, err := ParseShred(legacyData[:DataHeaderSize-1]); err != ErrShredTooShort{
+    t.Errorf("truncated data header: %v", err)
+  }
+  bad := shredSpecs[0].craft()
+  bad[64] = 0x10
+  if _, err := ParseShred(bad); err == nil{
+    t.Error("invalid variant parsed")
+  }
+}
@@ -0,0 +1,203 @@
+// Package shredstream subscribes to Jito ShredStream and receives the shreds it forwards over UDP.
+package shredstream
+import(
+  "encoding/binary"
+  "errors"
+  "fmt"
+  "time"
+
+  "github.com/scatkit/pumpdexer/solana"
+)
+
+const (
+  // Shred header layout (see solana-ledger shred.rs):
+  // common: signature (64) | variant (1) | slot (8) | index (4) | version (2) | fec_set_index (4)
+  // data:   parent_offset (2) | flags (1) | size (2)
+  // coding: num_data_shreds (2) | num_coding_shreds (2) | position (2)
+  CommonHeaderSize = 83
+  DataHeaderSize   = CommonHeaderSize + 5
+  CodingHeaderSize = CommonHeaderSize + 6
+
+  // MaxShredSize bounds a shred packet (PACKET_DATA_SIZE).
+  MaxShredSize = 1232
+
+  // Merkle shred payload sizes; data shreds are shorter so both erasure shards have the same size.
+  // Merkle shreds end with: chained merkle root (32, chained) | merkle proof (20 * proof size) | retransmitter signature (64, resigned)
+  merkleCodeShredSize  = 1228
+  merkleDataShredSize  = 1203
+  merkleProofEntrySize = 20
+  merkleRootSize       = 32
+)
+
+// Data shred flags.
+const (
+  FlagReferenceTickMask byte = 0x3f
+  FlagDataComplete      byte = 0x40
+  FlagLastInSlot        byte = 0xc0
+)
+
+type ShredType uint8
+
+const (
+  ShredTypeData ShredType = iota + 1
+  ShredTypeCode
+)
+
+func (t ShredType) String() string{
+  switch t{
+  case ShredTypeData:
+    return "data"
+  case ShredTypeCode:
+    return "code"
+  default:
+    return "unknown"
+  }
+}
+
+var ErrShredTooShort = errors.New("shred is too short")
+
+// Shred is a parsed shred header together with the raw packet.
+type Shred struct{
+  Signature   solana.Signature
+  Variant     byte
+  Type        ShredType
+  Merkle      bool
+  ProofSize   uint8 // number of merkle proof entries (merkle shreds only)
+  Chained     bool
+  Resigned    bool
+  Slot        uint64
+  Index       uint32
+  Version     uint16
+  FECSetIndex uint32
+
+  // data shreds
+  ParentOffset uint16
+  Flags        byte
+  Size         uint16 // size of the header + data payload
+
+  // coding shreds
+  NumData   uint16
+  NumCoding uint16
+  Position  uint16
+
+  Raw        []byte
+  ReceivedAt time.Time
+}
+
+// DataComplete reports whether the data shred ends a batch of entries.
+func (s *Shred) DataComplete() bool{ return s.Type == ShredTypeData && s.Flags&FlagDataComplete != 0 }
+
+// LastInSlot reports whether the data shred is the last one of its slot.
+func (s *Shred) LastInSlot() bool{ return s.Type == ShredTypeData && s.Flags&FlagLastInSlot == FlagLastInSlot }
+
+// ParentSlot returns the slot this shred's slot builds on (data shreds only).
+func (s *Shred) ParentSlot() uint64{ return s.Slot - uint64(s.ParentOffset) }
+
+// trailerSize is the size of the merkle fields following the erasure shard.
+func (s *Shred) trailerSize() int{
+  n := int(s.ProofSize) * merkleProofEntrySize
+  if s.Chained{
+    n += merkleRootSize
+  }
+  if s.Resigned{
+    n += len(solana.Signature{})
+  }
+  return n
+}
+
+// erasureShardSize is the size of the Reed-Solomon shard carried by the merkle shreds of this shred's FEC set.
+func (s *Shred) erasureShardSize() int{
+  return merkleCodeShredSize - CodingHeaderSize - s.trailerSize()
+}
+
+// erasureShard returns the Reed-Solomon shard of a merkle shred: everything between the signature and the merkle
+// fields for data shreds, the parity bytes following the headers for coding shreds.
+func (s *Shred) erasureShard() ([]byte, error){
+  offset := len(solana.Signature{})
+  if s.Type == ShredTypeCode{
+    offset = CodingHeaderSize
+  }
+  end := offset + s.erasureShardSize()
+  if !s.Merkle || len(s.Raw) < end{
+    return nil, fmt.Errorf("shred %d/%d has no erasure shard", s.Slot, s.Index)
+  }
+  return s.Raw[offset:end], nil
+}
+
+// Data returns the entry bytes carried by a data shred.
+func (s *Shred) Data() ([]byte, error){
+  if s.Type != ShredTypeData{
+    return nil, fmt.Errorf("shred %d/%d is not a data shred", s.Slot, s.Index)
+  }
+  end := len(s.Raw)
+  if s.Merkle{
+    end = merkleDataShredSize - s.trailerSize()
+  }
+  if int(s.Size) < DataHeaderSize || int(s.Size) > end || int(s.Size) > len(s.Raw){
+    return nil, fmt.Errorf("shred %d/%d has invalid size %d", s.Slot, s.Index, s.Size)
+  }
+  return s.Raw[DataHeaderSize:s.Size], nil
+}
+
+// parseVariant decodes the ShredVariant byte.
+func (s *Shred) parseVariant() error{
+  switch v := s.Variant; {
+  case v == 0x5a:
+    s.Type = ShredTypeCode
+  case v == 0xa5:
+    s.Type = ShredTypeData
+  default:
+    s.Merkle, s.ProofSize = true, v&0x0f
+    switch v & 0xf0{
+    case 0x40:
+      s.Type = ShredTypeCode
+    case 0x60:
+      s.Type, s.Chained = ShredTypeCode, true
+    case 0x70:
+      s.Type, s.Chained, s.Resigned = ShredTypeCode, true, true
+    case 0x80:
+      s.Type = ShredTypeData
+    case 0x90:
+      s.Type, s.Chained = ShredTypeData, true
+    case 0xb0:
+      s.Type, s.Chained, s.Resigned = ShredTypeData, true, true
+    default:
+      return fmt.Errorf("invalid shred variant 0x%02x", v)
+    }
+  }
+  return nil
+}
+
+// ParseShred parses the headers of a shred packet. The returned shred references data.
+func ParseShred(data []byte) (*Shred, error){
+  if len(data) < CommonHeaderSize{
+    return nil, ErrShredTooShort
+  }
+  s := &Shred{Variant: data[64], Raw: data}
+  copy(s.Signature[:], data[:64])
+  if err := s.parseVariant(); err != nil{
+    return nil, err
+  }
+  s.Slot = binary.LittleEndian.Uint64(data[65:73])
+  s.Index = binary.LittleEndian.Uint32(data[73:77])
+  s.Version = binary.LittleEndian.Uint16(data[77:79])
+  s.FECSetIndex = binary.LittleEndian.Uint32(data[79:83])
+
+  switch s.Type{
+  case ShredTypeData:
+    if len(data) < DataHeaderSize{
+      return nil, ErrShredTooShort
+    }
+    s.ParentOffset = binary.LittleEndian.Uint16(data[83:85])
+    s.Flags = data[85]
+    s.Size = binary.LittleEndian.Uint16(data[86:88])
+  case ShredTypeCode:
+    if len(data) < CodingHeaderSize{
+      return nil, ErrShredTooShort
+    }
+    s.NumData = binary.LittleEndian.Uint16(data[83:85])
+    s.NumCoding = binary.LittleEndian.Uint16(data[85:87])
+    s.Position = binary.LittleEndian.Uint16(data[87:89])
+  }
+  return s, nil
+}
@@ -0,0 +1,190 @@
+package shredstream
+import(
+  "cmp"
+  "fmt"
+  "math"
+  "slices"
+  "sync"
+  "time"
+
+  "github.com/scatkit/gojito/pb"
+  "google.golang.org/protobuf/proto"
+)
+
+// TraceShredSlot is the slot of trace shreds: data shreds sent by every ShredStream region whose
+// payload is a protobuf encoded TraceShred rather than entries.
+const TraceShredSlot = math.MaxUint64
+
+// DefaultTraceWindow is the number of latency samples kept per region.
+const DefaultTraceWindow = 1024
+
+// IsTrace reports whether the shred is a trace shred.
+func (s *Shred) IsTrace() bool{ return s.Type == ShredTypeData && s.Slot == TraceShredSlot }
+
+// DecodeTraceShred decodes the TraceShred carried by a trace shred.
+func DecodeTraceShred(s *Shred) (*jito_pb.TraceShred, error){
+  if !s.IsTrace(){
+    return nil, fmt.Errorf("shred %d/%d is not a trace shred", s.Slot, s.Index)
+  }
+  data, err := s.Data()
+  if err != nil{
+    return nil, err
+  }
+  trace := new(jito_pb.TraceShred)
+  if err = proto.Unmarshal(data, trace); err != nil{
+    return nil, err
+  }
+  return trace, nil
+}
+
+// RegionLatency summarizes the one-way latency of the trace shreds received from a region.
+// Latencies are computed from the sender's clock and are only as accurate as the clock synchronization.
+type RegionLatency struct{
+  Region   string
+  Received uint64 // trace shreds received
+  Missed   uint64 // sequence numbers skipped
+  Restarts uint64 // sequence resets, i.e. restarts of the region's trace service
+  Last     time.Time
+  Samples  int // latency samples the percentiles are computed from
+  Min      time.Duration
+  P50      time.Duration
+  P90      time.Duration
+  P99      time.Duration
+  Max      time.Duration
+}
+
+// LossRate is the fraction of trace shreds that never arrived.
+func (l *RegionLatency) LossRate() float64{
+  if l.Received+l.Missed == 0{
+    return 0
+  }
+  return float64(l.Missed) / float64(l.Received+l.Missed)
+}
+
+// TraceMonitor measures the shred propagation latency of each ShredStream region from trace shreds.
+// It is safe for concurrent use.
+type TraceMonitor struct{
+  mu      sync.Mutex
+  window  int
+  regions map[string]*regionTrace
+}
+
+type regionTrace struct{
+  samples  []time.Duration // ring buffer of the latest window samples
+  next     int
+  seq      uint32
+  received uint64
+  missed   uint64
+  restarts uint64
+  last     time.Time
+}
+
+// NewTraceMonitor keeps the latest window latency samples per region (DefaultTraceWindow if window <= 0).
+func NewTraceMonitor(window int) *TraceMonitor{
+  if window <= 0{
+    window = DefaultTraceWindow
+  }
+  return &TraceMonitor{window: window, regions: make(map[string]*regionTrace)}
+}
+
+// Observe records the shred if it is a trace shred and reports whether it was one,
+// so the caller can keep trace shreds away from the Deshredder.
+func (m *TraceMonitor) Observe(s *Shred) bool{
+  if !s.IsTrace(){
+    return false
+  }
+  trace, err := DecodeTraceShred(s)
+  if err != nil{
+    return true
+  }
+  receivedAt := s.ReceivedAt
+  if receivedAt.IsZero(){
+    receivedAt = time.Now()
+  }
+  m.Record(trace, receivedAt)
+  return true
+}
+
+// Record adds a decoded trace shred received at receivedAt.
+func (m *TraceMonitor) Record(trace *jito_pb.TraceShred, receivedAt time.Time){
+  m.mu.Lock()
+  defer m.mu.Unlock()
+
+  region := m.regions[trace.Region]
+  if region == nil{
+    region = &regionTrace{samples: make([]time.Duration, 0, m.window)}
+    m.regions[trace.Region] = region
+  }
+  switch{
+  case region.received == 0:
+  case trace.SeqNum == region.seq:
+    return // duplicate
+  case trace.SeqNum < region.seq:
+    region.restarts++
+  default:
+    region.missed += uint64(trace.SeqNum - region.seq - 1)
+  }
+  region.seq = trace.SeqNum
+  region.received++
+  region.last = receivedAt
+
+  if trace.CreatedAt == nil{
+    return
+  }
+  latency := receivedAt.Sub(trace.CreatedAt.AsTime())
+  if len(region.samples) < m.window{
+    region.samples = append(region.samples, latency)
+  } else{
+    region.samples[region.next] = latency
+  }
+  region.next = (region.next + 1) % m.window
+}
+
+// Stats returns the latency of every region seen so far, fastest (by median) first.
+// Regions without latency samples come last.
+func (m *TraceMonitor) Stats() []RegionLatency{
+  m.mu.Lock()
+  defer m.mu.Unlock()
+
+  stats := make([]RegionLatency, 0, len(m.regions))
+  for name, region := range m.regions{
+    stat := RegionLatency{
+      Region:   name,
+      Received: region.received,
+      Missed:   region.missed,
+      Restarts: region.restarts,
+      Last:     region.last,
+      Samples:  len(region.samples),
+    }
+    if len(region.samples) > 0{
+      sorted := slices.Clone(region.samples)
+      slices.Sort(sorted)
+      stat.Min, stat.Max = sorted[0], sorted[len(sorted)-1]
+      stat.P50, stat.P90, stat.P99 = percentile(sorted, 50), percentile(sorted, 90), percentile(sorted, 99)
+    }
+    stats = append(stats, stat)
+  }
+  slices.SortFunc(stats, func(a, b RegionLatency) int{
+    if (a.Samples == 0) != (b.Samples == 0){
+      return cmp.Compare(b.Samples, a.Samples)
+    }
+    return cmp.Or(cmp.Compare(a.P50, b.P50), cmp.Compare(a.Region, b.Region))
+  })
+  return stats
+}
+
+// Fastest returns the region with the lowest median latency.
+func (m *TraceMonitor) Fastest() (string, bool){
+  for _, stat := range m.Stats(){
+    if stat.Samples > 0{
+      return stat.Region, true
+    }
+  }
+  return "", false
+}
+
+// percentile returns the nearest-rank percentile p of sorted samples.
+func percentile(sorted []time.Duration, p int) time.Duration{
+  rank := (p*len(sorted) + 99) / 100
+  return sorted[max(rank-1, 0)]
+}
@@ -0,0 +1,86 @@
+// Package tracing holds the OpenTelemetry helpers used to trace a bundle from build to landing.
+// Spans are created through the global tracer provider, so nothing is recorded or exported
+// until the application installs one (otel.SetTracerProvider).
+package tracing
+import(
+  "context"
+  "fmt"
+  "net/http"
+  
+  "go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+  "go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+  "go.opentelemetry.io/otel"
+  "go.opentelemetry.io/otel/attribute"
+  "go.opentelemetry.io/otel/codes"
+  "go.opentelemetry.io/otel/trace"
+  "google.golang.org/grpc"
+  
+  "github.com/scatkit/gojito/pb"
+)
+
+const InstrumentationName = "github.com/scatkit/gojito"
+
+// Span attribute keys.
+const(
+  BundleUUIDKey  = attribute.Key("jito.bundle.uuid")
+  TipLamportsKey = attribute.Key("jito.bundle.tip_lamports")
+  RegionKey      = attribute.Key("jito.region")
+  SignaturesKey  = attribute.Key("solana.signatures")
+  SlotKey        = attribute.Key("solana.slot")
+  ValidatorKey   = attribute.Key("solana.validator")
+)
+
+// Tracer returns the tracer used for all gojito spans.
+func Tracer() trace.Tracer{
+  return otel.Tracer(InstrumentationName)
+}
+
+// Start starts a span named name as a child of the span in ctx (if any).
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span){
+  return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records err on span (if non-nil) and ends it.
+func End(span trace.Span, err error){
+  if err != nil{
+    span.RecordError(err)
+    span.SetStatus(codes.Error, err.Error())
+  }
+  span.End()
+}
+
+// DialOption instruments a gRPC client connection with the otelgrpc stats handler.
+func DialOption() grpc.DialOption{
+  return grpc.WithStatsHandler(otelgrpc.NewClientHandler())
+}
+
+// HTTPClient returns an HTTP client whose transport creates client spans and propagates trace context.
+func HTTPClient() *http.Client{
+  return &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)}
+}
+
+// WithSpan returns parent carrying the span context found in ctx. It is used to attach the caller's trace to
+// long-lived contexts such as the authenticated gRPC context, which hold metadata but no span.
+func WithSpan(parent, ctx context.Context) context.Context{
+  return trace.ContextWithSpanContext(parent, trace.SpanContextFromContext(ctx))
+}
+
+// RecordBundleResult adds a result-stream event (accepted, rejected, processed, ...) to span.
+func RecordBundleResult(span trace.Span, result *jito_pb.BundleResult){
+  attrs := []attribute.KeyValue{BundleUUIDKey.String(result.GetBundleId())}
+  switch r := result.GetResult().(type){
+  case *jito_pb.BundleResult_Accepted:
+    attrs = append(attrs, SlotKey.Int64(int64(r.Accepted.GetSlot())), ValidatorKey.String(r.Accepted.GetValidatorIdentity()))
+    span.AddEvent("accepted", trace.WithAttributes(attrs...))
+  case *jito_pb.BundleResult_Rejected:
+    span.AddEvent("rejected", trace.WithAttributes(append(attrs, attribute.String("jito.rejection", fmt.Sprintf("%T", r.Rejected.GetReason())))...))
+  case *jito_pb.BundleResult_Dropped:
+    span.AddEvent("dropped", trace.WithAttributes(append(attrs, attribute.String("jito.dropped_reason", r.Dropped.GetReason().String()))...))
+  case *jito_pb.BundleResult_Processed:
+    attrs = append(attrs, SlotKey.Int64(int64(r.Processed.GetSlot())), ValidatorKey.String(r.Processed.GetValidatorIdentity()),
+      attribute.Int64("jito.bundle.index", int64(r.Processed.GetBundleIndex())))
+    span.AddEvent("processed", trace.WithAttributes(attrs...))
+  case *jito_pb.BundleResult_Finalized:
+    span.AddEvent("finalized", trace.WithAttributes(attrs...))
+  }
+}